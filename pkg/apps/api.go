@@ -72,6 +72,12 @@ func (a *Apps) handleAPI(app starr.App, api APIHandler) http.HandlerFunc { //nol
 			msg = fmt.Errorf("%v: %w", aID, ErrNoReadarr)
 		case app == starr.Sonarr && (aID >= len(a.Sonarr) || aID < 0):
 			msg = fmt.Errorf("%v: %w", aID, ErrNoSonarr)
+		case app == starr.Whisparr && (aID >= len(a.Whisparr) || aID < 0):
+			msg = fmt.Errorf("%v: %w", aID, ErrNoWhisparr)
+		case app == Bazarr && (aID >= len(a.Bazarr) || aID < 0):
+			msg = fmt.Errorf("%v: %w", aID, ErrNoBazarr)
+		case app == starr.Plex && (aID >= len(a.Plex) || aID < 0):
+			msg = fmt.Errorf("%v: %w", aID, ErrNoPlex)
 			// Store the application configuration (starr) in a context then pass that into the api() method.
 			// Retrieve the return code and output, and send a response via a.Respond().
 		case app == starr.Lidarr:
@@ -84,6 +90,13 @@ func (a *Apps) handleAPI(app starr.App, api APIHandler) http.HandlerFunc { //nol
 			code, msg = api(r.WithContext(context.WithValue(ctx, app, a.Readarr[aID])))
 		case app == starr.Sonarr:
 			code, msg = api(r.WithContext(context.WithValue(ctx, app, a.Sonarr[aID])))
+		case app == starr.Whisparr:
+			// Whisparr uses the Radarr handlers, so store it where they look for it.
+			code, msg = api(r.WithContext(context.WithValue(ctx, starr.Radarr, (*RadarrConfig)(a.Whisparr[aID]))))
+		case app == Bazarr:
+			code, msg = api(r.WithContext(context.WithValue(ctx, app, a.Bazarr[aID])))
+		case app == starr.Plex:
+			code, msg = api(r.WithContext(context.WithValue(ctx, app, a.Plex[aID])))
 		case app == "":
 			// no app, just run the handler.
 			code, msg = api(r) // unknown app, just run the handler.
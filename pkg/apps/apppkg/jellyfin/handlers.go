@@ -0,0 +1,105 @@
+package jellyfin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// HandleSessions provides a web handler to the notifiarr client that
+// returns the current Jellyfin sessions. The handler satisfies apps.APIHandler.
+// @Description  Returns Jellyfin or Emby sessions directly from the server. Only sessions playing something are returned.
+// @Summary      Retrieve Jellyfin sessions.
+// @Tags         Jellyfin
+// @Produce      json
+// @Success      200  {object} apps.Respond.apiResponse{message=Sessions} "current sessions"
+// @Failure      500  {object} apps.Respond.apiResponse{message=string} "Jellyfin error"
+// @Failure      404  {object} string "bad token or api key"
+// @Router       /api/jellyfin/1/sessions [get]
+// @Security     ApiKeyAuth
+func (s *Server) HandleSessions(r *http.Request) (int, interface{}) {
+	jellyID, _ := r.Context().Value(App).(int)
+
+	sessions, err := s.GetSessionsWithContext(r.Context())
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("unable to get sessions (%d): %w", jellyID, err)
+	}
+
+	return http.StatusOK, sessions
+}
+
+// HandleKillSession provides a web handler to the notifiarr client that
+// allows notifiarr.com (via Discord request) to end a Jellyfin session.
+// @Description  Stops a Jellyfin or Emby session by ID and sends a message to the user.
+// @Summary      Kill a Jellyfin session.
+// @Tags         Jellyfin
+// @Produce      json
+// @Param        sessionId  query   string  true  "Jellyfin session ID"
+// @Param        reason     query   string  true  "Reason the session is being terminated. Sent to the user."
+// @Success      200  {object} apps.Respond.apiResponse{message=string} "success"
+// @Failure      500  {object} apps.Respond.apiResponse{message=string} "Jellyfin error"
+// @Failure      404  {object} string "bad token or api key"
+// @Router       /api/jellyfin/1/kill [get]
+// @Security     ApiKeyAuth
+func (s *Server) HandleKillSession(r *http.Request) (int, interface{}) {
+	var (
+		ctx        = r.Context()
+		jellyID, _ = ctx.Value(App).(int)
+		sessionID  = mux.Vars(r)["sessionId"]
+		reason     = mux.Vars(r)["reason"]
+	)
+
+	_, err := s.KillSessionWithContext(ctx, sessionID, reason)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("unable to kill session (%s@%d): %w", sessionID, jellyID, err)
+	}
+
+	return http.StatusOK, fmt.Sprintf("kilt session '%s' with reason: %s", sessionID, reason)
+}
+
+// HandleDirectory provides a web handler to the notifiarr client that
+// returns the Jellyfin library directory.
+// @Description  Returns the Jellyfin or Emby Library Directory (virtual folders).
+// @Summary      Retrieve the Jellyfin Library Directory.
+// @Tags         Jellyfin
+// @Produce      json
+// @Success      200  {object} apps.Respond.apiResponse{message=[]Library} "Jellyfin Library Directory"
+// @Failure      500  {object} apps.Respond.apiResponse{message=string} "Jellyfin error"
+// @Failure      404  {object} string "bad token or api key"
+// @Router       /api/jellyfin/1/directory [get]
+// @Security     ApiKeyAuth
+func (s *Server) HandleDirectory(r *http.Request) (int, interface{}) {
+	jellyID, _ := r.Context().Value(App).(int)
+
+	directory, err := s.GetDirectoryWithContext(r.Context())
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("directory request failed (%d): %w", jellyID, err)
+	}
+
+	return http.StatusOK, directory
+}
+
+// HandleMarkWatched provides a web handler to the notifiarr client that
+// marks an item as watched for a user.
+// @Description  Marks a movie or episode or audio track as watched for a user.
+// @Summary      Mark a Jellyfin item as watched.
+// @Tags         Jellyfin
+// @Produce      json
+// @Param        userId  path    string true  "Jellyfin User ID"
+// @Param        itemId  path    string true  "Jellyfin Item ID"
+// @Success      200  {object} apps.Respond.apiResponse{message=string} "ok"
+// @Failure      500  {object} apps.Respond.apiResponse{message=string} "Jellyfin error"
+// @Failure      404  {object} string "bad token or api key"
+// @Router       /api/jellyfin/1/markwatched/{userId}/{itemId} [get]
+// @Security     ApiKeyAuth
+func (s *Server) HandleMarkWatched(r *http.Request) (int, interface{}) {
+	jellyID, _ := r.Context().Value(App).(int)
+
+	body, err := s.MarkPlayedWithContext(r.Context(), mux.Vars(r)["userId"], mux.Vars(r)["itemId"])
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("mark watch failed (%d): %w", jellyID, err)
+	}
+
+	return http.StatusOK, "ok: " + string(body)
+}
@@ -0,0 +1,49 @@
+//nolint:tagliatelle
+package jellyfin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Info is the /System/Info path on Jellyfin and Emby.
+type Info struct {
+	ID                     string `json:"Id"`
+	ServerName             string `json:"ServerName"`
+	ProductName            string `json:"ProductName"`
+	Version                string `json:"Version"`
+	OperatingSystem        string `json:"OperatingSystem"`
+	LocalAddress           string `json:"LocalAddress"`
+	StartupWizardCompleted bool   `json:"StartupWizardCompleted"`
+	HasPendingRestart      bool   `json:"HasPendingRestart"`
+	HasUpdateAvailable     bool   `json:"HasUpdateAvailable"`
+	IsShuttingDown         bool   `json:"IsShuttingDown"`
+	CanSelfRestart         bool   `json:"CanSelfRestart"`
+	WebSocketPortNumber    int    `json:"WebSocketPortNumber"`
+	TranscodingTempPath    string `json:"TranscodingTempPath"`
+	LogPath                string `json:"LogPath"`
+	CachePath              string `json:"CachePath"`
+}
+
+// GetInfo retrieves Jellyfin Server Info, no timeout.
+func (s *Server) GetInfo() (*Info, error) {
+	return s.GetInfoWithContext(context.Background())
+}
+
+// GetInfoWithContext retrieves Jellyfin Server Info. This also sets the friendly name, so s.Name() works.
+func (s *Server) GetInfoWithContext(ctx context.Context) (*Info, error) {
+	body, err := s.getURL(ctx, "/System/Info", nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, string(body))
+	}
+
+	var info Info
+	if err := json.Unmarshal(body, &info); err != nil {
+		return nil, fmt.Errorf("unmarshaling system info from %s: %w", s.config.URL, err)
+	}
+
+	s.name = info.ServerName
+
+	return &info, nil
+}
@@ -0,0 +1,109 @@
+package jellyfin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Sessions is the list of active sessions on a server.
+type Sessions struct {
+	Name     string     `json:"server"`
+	Sessions []*Session `json:"sessions"`
+}
+
+// killMessageTimeout is how long the kill message stays on the user's screen.
+const killMessageTimeout = 10000
+
+// GetSessions returns the sessions that are playing something, no timeout.
+func (s *Server) GetSessions() (*Sessions, error) {
+	return s.GetSessionsWithContext(context.Background())
+}
+
+// GetSessionsWithContext returns the sessions that are playing something.
+// Idle sessions (connected clients without a playing item) are not returned.
+func (s *Server) GetSessionsWithContext(ctx context.Context) (*Sessions, error) {
+	sessions := &Sessions{Name: s.name}
+
+	body, err := s.getURL(ctx, "/Sessions", nil)
+	if err != nil {
+		return sessions, fmt.Errorf("%w: %s", err, string(body))
+	}
+
+	var output []*Session
+	if err = json.Unmarshal(body, &output); err != nil {
+		return sessions, fmt.Errorf("parsing sessions: %w: %s", err, string(body))
+	}
+
+	sessions.Sessions = make([]*Session, 0, len(output))
+
+	for _, session := range output {
+		if session.NowPlayingItem != nil {
+			sessions.Sessions = append(sessions.Sessions, session)
+		}
+	}
+
+	return sessions, nil
+}
+
+// KillSessionWithContext sends a message to a session and then stops playback.
+func (s *Server) KillSessionWithContext(ctx context.Context, sessionID, reason string) ([]byte, error) {
+	if reason != "" {
+		msg, _ := json.Marshal(map[string]interface{}{
+			"Header":    "Playback Stopped",
+			"Text":      reason,
+			"TimeoutMs": killMessageTimeout,
+		})
+
+		// Not every client supports messages, so ignore this error and stop the session anyway.
+		_, _ = s.postURL(ctx, "/Sessions/"+sessionID+"/Message", nil, bytes.NewReader(msg))
+	}
+
+	body, err := s.postURL(ctx, "/Sessions/"+sessionID+"/Playing/Stop", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, string(body))
+	}
+
+	return body, nil
+}
+
+// KillSession sends a message to a session and then stops playback.
+func (s *Server) KillSession(sessionID, reason string) ([]byte, error) {
+	return s.KillSessionWithContext(context.Background(), sessionID, reason)
+}
+
+// MarkPlayedWithContext marks an item as played for a user.
+func (s *Server) MarkPlayedWithContext(ctx context.Context, userID, itemID string) ([]byte, error) {
+	body, err := s.postURL(ctx, "/Users/"+userID+"/PlayedItems/"+itemID, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, string(body))
+	}
+
+	return body, nil
+}
+
+// MarkPlayed marks an item as played for a user.
+func (s *Server) MarkPlayed(userID, itemID string) ([]byte, error) {
+	return s.MarkPlayedWithContext(context.Background(), userID, itemID)
+}
+
+// GetDirectoryWithContext returns the libraries (virtual folders) on the server.
+func (s *Server) GetDirectoryWithContext(ctx context.Context) ([]*Library, error) {
+	body, err := s.getURL(ctx, "/Library/VirtualFolders", nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, string(body))
+	}
+
+	var output []*Library
+	if err = json.Unmarshal(body, &output); err != nil {
+		return nil, fmt.Errorf("parsing libraries: %w: %s", err, string(body))
+	}
+
+	return output, nil
+}
+
+// GetDirectory returns the libraries (virtual folders) on the server.
+func (s *Server) GetDirectory() ([]*Library, error) {
+	return s.GetDirectoryWithContext(context.Background())
+}
@@ -0,0 +1,155 @@
+//nolint:tagliatelle
+package jellyfin
+
+import (
+	"strings"
+	"time"
+)
+
+/* This file contains all the types for the Jellyfin Sessions and Library API responses. */
+
+// TicksPerSecond is how many Jellyfin/Emby "ticks" make a second. Durations and positions use ticks.
+const TicksPerSecond = 10000000
+
+// Session is a Jellyfin or Emby session.
+type Session struct {
+	ID                 string       `json:"Id"`
+	UserID             string       `json:"UserId"`
+	UserName           string       `json:"UserName"`
+	Client             string       `json:"Client"`
+	DeviceID           string       `json:"DeviceId"`
+	DeviceName         string       `json:"DeviceName"`
+	ApplicationVersion string       `json:"ApplicationVersion"`
+	RemoteEndPoint     string       `json:"RemoteEndPoint"`
+	LastActivityDate   time.Time    `json:"LastActivityDate"`
+	IsActive           bool         `json:"IsActive"`
+	PlayState          PlayState    `json:"PlayState"`
+	NowPlayingItem     *Item        `json:"NowPlayingItem,omitempty"`
+	TranscodingInfo    *Transcoding `json:"TranscodingInfo,omitempty"`
+}
+
+// PlayState is part of a Session.
+type PlayState struct {
+	PositionTicks int64  `json:"PositionTicks"`
+	CanSeek       bool   `json:"CanSeek"`
+	IsPaused      bool   `json:"IsPaused"`
+	IsMuted       bool   `json:"IsMuted"`
+	PlayMethod    string `json:"PlayMethod"`
+	RepeatMode    string `json:"RepeatMode"`
+}
+
+// Item is a media item, like a movie or episode.
+type Item struct {
+	ID                string            `json:"Id"`
+	Name              string            `json:"Name"`
+	OriginalTitle     string            `json:"OriginalTitle"`
+	ServerID          string            `json:"ServerId"`
+	Type              string            `json:"Type"`
+	MediaType         string            `json:"MediaType"`
+	Path              string            `json:"Path"`
+	Overview          string            `json:"Overview"`
+	Container         string            `json:"Container"`
+	PremiereDate      string            `json:"PremiereDate"`
+	OfficialRating    string            `json:"OfficialRating"`
+	CommunityRating   float64           `json:"CommunityRating"`
+	ProductionYear    int               `json:"ProductionYear"`
+	RunTimeTicks      int64             `json:"RunTimeTicks"`
+	IndexNumber       int64             `json:"IndexNumber"`
+	ParentIndexNumber int64             `json:"ParentIndexNumber"`
+	SeriesName        string            `json:"SeriesName"`
+	SeriesID          string            `json:"SeriesId"`
+	SeasonName        string            `json:"SeasonName"`
+	SeasonID          string            `json:"SeasonId"`
+	Album             string            `json:"Album"`
+	AlbumArtist       string            `json:"AlbumArtist"`
+	Artists           []string          `json:"Artists"`
+	Genres            []string          `json:"Genres"`
+	ProviderIDs       map[string]string `json:"ProviderIds"`
+}
+
+// Transcoding is part of a Session. It only exists when the session is transcoding.
+type Transcoding struct {
+	AudioCodec       string   `json:"AudioCodec"`
+	VideoCodec       string   `json:"VideoCodec"`
+	Container        string   `json:"Container"`
+	IsVideoDirect    bool     `json:"IsVideoDirect"`
+	IsAudioDirect    bool     `json:"IsAudioDirect"`
+	Bitrate          int64    `json:"Bitrate"`
+	Framerate        float64  `json:"Framerate"`
+	CompletionPct    float64  `json:"CompletionPercentage"`
+	Width            int      `json:"Width"`
+	Height           int      `json:"Height"`
+	AudioChannels    int      `json:"AudioChannels"`
+	HardwareAccel    string   `json:"HardwareAccelerationType"`
+	TranscodeReasons []string `json:"TranscodeReasons"`
+}
+
+// Library is a virtual folder from the Library Directory.
+type Library struct {
+	Name               string   `json:"Name"`
+	ItemID             string   `json:"ItemId"`
+	CollectionType     string   `json:"CollectionType"`
+	Locations          []string `json:"Locations"`
+	RefreshStatus      string   `json:"RefreshStatus"`
+	RefreshProgress    float64  `json:"RefreshProgress"`
+	PrimaryImageItemID string   `json:"PrimaryImageItemId"`
+}
+
+// Type returns the lowercase item type, like "movie" or "episode". This matches Plex types.
+// Returns an empty string if nothing is playing.
+func (s *Session) Type() string {
+	if s.NowPlayingItem == nil {
+		return ""
+	}
+
+	return strings.ToLower(s.NowPlayingItem.Type)
+}
+
+// Title returns the title of the playing item. Episodes include the series name.
+func (s *Session) Title() string {
+	switch {
+	case s.NowPlayingItem == nil:
+		return ""
+	case s.NowPlayingItem.SeriesName != "":
+		return s.NowPlayingItem.SeriesName + " - " + s.NowPlayingItem.Name
+	default:
+		return s.NowPlayingItem.Name
+	}
+}
+
+// Duration returns the runtime of the playing item.
+func (s *Session) Duration() time.Duration {
+	if s.NowPlayingItem == nil {
+		return 0
+	}
+
+	return time.Duration(s.NowPlayingItem.RunTimeTicks) * (time.Second / TicksPerSecond)
+}
+
+// Position returns the play position of the playing item.
+func (s *Session) Position() time.Duration {
+	return time.Duration(s.PlayState.PositionTicks) * (time.Second / TicksPerSecond)
+}
+
+// Percent returns how much of the playing item has been played. 0-100.
+func (s *Session) Percent() float64 {
+	if s.NowPlayingItem == nil || s.NowPlayingItem.RunTimeTicks == 0 {
+		return 0
+	}
+
+	return float64(s.PlayState.PositionTicks) / float64(s.NowPlayingItem.RunTimeTicks) * 100 //nolint:mnd
+}
+
+// Playing returns true if the session is playing something and not paused.
+func (s *Session) Playing() bool {
+	return s.NowPlayingItem != nil && !s.PlayState.IsPaused
+}
+
+// ItemID returns the ID of the playing item.
+func (s *Session) ItemID() string {
+	if s.NowPlayingItem == nil {
+		return ""
+	}
+
+	return s.NowPlayingItem.ID
+}
@@ -0,0 +1,139 @@
+//nolint:tagliatelle
+package jellyfin
+
+import (
+	"strings"
+)
+
+// IncomingWebhook is the incoming webhook from an Emby server or the Jellyfin webhook plugin.
+// Emby sends nested objects. The Jellyfin plugin sends a flat payload, so those fields are included too.
+// Call Normalize() after decoding to fill in the Emby fields from the Jellyfin fields.
+type IncomingWebhook struct {
+	Title       string `json:"Title,omitempty"`
+	Description string `json:"Description,omitempty"`
+	Date        string `json:"Date,omitempty"`
+	Event       string `json:"Event"`
+	Severity    string `json:"Severity,omitempty"`
+	User        struct {
+		Name string `json:"Name"`
+		ID   string `json:"Id"`
+	} `json:"User"`
+	Server struct {
+		Name    string `json:"Name"`
+		ID      string `json:"Id"`
+		Version string `json:"Version"`
+	} `json:"Server"`
+	Session struct {
+		ID             string `json:"Id"`
+		Client         string `json:"Client"`
+		DeviceName     string `json:"DeviceName"`
+		DeviceID       string `json:"DeviceId"`
+		RemoteEndPoint string `json:"RemoteEndPoint"`
+	} `json:"Session"`
+	PlaybackInfo struct {
+		PositionTicks      int64  `json:"PositionTicks"`
+		PlayedToCompletion bool   `json:"PlayedToCompletion"`
+		PlaySessionID      string `json:"PlaySessionId,omitempty"`
+	} `json:"PlaybackInfo"`
+	Item *Item `json:"Item,omitempty"`
+	// These are from the Jellyfin webhook plugin's default json template.
+	NotificationType      string `json:"NotificationType,omitempty"`
+	NotificationUsername  string `json:"NotificationUsername,omitempty"`
+	UserID                string `json:"UserId,omitempty"`
+	ServerID              string `json:"ServerId,omitempty"`
+	ServerName            string `json:"ServerName,omitempty"`
+	ServerVersion         string `json:"ServerVersion,omitempty"`
+	ItemID                string `json:"ItemId,omitempty"`
+	ItemType              string `json:"ItemType,omitempty"`
+	Name                  string `json:"Name,omitempty"`
+	SeriesName            string `json:"SeriesName,omitempty"`
+	Year                  int    `json:"Year,omitempty"`
+	RunTimeTicks          int64  `json:"RunTimeTicks,omitempty"`
+	PlaybackPositionTicks int64  `json:"PlaybackPositionTicks,omitempty"`
+	PlayedToCompletion    bool   `json:"PlayedToCompletion,omitempty"`
+	DeviceID              string `json:"DeviceId,omitempty"`
+	DeviceName            string `json:"DeviceName,omitempty"`
+	ClientName            string `json:"ClientName,omitempty"`
+}
+
+// jellyfinEvents maps Jellyfin plugin notification types to Emby event names.
+var jellyfinEvents = map[string]string{ //nolint:gochecknoglobals
+	"playbackstart":    "playback.start",
+	"playbackstop":     "playback.stop",
+	"playbackprogress": "playback.progress",
+	"itemadded":        "library.new",
+	"itemdeleted":      "library.deleted",
+	"useritemsaved":    "item.rate",
+	"pendingrestart":   "system.serverrestartrequired",
+	"authfailure":      "user.authenticationfailed",
+	"sessionstart":     "session.start",
+	"pluginupdated":    "plugins.pluginupdated",
+}
+
+// Normalize fills in the Emby fields from the Jellyfin plugin fields, so either server looks the same.
+// The event name is also lowercased, and Jellyfin notification types become Emby event names.
+func (w *IncomingWebhook) Normalize() {
+	if w.Event == "" {
+		w.Event = w.NotificationType
+		if event, ok := jellyfinEvents[strings.ToLower(w.NotificationType)]; ok {
+			w.Event = event
+		}
+	}
+
+	w.Event = strings.ToLower(w.Event)
+
+	if w.User.Name == "" {
+		w.User.Name = w.NotificationUsername
+		w.User.ID = w.UserID
+	}
+
+	if w.Server.ID == "" {
+		w.Server.Name = w.ServerName
+		w.Server.ID = w.ServerID
+		w.Server.Version = w.ServerVersion
+	}
+
+	if w.Session.DeviceName == "" {
+		w.Session.DeviceName = w.DeviceName
+		w.Session.DeviceID = w.DeviceID
+		w.Session.Client = w.ClientName
+	}
+
+	if w.PlaybackInfo.PositionTicks == 0 {
+		w.PlaybackInfo.PositionTicks = w.PlaybackPositionTicks
+		w.PlaybackInfo.PlayedToCompletion = w.PlayedToCompletion
+	}
+
+	if w.Item == nil && w.ItemID != "" {
+		w.Item = &Item{
+			ID:             w.ItemID,
+			Name:           w.Name,
+			Type:           w.ItemType,
+			SeriesName:     w.SeriesName,
+			ProductionYear: w.Year,
+			RunTimeTicks:   w.RunTimeTicks,
+			ServerID:       w.ServerID,
+		}
+	}
+}
+
+// ItemName returns the name of the item in the webhook, if there is one.
+func (w *IncomingWebhook) ItemName() string {
+	switch {
+	case w.Item == nil:
+		return ""
+	case w.Item.SeriesName != "":
+		return w.Item.SeriesName + " - " + w.Item.Name
+	default:
+		return w.Item.Name
+	}
+}
+
+// ItemKey returns a unique key for the item in the webhook; useful for cooldowns.
+func (w *IncomingWebhook) ItemKey() string {
+	if w.Item == nil {
+		return w.Session.ID
+	}
+
+	return w.Item.ID + w.User.ID
+}
@@ -0,0 +1,62 @@
+// Package jsonapi sends requests to the JSON APIs of the small apps in apppkg.
+// Jellyfin, Emby, Overseerr and Jellyseerr all authenticate with an API key header,
+// so they share this request code.
+package jsonapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// ErrBadStatus is returned when the server returns an invalid status code.
+var ErrBadStatus = errors.New("status code not 2xx")
+
+// Request is an http request to an app's API.
+type Request struct {
+	Method string
+	URL    string
+	Params url.Values
+	// KeyHeader is the header name the API key is sent in.
+	KeyHeader string
+	APIKey    string
+	// Body is sent as JSON when it's not nil.
+	Body io.Reader
+}
+
+// Do sends a request and returns the response body.
+// The body is also returned with ErrBadStatus when the response status code is not 2xx.
+func Do(ctx context.Context, client *http.Client, request *Request) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, request.Method, request.URL, request.Body)
+	if err != nil {
+		return nil, fmt.Errorf("creating http request: %w", err)
+	}
+
+	req.URL.RawQuery = request.Params.Encode()
+	req.Header.Set(request.KeyHeader, request.APIKey)
+	req.Header.Set("Accept", "application/json")
+
+	if request.Body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("making http request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading http response: %w", err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return body, fmt.Errorf("%w: %s", ErrBadStatus, resp.Status)
+	}
+
+	return body, nil
+}
@@ -0,0 +1,51 @@
+package jsonapi_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/jsonapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDo(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Header.Get("X-Api-Key") != "secret":
+			resp.WriteHeader(http.StatusUnauthorized)
+		case req.Method == http.MethodPost && req.Header.Get("Content-Type") != "application/json":
+			resp.WriteHeader(http.StatusBadRequest)
+		default:
+			_, _ = resp.Write([]byte(req.Method + " " + req.URL.Path + "?" + req.URL.RawQuery))
+		}
+	}))
+	defer server.Close()
+
+	request := &jsonapi.Request{
+		Method:    http.MethodGet,
+		URL:       server.URL + "/api/v1/request",
+		Params:    url.Values{"take": []string{"10"}},
+		KeyHeader: "X-Api-Key",
+		APIKey:    "secret",
+	}
+
+	body, err := jsonapi.Do(context.Background(), server.Client(), request)
+	require.NoError(t, err)
+	assert.Equal(t, "GET /api/v1/request?take=10", string(body))
+
+	request.Method, request.Body = http.MethodPost, bytes.NewBufferString("{}")
+	body, err = jsonapi.Do(context.Background(), server.Client(), request)
+	require.NoError(t, err, "a body must be sent as json")
+	assert.Equal(t, "POST /api/v1/request?take=10", string(body))
+
+	request.APIKey, request.Body = "wrong", nil
+	_, err = jsonapi.Do(context.Background(), server.Client(), request)
+	require.ErrorIs(t, err, jsonapi.ErrBadStatus)
+}
@@ -0,0 +1,84 @@
+package overseerr
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// HandlePending provides a web handler to the notifiarr client that
+// returns the pending Overseerr requests. The handler satisfies apps.APIHandler.
+// @Description  Returns pending (unapproved) Overseerr or Jellyseerr requests, newest first.
+// @Description  Older pending requests are left out when there are more than take of them.
+// @Summary      Retrieve pending Overseerr requests.
+// @Tags         Overseerr
+// @Produce      json
+// @Param        take  query   int  false  "Number of newest pending requests to return. Default 50."
+// @Success      200  {object} apps.Respond.apiResponse{message=Requests} "pending requests"
+// @Failure      500  {object} apps.Respond.apiResponse{message=string} "Overseerr error"
+// @Failure      404  {object} string "bad token or api key"
+// @Router       /api/overseerr/1/pending [get]
+// @Security     ApiKeyAuth
+func (s *Server) HandlePending(r *http.Request) (int, interface{}) {
+	seerrID, _ := r.Context().Value(App).(int)
+	take, _ := strconv.Atoi(r.URL.Query().Get("take"))
+
+	requests, err := s.GetPendingWithContext(r.Context(), take)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("unable to get pending requests (%d): %w", seerrID, err)
+	}
+
+	return http.StatusOK, requests
+}
+
+// HandleUpdateRequest provides a web handler to the notifiarr client that
+// allows notifiarr.com (via Discord request) to approve or decline a request.
+// @Description  Approves or declines a pending Overseerr or Jellyseerr request.
+// @Summary      Approve or decline an Overseerr request.
+// @Tags         Overseerr
+// @Produce      json
+// @Param        requestID  path   int64   true  "Overseerr request ID"
+// @Param        action     path   string  true  "approve or decline"
+// @Success      200  {object} apps.Respond.apiResponse{message=MediaRequest} "updated request"
+// @Failure      500  {object} apps.Respond.apiResponse{message=string} "Overseerr error"
+// @Failure      404  {object} string "bad token or api key"
+// @Router       /api/overseerr/1/request/{requestID}/{action} [post]
+// @Security     ApiKeyAuth
+func (s *Server) HandleUpdateRequest(r *http.Request) (int, interface{}) {
+	var (
+		seerrID, _   = r.Context().Value(App).(int)
+		requestID, _ = strconv.ParseInt(mux.Vars(r)["requestID"], 10, 64)
+		action       = mux.Vars(r)["action"]
+	)
+
+	request, err := s.UpdateRequestWithContext(r.Context(), requestID, action)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("unable to %s request %d (%d): %w", action, requestID, seerrID, err)
+	}
+
+	return http.StatusOK, request
+}
+
+// HandleCounts provides a web handler to the notifiarr client that
+// returns the Overseerr request counts.
+// @Description  Returns the Overseerr or Jellyseerr request counts: pending, approved, declined, etc.
+// @Summary      Retrieve Overseerr request counts.
+// @Tags         Overseerr
+// @Produce      json
+// @Success      200  {object} apps.Respond.apiResponse{message=RequestCounts} "request counts"
+// @Failure      500  {object} apps.Respond.apiResponse{message=string} "Overseerr error"
+// @Failure      404  {object} string "bad token or api key"
+// @Router       /api/overseerr/1/counts [get]
+// @Security     ApiKeyAuth
+func (s *Server) HandleCounts(r *http.Request) (int, interface{}) {
+	seerrID, _ := r.Context().Value(App).(int)
+
+	counts, err := s.GetCountsWithContext(r.Context())
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("unable to get request counts (%d): %w", seerrID, err)
+	}
+
+	return http.StatusOK, counts
+}
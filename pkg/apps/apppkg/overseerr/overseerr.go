@@ -0,0 +1,87 @@
+// Package overseerr provides the methods the Notifiarr client uses to interface with Overseerr and Jellyseerr.
+// Jellyseerr is a fork of Overseerr with the same API, so one package works for either of them.
+// This package provides handlers for requests from Notifiarr.com to list, approve and decline media requests.
+// This package can be disabled by not providing a URL or API Key.
+package overseerr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/jsonapi"
+	"golift.io/starr"
+)
+
+// App is the name used for this app in API paths and request contexts.
+const App starr.App = "Overseerr"
+
+// Server is the Overseerr (or Jellyseerr) configuration from a config file.
+// Without a URL or API Key, nothing works and this package is unused.
+type Server struct {
+	config Config
+}
+
+type Config struct {
+	URL    string       `json:"url"    toml:"url"     xml:"url"`
+	APIKey string       `json:"apiKey" toml:"api_key" xml:"api_key"`
+	Client *http.Client `json:"-"      toml:"-"       xml:"-"`
+}
+
+// New turns a config into a server.
+func New(config *Config) *Server {
+	if config.Client == nil {
+		config.Client = &http.Client{
+			Timeout: time.Minute,
+		}
+	}
+
+	return &Server{
+		config: *config,
+	}
+}
+
+// Errors returned by this package.
+var (
+	// ErrNoURLKey is returned when there is no api key or URL.
+	ErrNoURLKey = errors.New("api key or URL for Overseerr missing")
+	// ErrBadStatus is returned when the server returns an invalid status code.
+	ErrBadStatus = jsonapi.ErrBadStatus
+)
+
+func (s *Server) getURL(ctx context.Context, uri string, params url.Values) ([]byte, error) {
+	return s.reqURL(ctx, uri, http.MethodGet, params, nil)
+}
+
+func (s *Server) postURL(ctx context.Context, uri string, params url.Values, postData io.Reader) ([]byte, error) {
+	return s.reqURL(ctx, uri, http.MethodPost, params, postData)
+}
+
+func (s *Server) reqURL(
+	ctx context.Context,
+	uri, method string,
+	params url.Values,
+	sendData io.Reader,
+) ([]byte, error) {
+	if s.config.URL == "" || s.config.APIKey == "" {
+		return nil, ErrNoURLKey
+	}
+
+	body, err := jsonapi.Do(ctx, s.config.Client, &jsonapi.Request{
+		Method:    method,
+		URL:       s.config.URL + "/api/v1" + uri,
+		Params:    params,
+		KeyHeader: "X-Api-Key",
+		APIKey:    s.config.APIKey,
+		Body:      sendData,
+	})
+	if err != nil {
+		return body, fmt.Errorf("%s %s: %w", method, uri, err)
+	}
+
+	return body, nil
+}
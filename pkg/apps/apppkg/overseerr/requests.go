@@ -0,0 +1,162 @@
+package overseerr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Request statuses. These are the status values on a MediaRequest.
+const (
+	StatusPending  = 1
+	StatusApproved = 2
+	StatusDeclined = 3
+)
+
+// Actions that may be taken on a pending request.
+const (
+	ActionApprove = "approve"
+	ActionDecline = "decline"
+)
+
+// defaultTake is how many pending requests are returned when no count is provided.
+const defaultTake = 50
+
+// Requests is the paged list of media requests.
+type Requests struct {
+	PageInfo struct {
+		Pages    int `json:"pages"`
+		PageSize int `json:"pageSize"`
+		Results  int `json:"results"`
+		Page     int `json:"page"`
+	} `json:"pageInfo"`
+	Results []*MediaRequest `json:"results"`
+}
+
+// MediaRequest is a single movie or series request.
+type MediaRequest struct {
+	ID         int64     `json:"id"`
+	Status     int       `json:"status"`
+	Type       string    `json:"type"`
+	Is4k       bool      `json:"is4k"`
+	ServerID   int64     `json:"serverId"`
+	ProfileID  int64     `json:"profileId"`
+	RootFolder string    `json:"rootFolder"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+	Media      *Media    `json:"media"`
+	Seasons    []*struct {
+		ID           int64 `json:"id"`
+		SeasonNumber int   `json:"seasonNumber"`
+		Status       int   `json:"status"`
+	} `json:"seasons,omitempty"`
+	RequestedBy *User `json:"requestedBy"`
+	ModifiedBy  *User `json:"modifiedBy,omitempty"`
+}
+
+// Media is the media item attached to a request.
+type Media struct {
+	ID        int64  `json:"id"`
+	MediaType string `json:"mediaType"`
+	TmdbID    int64  `json:"tmdbId"`
+	TvdbID    int64  `json:"tvdbId"`
+	ImdbID    string `json:"imdbId"`
+	Status    int    `json:"status"`
+	Status4k  int    `json:"status4k"`
+}
+
+// User is an Overseerr user that made or modified a request.
+type User struct {
+	ID           int64  `json:"id"`
+	Email        string `json:"email"`
+	Username     string `json:"username"`
+	PlexUsername string `json:"plexUsername"`
+	DisplayName  string `json:"displayName"`
+	UserType     int    `json:"userType"`
+}
+
+// RequestCounts is returned by the request count endpoint.
+type RequestCounts struct {
+	Total      int64 `json:"total"`
+	Movie      int64 `json:"movie"`
+	TV         int64 `json:"tv"`
+	Pending    int64 `json:"pending"`
+	Approved   int64 `json:"approved"`
+	Declined   int64 `json:"declined"`
+	Processing int64 `json:"processing"`
+	Available  int64 `json:"available"`
+}
+
+// GetPendingWithContext returns the newest take pending requests, newest first.
+// Older pending requests are not returned when there are more than take of them.
+func (s *Server) GetPendingWithContext(ctx context.Context, take int) (*Requests, error) {
+	if take < 1 {
+		take = defaultTake
+	}
+
+	params := make(url.Values)
+	params.Set("take", strconv.Itoa(take))
+	params.Set("skip", "0")
+	params.Set("filter", "pending")
+	params.Set("sort", "added")
+
+	body, err := s.getURL(ctx, "/request", params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, string(body))
+	}
+
+	var output Requests
+	if err = json.Unmarshal(body, &output); err != nil {
+		return nil, fmt.Errorf("parsing requests: %w: %s", err, string(body))
+	}
+
+	return &output, nil
+}
+
+// GetPending returns the newest take pending requests, newest first.
+func (s *Server) GetPending(take int) (*Requests, error) {
+	return s.GetPendingWithContext(context.Background(), take)
+}
+
+// UpdateRequestWithContext approves or declines a request. Use ActionApprove or ActionDecline.
+func (s *Server) UpdateRequestWithContext(ctx context.Context, requestID int64, action string) (*MediaRequest, error) {
+	body, err := s.postURL(ctx, "/request/"+strconv.FormatInt(requestID, 10)+"/"+action, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, string(body))
+	}
+
+	var output MediaRequest
+	if err = json.Unmarshal(body, &output); err != nil {
+		return nil, fmt.Errorf("parsing request: %w: %s", err, string(body))
+	}
+
+	return &output, nil
+}
+
+// UpdateRequest approves or declines a request. Use ActionApprove or ActionDecline.
+func (s *Server) UpdateRequest(requestID int64, action string) (*MediaRequest, error) {
+	return s.UpdateRequestWithContext(context.Background(), requestID, action)
+}
+
+// GetCountsWithContext returns the request counts.
+func (s *Server) GetCountsWithContext(ctx context.Context) (*RequestCounts, error) {
+	body, err := s.getURL(ctx, "/request/count", nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, string(body))
+	}
+
+	var output RequestCounts
+	if err = json.Unmarshal(body, &output); err != nil {
+		return nil, fmt.Errorf("parsing request counts: %w: %s", err, string(body))
+	}
+
+	return &output, nil
+}
+
+// GetCounts returns the request counts.
+func (s *Server) GetCounts() (*RequestCounts, error) {
+	return s.GetCountsWithContext(context.Background())
+}
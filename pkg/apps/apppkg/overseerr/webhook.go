@@ -0,0 +1,56 @@
+//nolint:tagliatelle
+package overseerr
+
+// IncomingWebhook is the incoming webhook from Overseerr or Jellyseerr using the default json payload template.
+type IncomingWebhook struct {
+	NotificationType string `json:"notification_type"`
+	Event            string `json:"event"`
+	Subject          string `json:"subject"`
+	Message          string `json:"message"`
+	Image            string `json:"image"`
+	Media            *struct {
+		MediaType string `json:"media_type"`
+		TmdbID    string `json:"tmdbId"`
+		TvdbID    string `json:"tvdbId"`
+		Status    string `json:"status"`
+		Status4k  string `json:"status4k"`
+	} `json:"media,omitempty"`
+	Request *struct {
+		RequestID string `json:"request_id"`
+		Email     string `json:"requestedBy_email"`
+		Username  string `json:"requestedBy_username"`
+		Avatar    string `json:"requestedBy_avatar"`
+	} `json:"request,omitempty"`
+	Issue *struct {
+		IssueID   string `json:"issue_id"`
+		IssueType string `json:"issue_type"`
+		Status    string `json:"issue_status"`
+		Email     string `json:"reportedBy_email"`
+		Username  string `json:"reportedBy_username"`
+		Avatar    string `json:"reportedBy_avatar"`
+	} `json:"issue,omitempty"`
+	Comment *struct {
+		Message  string `json:"comment_message"`
+		Email    string `json:"commentedBy_email"`
+		Username string `json:"commentedBy_username"`
+		Avatar   string `json:"commentedBy_avatar"`
+	} `json:"comment,omitempty"`
+	Extra []*struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"extra,omitempty"`
+}
+
+// Username returns the user that made the request, reported the issue or left the comment.
+func (w *IncomingWebhook) Username() string {
+	switch {
+	case w.Comment != nil && w.Comment.Username != "":
+		return w.Comment.Username
+	case w.Issue != nil && w.Issue.Username != "":
+		return w.Issue.Username
+	case w.Request != nil:
+		return w.Request.Username
+	default:
+		return ""
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"net/http"
 
 	"github.com/gorilla/mux"
-	"golift.io/starr"
 )
 
 // HandleSessions provides a web handler to the notifiarr client that
@@ -20,11 +19,11 @@ import (
 // @Router       /api/plex/1/sessions [get]
 // @Security     ApiKeyAuth
 func (s *Server) HandleSessions(r *http.Request) (int, interface{}) {
-	plexID, _ := r.Context().Value(starr.Plex).(int)
+	plexID := mux.Vars(r)["id"]
 
 	sessions, err := s.GetSessionsWithContext(r.Context())
 	if err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("unable to get sessions (%d): %w", plexID, err)
+		return http.StatusInternalServerError, fmt.Errorf("unable to get sessions (%s): %w", plexID, err)
 	}
 
 	return http.StatusOK, sessions
@@ -46,14 +45,14 @@ func (s *Server) HandleSessions(r *http.Request) (int, interface{}) {
 func (s *Server) HandleKillSession(r *http.Request) (int, interface{}) {
 	var (
 		ctx       = r.Context()
-		plexID, _ = ctx.Value(starr.Plex).(int)
+		plexID    = mux.Vars(r)["id"]
 		sessionID = mux.Vars(r)["sessionId"]
 		reason    = mux.Vars(r)["reason"]
 	)
 
 	_, err := s.KillSessionWithContext(ctx, sessionID, reason)
 	if err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("unable to kill session (%s@%d): %w", sessionID, plexID, err)
+		return http.StatusInternalServerError, fmt.Errorf("unable to kill session (%s@%s): %w", sessionID, plexID, err)
 	}
 
 	return http.StatusOK, fmt.Sprintf("kilt session '%s' with reason: %s", sessionID, reason)
@@ -71,17 +70,17 @@ func (s *Server) HandleKillSession(r *http.Request) (int, interface{}) {
 // @Router       /api/plex/1/directory [get]
 // @Security     ApiKeyAuth
 func (s *Server) HandleDirectory(req *http.Request) (int, interface{}) {
-	plexID, _ := req.Context().Value(starr.Plex).(int)
+	plexID := mux.Vars(req)["id"]
 
 	directory, err := s.GetDirectoryWithContext(req.Context())
 	if err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("directory request failed (%d): %w", plexID, err)
+		return http.StatusInternalServerError, fmt.Errorf("directory request failed (%s): %w", plexID, err)
 	}
 
 	for idx, library := range directory.Directory {
 		directory.Directory[idx].TrashSize, err = s.GetDirectoryTrashSizeWithContext(req.Context(), library.Key)
 		if err != nil {
-			return http.StatusInternalServerError, fmt.Errorf("directory trash request failed (%d): %w", plexID, err)
+			return http.StatusInternalServerError, fmt.Errorf("directory trash request failed (%s): %w", plexID, err)
 		}
 	}
 
@@ -101,11 +100,11 @@ func (s *Server) HandleDirectory(req *http.Request) (int, interface{}) {
 // @Router       /api/plex/1/emptytrash/{libraryKey} [get]
 // @Security     ApiKeyAuth
 func (s *Server) HandleEmptyTrash(r *http.Request) (int, interface{}) {
-	plexID, _ := r.Context().Value(starr.Plex).(int)
+	plexID := mux.Vars(r)["id"]
 
 	body, err := s.EmptyTrashWithContext(r.Context(), mux.Vars(r)["key"])
 	if err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("trash empty failed (%d): %w", plexID, err)
+		return http.StatusInternalServerError, fmt.Errorf("trash empty failed (%s): %w", plexID, err)
 	}
 
 	return http.StatusOK, "ok: " + string(body)
@@ -124,12 +123,36 @@ func (s *Server) HandleEmptyTrash(r *http.Request) (int, interface{}) {
 // @Router       /api/plex/1/markwatched/{itemKey} [get]
 // @Security     ApiKeyAuth
 func (s *Server) HandleMarkWatched(r *http.Request) (int, interface{}) {
-	plexID, _ := r.Context().Value(starr.Plex).(int)
+	plexID := mux.Vars(r)["id"]
 
 	body, err := s.MarkPlayedWithContext(r.Context(), mux.Vars(r)["key"])
 	if err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("mark watch failed (%d): %w", plexID, err)
+		return http.StatusInternalServerError, fmt.Errorf("mark watch failed (%s): %w", plexID, err)
 	}
 
 	return http.StatusOK, "ok: " + string(body)
 }
+
+// HandleScanPath provides a web handler to the notifiarr client that
+// asks Plex to scan a single path instead of an entire library.
+// @Description  Scans the Plex library section that contains the provided path, but only the provided path.
+// @Description  The path is translated with the configured path maps, so a Radarr or Sonarr path works too.
+// @Summary      Partial Plex library scan.
+// @Tags         Plex
+// @Produce      json
+// @Param        path  query   string true  "File or folder path to scan"
+// @Success      200  {object} apps.Respond.apiResponse{message=ScanResult} "scan started"
+// @Failure      500  {object} apps.Respond.apiResponse{message=string} "Plex error"
+// @Failure      404  {object} string "bad token or api key"
+// @Router       /api/plex/1/scan [get]
+// @Security     ApiKeyAuth
+func (s *Server) HandleScanPath(r *http.Request) (int, interface{}) {
+	plexID := mux.Vars(r)["id"]
+
+	result, err := s.ScanPathWithContext(r.Context(), mux.Vars(r)["path"])
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("scan request failed (%s): %w", plexID, err)
+	}
+
+	return http.StatusOK, result
+}
@@ -0,0 +1,54 @@
+package plex
+
+import (
+	"strings"
+
+	"golift.io/cnfg"
+)
+
+// These are the actions that may be taken for an incoming webhook event.
+const (
+	// HookRelay sends the webhook to the website.
+	HookRelay = "relay"
+	// HookSessions collects sessions from the Plex server and sends them to the website with the webhook.
+	HookSessions = "sessions"
+	// HookCommand runs a custom command.
+	HookCommand = "command"
+	// HookIgnore drops the webhook.
+	HookIgnore = "ignore"
+)
+
+// WebhookConfig controls how an incoming webhook event is handled.
+// Event may end with a * to match every event with that prefix, ie. admin.database.*
+type WebhookConfig struct {
+	Event    string        `json:"event"    toml:"event"    xml:"event"    yaml:"event"`
+	Action   string        `json:"action"   toml:"action"   xml:"action"   yaml:"action"`
+	Command  string        `json:"command"  toml:"command"  xml:"command"  yaml:"command"`
+	Cooldown cnfg.Duration `json:"cooldown" toml:"cooldown" xml:"cooldown" yaml:"cooldown"`
+}
+
+// FindWebhookConfig returns the config for an event. An exact match is preferred,
+// then the wildcard entry with the longest prefix. Returns nil if nothing matches.
+func FindWebhookConfig(configs []*WebhookConfig, event string) *WebhookConfig {
+	var (
+		found  *WebhookConfig
+		length = -1
+	)
+
+	for _, config := range configs {
+		if config == nil {
+			continue
+		}
+
+		if strings.EqualFold(config.Event, event) {
+			return config
+		}
+
+		prefix, ok := strings.CutSuffix(config.Event, "*")
+		if ok && len(prefix) > length && len(event) >= len(prefix) && strings.EqualFold(event[:len(prefix)], prefix) {
+			found, length = config, len(prefix)
+		}
+	}
+
+	return found
+}
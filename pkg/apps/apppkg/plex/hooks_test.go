@@ -0,0 +1,45 @@
+package plex_test
+
+import (
+	"testing"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/plex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindWebhookConfig(t *testing.T) {
+	t.Parallel()
+
+	configs := []*plex.WebhookConfig{
+		nil,
+		{Event: "*", Action: "all"},
+		{Event: "admin.*", Action: "admin"},
+		{Event: "admin.database.*", Action: "database"},
+		{Event: "media.play", Action: "play"},
+		{Event: "Media.Play", Action: "second play"},
+	}
+
+	tests := []struct {
+		event string
+		want  string
+	}{
+		{event: "media.play", want: "play"},
+		{event: "MEDIA.PLAY", want: "play"},
+		{event: "media.stop", want: "all"},
+		{event: "admin.database.backup", want: "database"},
+		{event: "admin.database.corrupted", want: "database"},
+		{event: "admin.other", want: "admin"},
+		{event: "admin.", want: "admin"},
+		{event: "admin", want: "all"},
+	}
+
+	for _, test := range tests {
+		found := plex.FindWebhookConfig(configs, test.event)
+		if assert.NotNil(t, found, test.event) {
+			assert.Equal(t, test.want, found.Action, test.event)
+		}
+	}
+
+	assert.Nil(t, plex.FindWebhookConfig(configs[2:], "media.stop"), "nothing matches")
+	assert.Nil(t, plex.FindWebhookConfig(nil, "media.stop"), "nothing matches")
+}
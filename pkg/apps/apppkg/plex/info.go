@@ -7,7 +7,7 @@ import (
 	"fmt"
 )
 
-// GetInfo retrieves Plex Server Info. This also sets the friendly name, so s.Name() works.
+// GetInfo retrieves Plex Server Info. This also sets the friendly name and uuid, so s.Name() and s.UUID() work.
 func (s *Server) GetInfo(ctx context.Context) (*PMSInfo, error) {
 	data, err := s.getPlexURL(ctx, s.config.URL, nil)
 	if err != nil {
@@ -23,6 +23,7 @@ func (s *Server) GetInfo(ctx context.Context) (*PMSInfo, error) {
 	}
 
 	s.name = output.MediaContainer.FriendlyName
+	s.uuid = output.MediaContainer.MachineIdentifier
 
 	return output.MediaContainer, nil
 }
@@ -22,12 +22,15 @@ import (
 type Server struct {
 	config Config
 	name   string
+	uuid   string
 }
 
 type Config struct {
-	URL    string       `json:"url"   toml:"url"   xml:"url"`
-	Token  string       `json:"token" toml:"token" xml:"token"`
-	Client *http.Client `json:"-"     toml:"-"     xml:"-"`
+	URL      string       `json:"url"      toml:"url"       xml:"url"`
+	Token    string       `json:"token"    toml:"token"     xml:"token"`
+	AutoScan bool         `json:"autoScan" toml:"auto_scan" xml:"auto_scan"`
+	PathMaps []*PathMap   `json:"pathMaps" toml:"path_map"  xml:"path_map"`
+	Client   *http.Client `json:"-"        toml:"-"         xml:"-"`
 }
 
 // New turns a config into a server.
@@ -48,6 +51,11 @@ func (s *Server) Name() string {
 	return s.name
 }
 
+// UUID returns the server's machine identifier. Plex webhooks include this value.
+func (s *Server) UUID() string {
+	return s.uuid
+}
+
 // ErrNoURLToken is returned when there is no token or URL.
 var ErrNoURLToken = errors.New("token or URL for Plex missing")
 
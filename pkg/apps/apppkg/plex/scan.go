@@ -0,0 +1,123 @@
+package plex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// ErrNoSection is returned when a path is not inside any Plex library section location.
+var ErrNoSection = errors.New("path is not inside any library section")
+
+// PathMap translates a path from another application, like Radarr or Sonarr, into the path Plex uses.
+// This is only needed when the applications see the same media at different paths, usually because of Docker mounts.
+type PathMap struct {
+	From string `json:"from" toml:"from" xml:"from"`
+	To   string `json:"to"   toml:"to"   xml:"to"`
+}
+
+// ScanResult is returned after a partial library scan is requested.
+type ScanResult struct {
+	Path     string `json:"path"`     // The path that was provided.
+	PlexPath string `json:"plexPath"` // The path after translation; this is sent to Plex.
+	Section  string `json:"section"`  // The library section title.
+	Key      string `json:"key"`      // The library section key.
+}
+
+// TranslatePath converts a path from another application into a path Plex can see.
+// The path map with the longest matching prefix wins. The path is returned unchanged if no maps match.
+func (c *Config) TranslatePath(input string) string {
+	var found *PathMap
+
+	for _, pathMap := range c.PathMaps {
+		if pathMap == nil || pathMap.From == "" || !hasPathPrefix(input, pathMap.From) {
+			continue
+		}
+
+		if found == nil || len(pathMap.From) > len(found.From) {
+			found = pathMap
+		}
+	}
+
+	if found == nil {
+		return input
+	}
+
+	rest := input[len(strings.TrimRight(found.From, `/\`)):]
+	// Use the path separator from the Plex path, in case one app is on Windows and the other is not.
+	if strings.Contains(found.To, "/") {
+		rest = strings.ReplaceAll(rest, `\`, "/")
+	} else if strings.Contains(found.To, `\`) {
+		rest = strings.ReplaceAll(rest, "/", `\`)
+	}
+
+	return strings.TrimRight(found.To, `/\`) + rest
+}
+
+// hasPathPrefix returns true if prefix is a parent folder of (or equal to) input.
+func hasPathPrefix(input, prefix string) bool {
+	prefix = strings.TrimRight(prefix, `/\`)
+	if !strings.HasPrefix(input, prefix) {
+		return false
+	}
+
+	return len(input) == len(prefix) || input[len(prefix)] == '/' || input[len(prefix)] == '\\'
+}
+
+// FindSectionWithContext returns the library section with a location that contains the provided path.
+// The path must be the path Plex uses; translate it first if needed.
+func (s *Server) FindSectionWithContext(ctx context.Context, plexPath string) (*LibrarySection, error) {
+	directory, err := s.GetDirectoryWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		found   *LibrarySection
+		longest int
+	)
+
+	for _, section := range directory.Directory {
+		for _, location := range section.Location {
+			if hasPathPrefix(plexPath, location.Path) && len(location.Path) > longest {
+				found, longest = section, len(location.Path)
+			}
+		}
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoSection, plexPath)
+	}
+
+	return found, nil
+}
+
+// ScanPath asks Plex to scan a single path inside a library section. This is much faster than a full library scan.
+// The path is translated using the configured path maps before the library section is located.
+func (s *Server) ScanPath(filePath string) (*ScanResult, error) {
+	return s.ScanPathWithContext(context.Background(), filePath)
+}
+
+// ScanPathWithContext asks Plex to scan a single path inside a library section.
+// The path is translated using the configured path maps before the library section is located.
+func (s *Server) ScanPathWithContext(ctx context.Context, filePath string) (*ScanResult, error) {
+	plexPath := s.config.TranslatePath(filePath)
+
+	section, err := s.FindSectionWithContext(ctx, plexPath)
+	if err != nil {
+		return nil, err
+	}
+
+	params := make(url.Values)
+	params.Set("path", plexPath)
+
+	uri := s.config.URL + path.Join("/library", "sections", section.Key, "refresh")
+	if body, err := s.getPlexURL(ctx, uri, params); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, string(body))
+	}
+
+	return &ScanResult{Path: filePath, PlexPath: plexPath, Section: section.Title, Key: section.Key}, nil
+}
@@ -0,0 +1,61 @@
+package plex //nolint:testpackage // hasPathPrefix is not exported.
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslatePath(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{PathMaps: []*PathMap{
+		{From: "/downloads", To: "/data"},
+		{From: "/downloads/movies/", To: "/media/movies"},
+		{From: `D:\TV`, To: "/media/tv"},
+		{From: "/unix", To: `M:\Media`},
+		nil,
+		{From: "", To: "/nope"},
+	}}
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "/downloads/tv/show", want: "/data/tv/show"},
+		{input: "/downloads/movies/Movie (2024)", want: "/media/movies/Movie (2024)"},
+		{input: "/downloads/movies", want: "/media/movies"},
+		{input: "/downloads2/file", want: "/downloads2/file"},
+		{input: `D:\TV\Show\Season 1`, want: "/media/tv/Show/Season 1"},
+		{input: "/unix/Show/file.mkv", want: `M:\Media\Show\file.mkv`},
+		{input: "/other/path", want: "/other/path"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, config.TranslatePath(test.input), "input: %s", test.input)
+	}
+
+	assert.Equal(t, "/same", (&Config{}).TranslatePath("/same"), "no path maps must not change the path")
+}
+
+func TestHasPathPrefix(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input  string
+		prefix string
+		want   bool
+	}{
+		{input: "/data", prefix: "/data", want: true},
+		{input: "/data/tv", prefix: "/data", want: true},
+		{input: "/data/tv", prefix: "/data/", want: true},
+		{input: "/data2/tv", prefix: "/data", want: false},
+		{input: "/dat", prefix: "/data", want: false},
+		{input: `C:\Media\TV`, prefix: `C:\Media`, want: true},
+		{input: `C:\Media2`, prefix: `C:\Media`, want: false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, hasPathPrefix(test.input, test.prefix), "input: %s, prefix: %s", test.input, test.prefix)
+	}
+}
@@ -0,0 +1,355 @@
+package apps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/gorilla/mux"
+	"golift.io/starr"
+	"golift.io/starr/debuglog"
+)
+
+// Bazarr is not a starr app, but it uses the same api key header, so it borrows the starr library.
+const Bazarr starr.App = "Bazarr"
+
+// ErrNoBazarr is returned when a Bazarr instance ID is out of range.
+var ErrNoBazarr = fmt.Errorf("configured %s ID not found", Bazarr)
+
+// bazarrHandlers is called once on startup to register the web API paths.
+func (a *Apps) bazarrHandlers() {
+	a.HandleAPIpath(Bazarr, "/wanted/movies", bazarrWantedMovies, "GET")
+	a.HandleAPIpath(Bazarr, "/wanted/episodes", bazarrWantedEpisodes, "GET")
+	a.HandleAPIpath(Bazarr, "/search/movie/{radarrID:[0-9]+}", bazarrSearchMovie, "POST")
+	a.HandleAPIpath(Bazarr, "/search/episode/{episodeID:[0-9]+}", bazarrSearchEpisode, "POST")
+	a.HandleAPIpath(Bazarr, "/providers", bazarrProviders, "GET")
+}
+
+// BazarrConfig represents the input data for a Bazarr server.
+type BazarrConfig struct {
+	ExtraConfig
+	*starr.Config
+}
+
+// BazarrLanguage is a missing subtitle language.
+type BazarrLanguage struct {
+	Name   string `json:"name"`
+	Code2  string `json:"code2"`
+	Code3  string `json:"code3"`
+	Forced bool   `json:"forced"`
+	HI     bool   `json:"hi"`
+}
+
+// BazarrWantedMovie is a movie that is missing subtitles.
+type BazarrWantedMovie struct {
+	Title            string            `json:"title"`
+	RadarrID         int64             `json:"radarrId"`
+	SceneName        string            `json:"sceneName"`
+	Tags             []string          `json:"tags"`
+	MissingSubtitles []*BazarrLanguage `json:"missing_subtitles"` //nolint:tagliatelle
+}
+
+// BazarrWantedEpisode is an episode that is missing subtitles.
+type BazarrWantedEpisode struct {
+	SeriesTitle      string            `json:"seriesTitle"`
+	EpisodeNumber    string            `json:"episode_number"` //nolint:tagliatelle
+	EpisodeTitle     string            `json:"episodeTitle"`
+	SonarrSeriesID   int64             `json:"sonarrSeriesId"`
+	SonarrEpisodeID  int64             `json:"sonarrEpisodeId"`
+	SceneName        string            `json:"sceneName"`
+	SeriesType       string            `json:"seriesType"`
+	Tags             []string          `json:"tags"`
+	MissingSubtitles []*BazarrLanguage `json:"missing_subtitles"` //nolint:tagliatelle
+}
+
+// BazarrEpisode is a single Sonarr episode in Bazarr.
+type BazarrEpisode struct {
+	Title            string            `json:"title"`
+	Season           int64             `json:"season"`
+	Episode          int64             `json:"episode"`
+	SonarrSeriesID   int64             `json:"sonarrSeriesId"`
+	SonarrEpisodeID  int64             `json:"sonarrEpisodeId"`
+	SceneName        string            `json:"sceneName"`
+	Path             string            `json:"path"`
+	MissingSubtitles []*BazarrLanguage `json:"missing_subtitles"` //nolint:tagliatelle
+}
+
+// BazarrWantedMovies is the wanted movies list.
+type BazarrWantedMovies struct {
+	Data  []*BazarrWantedMovie `json:"data"`
+	Total int64                `json:"total"`
+}
+
+// BazarrWantedEpisodes is the wanted episodes list.
+type BazarrWantedEpisodes struct {
+	Data  []*BazarrWantedEpisode `json:"data"`
+	Total int64                  `json:"total"`
+}
+
+// BazarrProvider is a subtitle provider and its status. Throttled providers include a retry time.
+type BazarrProvider struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Retry  string `json:"retry"`
+}
+
+// Enabled returns true if the Bazarr instance is enabled and usable.
+func (b *BazarrConfig) Enabled() bool {
+	return b != nil && b.Config != nil && b.URL != "" && b.APIKey != "" && b.Timeout.Duration >= 0
+}
+
+func (a *Apps) setupBazarr() error {
+	for idx, app := range a.Bazarr {
+		if app.Config == nil || app.Config.URL == "" {
+			return fmt.Errorf("%w: missing url: Bazarr config %d", ErrInvalidApp, idx+1)
+		} else if !strings.HasPrefix(app.Config.URL, "http://") && !strings.HasPrefix(app.Config.URL, "https://") {
+			return fmt.Errorf("%w: URL must begin with http:// or https://: Bazarr config %d", ErrInvalidApp, idx+1)
+		}
+
+		if a.Logger.DebugEnabled() {
+			app.Config.Client = starr.ClientWithDebug(app.Timeout.Duration, app.ValidSSL, debuglog.Config{
+				MaxBody: a.MaxBody,
+				Debugf:  a.Debugf,
+				Caller:  metricMakerCallback(Bazarr.String()),
+				Redact:  []string{app.APIKey, app.HTTPPass},
+			})
+		} else {
+			app.Config.Client = starr.Client(app.Timeout.Duration, app.ValidSSL)
+			app.Config.Client.Transport = NewMetricsRoundTripper(Bazarr.String(), app.Config.Client.Transport)
+		}
+
+		app.URL = strings.TrimRight(app.URL, "/")
+	}
+
+	return nil
+}
+
+// bazarrPage returns the query parameters for a page of a list. A length of -1 returns everything.
+func bazarrPage(start, length int) url.Values {
+	params := make(url.Values)
+	params.Set("start", strconv.Itoa(start))
+	params.Set("length", strconv.Itoa(length))
+
+	return params
+}
+
+// GetWantedMoviesContext returns movies missing subtitles. A length of -1 returns all of them.
+func (b *BazarrConfig) GetWantedMoviesContext(ctx context.Context, start, length int) (*BazarrWantedMovies, error) {
+	var output BazarrWantedMovies
+
+	req := starr.Request{URI: "movies/wanted", Query: bazarrPage(start, length)}
+	if err := b.GetInto(ctx, req, &output); err != nil {
+		return nil, fmt.Errorf("api.Get(%s): %w", &req, err)
+	}
+
+	return &output, nil
+}
+
+// GetWantedEpisodesContext returns episodes missing subtitles. A length of -1 returns all of them.
+func (b *BazarrConfig) GetWantedEpisodesContext(ctx context.Context, start, length int) (*BazarrWantedEpisodes, error) {
+	var output BazarrWantedEpisodes
+
+	req := starr.Request{URI: "episodes/wanted", Query: bazarrPage(start, length)}
+	if err := b.GetInto(ctx, req, &output); err != nil {
+		return nil, fmt.Errorf("api.Get(%s): %w", &req, err)
+	}
+
+	return &output, nil
+}
+
+// GetProvidersContext returns the subtitle providers and their status.
+func (b *BazarrConfig) GetProvidersContext(ctx context.Context) ([]*BazarrProvider, error) {
+	var output struct {
+		Data []*BazarrProvider `json:"data"`
+	}
+
+	req := starr.Request{URI: "providers"}
+	if err := b.GetInto(ctx, req, &output); err != nil {
+		return nil, fmt.Errorf("api.Get(%s): %w", &req, err)
+	}
+
+	return output.Data, nil
+}
+
+// SearchMovieContext tells Bazarr to search for all missing subtitles on a Radarr movie.
+func (b *BazarrConfig) SearchMovieContext(ctx context.Context, radarrID int64) error {
+	params := make(url.Values)
+	params.Set("radarrid", strconv.FormatInt(radarrID, mnd.Base10))
+	params.Set("action", "search-missing")
+
+	return b.patch(ctx, starr.Request{URI: "movies", Query: params})
+}
+
+// GetEpisodeContext returns a single episode using its Sonarr episode ID.
+func (b *BazarrConfig) GetEpisodeContext(ctx context.Context, episodeID int64) (*BazarrEpisode, error) {
+	var output struct {
+		Data []*BazarrEpisode `json:"data"`
+	}
+
+	params := make(url.Values)
+	params.Set("episodeid[]", strconv.FormatInt(episodeID, mnd.Base10))
+
+	req := starr.Request{URI: "episodes", Query: params}
+	if err := b.GetInto(ctx, req, &output); err != nil {
+		return nil, fmt.Errorf("api.Get(%s): %w", &req, err)
+	}
+
+	for _, episode := range output.Data {
+		if episode.SonarrEpisodeID == episodeID {
+			return episode, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: episode %d", ErrNotFound, episodeID)
+}
+
+// SearchEpisodeContext tells Bazarr to search for each missing subtitle language on a Sonarr episode.
+// Bazarr has no "search missing" action for a single episode, so each missing language is searched.
+func (b *BazarrConfig) SearchEpisodeContext(ctx context.Context, episodeID int64) (*BazarrEpisode, error) {
+	episode, err := b.GetEpisodeContext(ctx, episodeID)
+	if err != nil {
+		return nil, err
+	} else if len(episode.MissingSubtitles) == 0 {
+		return nil, fmt.Errorf("%w: episode %d is not missing subtitles", ErrNotFound, episodeID)
+	}
+
+	for _, lang := range episode.MissingSubtitles {
+		params := make(url.Values)
+		params.Set("seriesid", strconv.FormatInt(episode.SonarrSeriesID, mnd.Base10))
+		params.Set("episodeid", strconv.FormatInt(episodeID, mnd.Base10))
+		params.Set("language", lang.Code2)
+		params.Set("forced", strconv.FormatBool(lang.Forced))
+		params.Set("hi", strconv.FormatBool(lang.HI))
+
+		if err := b.patch(ctx, starr.Request{URI: "episodes/subtitles", Query: params}); err != nil {
+			return episode, fmt.Errorf("searching %s subtitles: %w", lang.Name, err)
+		}
+	}
+
+	return episode, nil
+}
+
+// patch sends a PATCH request to Bazarr. The starr library has no PATCH helper, so this uses Req.
+func (b *BazarrConfig) patch(ctx context.Context, req starr.Request) error {
+	req.URI = starr.SetAPIPath(req.URI)
+
+	resp, err := b.Req(ctx, http.MethodPatch, req)
+	if err != nil {
+		return fmt.Errorf("api.Patch(%s): %w", &req, err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// @Description  Returns movies that are missing subtitles.
+// @Summary      Retrieve Bazarr wanted movies.
+// @Tags         Bazarr
+// @Produce      json
+// @Param        instance  path   int64  true  "instance ID"
+// @Success      200  {object} apps.Respond.apiResponse{message=BazarrWantedMovies} "wanted movies"
+// @Failure      503  {object} apps.Respond.apiResponse{message=string} "instance error"
+// @Failure      404  {object} string "bad token or api key"
+// @Router       /api/bazarr/{instance}/wanted/movies [get]
+// @Security     ApiKeyAuth
+func bazarrWantedMovies(req *http.Request) (int, interface{}) {
+	wanted, err := getBazarr(req).GetWantedMoviesContext(req.Context(), 0, -1)
+	if err != nil {
+		return apiError(http.StatusServiceUnavailable, "getting wanted movies", err)
+	}
+
+	return http.StatusOK, wanted
+}
+
+// @Description  Returns episodes that are missing subtitles.
+// @Summary      Retrieve Bazarr wanted episodes.
+// @Tags         Bazarr
+// @Produce      json
+// @Param        instance  path   int64  true  "instance ID"
+// @Success      200  {object} apps.Respond.apiResponse{message=BazarrWantedEpisodes} "wanted episodes"
+// @Failure      503  {object} apps.Respond.apiResponse{message=string} "instance error"
+// @Failure      404  {object} string "bad token or api key"
+// @Router       /api/bazarr/{instance}/wanted/episodes [get]
+// @Security     ApiKeyAuth
+func bazarrWantedEpisodes(req *http.Request) (int, interface{}) {
+	wanted, err := getBazarr(req).GetWantedEpisodesContext(req.Context(), 0, -1)
+	if err != nil {
+		return apiError(http.StatusServiceUnavailable, "getting wanted episodes", err)
+	}
+
+	return http.StatusOK, wanted
+}
+
+// @Description  Searches for all missing subtitles on a movie, using its Radarr movie ID.
+// @Summary      Search Bazarr subtitles for a movie.
+// @Tags         Bazarr
+// @Produce      json
+// @Param        instance  path   int64  true  "instance ID"
+// @Param        radarrID  path   int64  true  "Radarr movie ID"
+// @Success      200  {object} apps.Respond.apiResponse{message=string} "ok"
+// @Failure      503  {object} apps.Respond.apiResponse{message=string} "instance error"
+// @Failure      404  {object} string "bad token or api key"
+// @Router       /api/bazarr/{instance}/search/movie/{radarrID} [post]
+// @Security     ApiKeyAuth
+func bazarrSearchMovie(req *http.Request) (int, interface{}) {
+	radarrID, _ := strconv.ParseInt(mux.Vars(req)["radarrID"], mnd.Base10, mnd.Bits64)
+
+	if err := getBazarr(req).SearchMovieContext(req.Context(), radarrID); err != nil {
+		return apiError(http.StatusServiceUnavailable, "searching movie", err)
+	}
+
+	return http.StatusOK, "searching for missing subtitles on movie " + strconv.FormatInt(radarrID, mnd.Base10)
+}
+
+// @Description  Searches for each missing subtitle language on an episode, using its Sonarr episode ID.
+// @Summary      Search Bazarr subtitles for an episode.
+// @Tags         Bazarr
+// @Produce      json
+// @Param        instance   path   int64  true  "instance ID"
+// @Param        episodeID  path   int64  true  "Sonarr episode ID"
+// @Success      200  {object} apps.Respond.apiResponse{message=BazarrEpisode} "the searched episode"
+// @Failure      503  {object} apps.Respond.apiResponse{message=string} "instance error"
+// @Failure      404  {object} apps.Respond.apiResponse{message=string} "episode not missing subtitles"
+// @Router       /api/bazarr/{instance}/search/episode/{episodeID} [post]
+// @Security     ApiKeyAuth
+func bazarrSearchEpisode(req *http.Request) (int, interface{}) {
+	episodeID, _ := strconv.ParseInt(mux.Vars(req)["episodeID"], mnd.Base10, mnd.Bits64)
+
+	episode, err := getBazarr(req).SearchEpisodeContext(req.Context(), episodeID)
+	if errors.Is(err, ErrNotFound) {
+		return http.StatusNotFound, err
+	} else if err != nil {
+		return apiError(http.StatusServiceUnavailable, "searching episode", err)
+	}
+
+	return http.StatusOK, episode
+}
+
+// @Description  Returns the subtitle providers and their health. Throttled providers include a retry time.
+// @Summary      Retrieve Bazarr provider health.
+// @Tags         Bazarr
+// @Produce      json
+// @Param        instance  path   int64  true  "instance ID"
+// @Success      200  {object} apps.Respond.apiResponse{message=[]BazarrProvider} "providers"
+// @Failure      503  {object} apps.Respond.apiResponse{message=string} "instance error"
+// @Failure      404  {object} string "bad token or api key"
+// @Router       /api/bazarr/{instance}/providers [get]
+// @Security     ApiKeyAuth
+func bazarrProviders(req *http.Request) (int, interface{}) {
+	providers, err := getBazarr(req).GetProvidersContext(req.Context())
+	if err != nil {
+		return apiError(http.StatusServiceUnavailable, "getting providers", err)
+	}
+
+	return http.StatusOK, providers
+}
+
+func getBazarr(r *http.Request) *BazarrConfig {
+	app, _ := r.Context().Value(Bazarr).(*BazarrConfig)
+	return app
+}
@@ -0,0 +1,83 @@
+package apps //nolint:testpackage // the handlers are not exported.
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golift.io/starr"
+)
+
+func testBazarrServer(t *testing.T, patches *[]string) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == http.MethodPatch:
+			*patches = append(*patches, req.URL.Query().Get("language"))
+		case strings.HasSuffix(req.URL.Path, "/episodes") && req.URL.Query().Get("episodeid[]") == "10":
+			_, _ = resp.Write([]byte(`{"data":[{"title":"Pilot","sonarrSeriesId":1,"sonarrEpisodeId":10,` +
+				`"missing_subtitles":[{"name":"English","code2":"en"},{"name":"French","code2":"fr"}]}]}`))
+		case strings.HasSuffix(req.URL.Path, "/episodes") && req.URL.Query().Get("episodeid[]") == "11":
+			_, _ = resp.Write([]byte(`{"data":[{"title":"Done","sonarrSeriesId":1,"sonarrEpisodeId":11}]}`))
+		case strings.HasSuffix(req.URL.Path, "/episodes"):
+			_, _ = resp.Write([]byte(`{"data":[]}`))
+		default:
+			resp.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+}
+
+func testBazarrRequest(appURL, episodeID string) *http.Request {
+	app := &BazarrConfig{Config: starr.New("apikey", appURL, time.Second)}
+	ctx := context.WithValue(context.Background(), Bazarr, app)
+	req := httptest.NewRequest(http.MethodPost, "/api/bazarr/1/search/episode/"+episodeID, nil).WithContext(ctx)
+
+	return mux.SetURLVars(req, map[string]string{"episodeID": episodeID})
+}
+
+func TestBazarrSearchEpisode(t *testing.T) {
+	t.Parallel()
+
+	patches := []string{}
+	server := testBazarrServer(t, &patches)
+	defer server.Close()
+
+	code, output := bazarrSearchEpisode(testBazarrRequest(server.URL, "10"))
+	require.Equal(t, http.StatusOK, code, output)
+	assert.Equal(t, []string{"en", "fr"}, patches, "every missing language must be searched")
+
+	episode, _ := output.(*BazarrEpisode)
+	require.NotNil(t, episode)
+	assert.Equal(t, "Pilot", episode.Title)
+
+	code, _ = bazarrSearchEpisode(testBazarrRequest(server.URL, "11"))
+	assert.Equal(t, http.StatusNotFound, code, "an episode with all subtitles is not searched")
+
+	code, _ = bazarrSearchEpisode(testBazarrRequest(server.URL, "12"))
+	assert.Equal(t, http.StatusNotFound, code, "a missing episode is not found")
+	assert.Len(t, patches, 2)
+}
+
+func TestBazarrErrors(t *testing.T) {
+	t.Parallel()
+
+	patches := []string{}
+	server := testBazarrServer(t, &patches)
+	defer server.Close()
+
+	code, output := bazarrProviders(testBazarrRequest(server.URL, "10"))
+	assert.Equal(t, http.StatusBadGateway, code, "the app's status code must be returned")
+	assert.Error(t, output.(error)) //nolint:forcetypeassert
+
+	server.Close()
+
+	code, _ = bazarrWantedMovies(testBazarrRequest(server.URL, "10"))
+	assert.Equal(t, http.StatusServiceUnavailable, code, "connection errors are instance errors")
+}
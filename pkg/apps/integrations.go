@@ -7,6 +7,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/jellyfin"
+	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/overseerr"
 	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/plex"
 	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/sabnzbd"
 	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/tautulli"
@@ -53,6 +55,72 @@ func (c *PlexConfig) Enabled() bool {
 	return c != nil && c.Config != nil && c.Config.URL != "" && c.Config.Token != "" && c.Timeout.Duration >= 0
 }
 
+// JellyfinConfig works for Jellyfin and Emby.
+type JellyfinConfig struct {
+	*jellyfin.Config
+	*jellyfin.Server
+	ExtraConfig
+}
+
+func (c *JellyfinConfig) Setup(maxBody int, logger mnd.Logger) {
+	if c.Timeout.Duration == 0 {
+		c.Timeout.Duration = time.Minute
+	}
+
+	if logger != nil && logger.DebugEnabled() {
+		c.Config.Client = starr.ClientWithDebug(c.Timeout.Duration, c.ValidSSL, debuglog.Config{
+			MaxBody: maxBody,
+			Debugf:  logger.Debugf,
+			Caller:  metricMakerCallback(jellyfin.App.String()),
+			Redact:  []string{c.APIKey},
+		})
+	} else {
+		c.Config.Client = starr.Client(c.Timeout.Duration, c.ValidSSL)
+		c.Config.Client.Transport = NewMetricsRoundTripper(jellyfin.App.String(), c.Config.Client.Transport)
+	}
+
+	c.URL = strings.TrimRight(c.URL, "/")
+	c.Server = jellyfin.New(c.Config)
+}
+
+// Enabled returns true if the server is configured, false otherwise.
+func (c *JellyfinConfig) Enabled() bool {
+	return c != nil && c.Config != nil && c.Config.URL != "" && c.Config.APIKey != "" && c.Timeout.Duration >= 0
+}
+
+// OverseerrConfig works for Overseerr and Jellyseerr.
+type OverseerrConfig struct {
+	*overseerr.Config
+	*overseerr.Server
+	ExtraConfig
+}
+
+func (c *OverseerrConfig) Setup(maxBody int, logger mnd.Logger) {
+	if c.Timeout.Duration == 0 {
+		c.Timeout.Duration = time.Minute
+	}
+
+	if logger != nil && logger.DebugEnabled() {
+		c.Config.Client = starr.ClientWithDebug(c.Timeout.Duration, c.ValidSSL, debuglog.Config{
+			MaxBody: maxBody,
+			Debugf:  logger.Debugf,
+			Caller:  metricMakerCallback(overseerr.App.String()),
+			Redact:  []string{c.APIKey},
+		})
+	} else {
+		c.Config.Client = starr.Client(c.Timeout.Duration, c.ValidSSL)
+		c.Config.Client.Transport = NewMetricsRoundTripper(overseerr.App.String(), c.Config.Client.Transport)
+	}
+
+	c.URL = strings.TrimRight(c.URL, "/")
+	c.Server = overseerr.New(c.Config)
+}
+
+// Enabled returns true if the server is configured, false otherwise.
+func (c *OverseerrConfig) Enabled() bool {
+	return c != nil && c.Config != nil && c.Config.URL != "" && c.Config.APIKey != "" && c.Timeout.Duration >= 0
+}
+
 type TautulliConfig struct {
 	ExtraConfig
 	tautulli.Config
@@ -0,0 +1,179 @@
+package apps
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"golift.io/starr"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNoPlex is returned when a Plex instance ID is out of range.
+var ErrNoPlex = fmt.Errorf("configured %s ID not found", starr.Plex)
+
+// PlexServers is the list of configured Plex Media Servers.
+type PlexServers []*PlexConfig
+
+// legacyPlex returns the legacy single plex config as a list.
+// The server is dropped if it has no url, because older config files always contain it.
+func legacyPlex(server *PlexConfig) PlexServers {
+	if server == nil || server.Config == nil || server.URL == "" {
+		return nil
+	}
+
+	return PlexServers{server}
+}
+
+// UnmarshalTOML allows the legacy single [plex] section in a config file, along with a list of [[plex]] sections.
+func (p *PlexServers) UnmarshalTOML(input interface{}) error {
+	legacy, isLegacy := input.(map[string]interface{})
+	if isLegacy {
+		input = []map[string]interface{}{legacy}
+	}
+
+	var (
+		buf    bytes.Buffer
+		output struct {
+			Plex []*PlexConfig `toml:"plex"`
+		}
+	)
+
+	if err := toml.NewEncoder(&buf).Encode(map[string]interface{}{"plex": input}); err != nil {
+		return fmt.Errorf("re-encoding plex config: %w", err)
+	}
+
+	if _, err := toml.NewDecoder(&buf).Decode(&output); err != nil {
+		return fmt.Errorf("decoding plex config: %w", err)
+	}
+
+	if *p = output.Plex; isLegacy && len(output.Plex) == 1 {
+		*p = legacyPlex(output.Plex[0])
+	}
+
+	return nil
+}
+
+// UnmarshalJSON allows the legacy single plex object in a config file, along with a list of plex objects.
+func (p *PlexServers) UnmarshalJSON(input []byte) error {
+	if trimmed := bytes.TrimSpace(input); len(trimmed) == 0 || trimmed[0] != '{' {
+		var servers []*PlexConfig
+		if err := json.Unmarshal(input, &servers); err != nil {
+			return fmt.Errorf("decoding plex config: %w", err)
+		}
+
+		*p = servers
+
+		return nil
+	}
+
+	var server PlexConfig
+	if err := json.Unmarshal(input, &server); err != nil {
+		return fmt.Errorf("decoding plex config: %w", err)
+	}
+
+	*p = legacyPlex(&server)
+
+	return nil
+}
+
+// UnmarshalYAML allows the legacy single plex object in a config file, along with a list of plex objects.
+// The embedded plex configs have no yaml tags, so this decodes them with the json tags.
+func (p *PlexServers) UnmarshalYAML(value *yaml.Node) error {
+	var input interface{}
+	if err := value.Decode(&input); err != nil {
+		return fmt.Errorf("decoding plex config: %w", err)
+	}
+
+	buf, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("re-encoding plex config: %w", err)
+	}
+
+	return p.UnmarshalJSON(buf)
+}
+
+// UnmarshalXML is called once for each plex element. Elements without a url are dropped like the legacy config.
+func (p *PlexServers) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	var server PlexConfig
+	if err := dec.DecodeElement(&server, &start); err != nil {
+		return fmt.Errorf("decoding plex config: %w", err)
+	}
+
+	*p = append(*p, legacyPlex(&server)...)
+
+	return nil
+}
+
+// Enabled returns true if at least one Plex server is enabled.
+func (p PlexServers) Enabled() bool {
+	for _, server := range p {
+		if server.Enabled() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// plexHandlers is called once on startup to register the web API paths.
+// The webhook handler for Plex is registered in the client package.
+func (a *Apps) plexHandlers() {
+	a.HandleAPIpath(starr.Plex, "sessions", plexSessions, "GET")
+	a.HandleAPIpath(starr.Plex, "directory", plexDirectory, "GET")
+	a.HandleAPIpath(starr.Plex, "emptytrash/{key}", plexEmptyTrash, "GET")
+	a.HandleAPIpath(starr.Plex, "markwatched/{key}", plexMarkWatched, "GET")
+	a.HandleAPIpath(starr.Plex, "kill", plexKillSession, "GET").
+		Queries("reason", "{reason:.*}", "sessionId", "{sessionId:.*}")
+	a.HandleAPIpath(starr.Plex, "scan", plexScanPath, "GET").Queries("path", "{path:.+}")
+}
+
+func (a *Apps) setupPlex() error {
+	for idx, app := range a.Plex {
+		if app == nil || app.Config == nil || app.URL == "" || app.Token == "" {
+			return fmt.Errorf("%w: missing url or token: Plex config %d", ErrInvalidApp, idx+1)
+		} else if !strings.HasPrefix(app.URL, "http://") && !strings.HasPrefix(app.URL, "https://") {
+			return fmt.Errorf("%w: URL must begin with http:// or https://: Plex config %d", ErrInvalidApp, idx+1)
+		}
+
+		app.Setup(a.MaxBody, a.Logger)
+	}
+
+	return nil
+}
+
+// getPlex returns the Plex server stored in the request context by handleAPI.
+func getPlex(r *http.Request) *PlexConfig {
+	app, _ := r.Context().Value(starr.Plex).(*PlexConfig)
+	return app
+}
+
+// The Plex API handlers are documented in the plex package.
+
+func plexSessions(r *http.Request) (int, interface{}) {
+	return getPlex(r).HandleSessions(r)
+}
+
+func plexDirectory(r *http.Request) (int, interface{}) {
+	return getPlex(r).HandleDirectory(r)
+}
+
+func plexEmptyTrash(r *http.Request) (int, interface{}) {
+	return getPlex(r).HandleEmptyTrash(r)
+}
+
+func plexMarkWatched(r *http.Request) (int, interface{}) {
+	return getPlex(r).HandleMarkWatched(r)
+}
+
+func plexKillSession(r *http.Request) (int, interface{}) {
+	return getPlex(r).HandleKillSession(r)
+}
+
+func plexScanPath(r *http.Request) (int, interface{}) {
+	return getPlex(r).HandleScanPath(r)
+}
@@ -0,0 +1,140 @@
+package apps_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type plexFile struct {
+	Plex apps.PlexServers `json:"plex" toml:"plex" xml:"plex" yaml:"plex"`
+}
+
+// urls returns the url of each plex server, so the tests can compare them.
+func (p *plexFile) urls() []string {
+	urls := []string{}
+	for _, server := range p.Plex {
+		urls = append(urls, server.URL)
+	}
+
+	return urls
+}
+
+func TestPlexServersTOML(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{name: "legacy", input: "[plex]\nurl = 'http://plex:32400'\ntoken = 'abc'\n", want: []string{"http://plex:32400"}},
+		{name: "legacy empty", input: "[plex]\nurl = ''\ntoken = ''\n", want: []string{}},
+		{name: "list", input: "[[plex]]\nurl = 'http://one'\n[[plex]]\nurl = 'http://two'\n",
+			want: []string{"http://one", "http://two"}},
+		{name: "none", input: "", want: []string{}},
+	}
+
+	for _, test := range tests {
+		var file plexFile
+
+		_, err := toml.Decode(test.input, &file)
+		require.NoError(t, err, test.name)
+		assert.Equal(t, test.want, file.urls(), test.name)
+	}
+}
+
+func TestPlexServersJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{name: "legacy", input: `{"plex":{"url":"http://plex:32400","token":"abc"}}`, want: []string{"http://plex:32400"}},
+		{name: "legacy empty", input: `{"plex":{"url":""}}`, want: []string{}},
+		{name: "list", input: `{"plex":[{"url":"http://one"},{"url":"http://two"}]}`,
+			want: []string{"http://one", "http://two"}},
+		{name: "null", input: `{"plex":null}`, want: []string{}},
+	}
+
+	for _, test := range tests {
+		var file plexFile
+
+		require.NoError(t, json.Unmarshal([]byte(test.input), &file), test.name)
+		assert.Equal(t, test.want, file.urls(), test.name)
+	}
+
+	var file plexFile
+	require.Error(t, json.Unmarshal([]byte(`{"plex":"nope"}`), &file))
+}
+
+func TestPlexServersYAML(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{name: "legacy", input: "plex:\n  url: http://plex:32400\n  token: abc\n", want: []string{"http://plex:32400"}},
+		{name: "legacy empty", input: "plex:\n  url: ''\n", want: []string{}},
+		{name: "list", input: "plex:\n  - url: http://one\n  - url: http://two\n",
+			want: []string{"http://one", "http://two"}},
+	}
+
+	for _, test := range tests {
+		var file plexFile
+
+		require.NoError(t, yaml.Unmarshal([]byte(test.input), &file), test.name)
+		assert.Equal(t, test.want, file.urls(), test.name)
+	}
+
+	var file plexFile
+	require.NoError(t, yaml.Unmarshal([]byte("plex:\n  url: http://plex\n  token: abc\n  timeout: 10s\n"), &file))
+	require.Len(t, file.Plex, 1)
+	assert.Equal(t, "abc", file.Plex[0].Token, "fields must decode from yaml")
+	assert.Equal(t, "10s", file.Plex[0].Timeout.String(), "durations must decode from yaml")
+}
+
+func TestPlexServersXML(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{name: "legacy", input: `<c><plex><url>http://plex:32400</url><token>abc</token></plex></c>`,
+			want: []string{"http://plex:32400"}},
+		{name: "legacy empty", input: `<c><plex><url></url></plex></c>`, want: []string{}},
+		{name: "list", input: `<c><plex><url>http://one</url></plex><plex><url>http://two</url></plex></c>`,
+			want: []string{"http://one", "http://two"}},
+	}
+
+	for _, test := range tests {
+		var file plexFile
+
+		require.NoError(t, xml.Unmarshal([]byte(test.input), &file), test.name)
+		assert.Equal(t, test.want, file.urls(), test.name)
+	}
+}
+
+func TestPlexServersEnabled(t *testing.T) {
+	t.Parallel()
+
+	var file plexFile
+
+	_, err := toml.Decode("[[plex]]\nurl = 'http://one'\ntoken = 'abc'\n", &file)
+	require.NoError(t, err)
+	assert.True(t, file.Plex.Enabled())
+	assert.False(t, apps.PlexServers{}.Enabled())
+	assert.False(t, apps.PlexServers(nil).Enabled())
+}
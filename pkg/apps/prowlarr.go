@@ -1,12 +1,21 @@
 package apps
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"path"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/gorilla/mux"
+	"golift.io/cnfg"
 	"golift.io/starr"
 	"golift.io/starr/debuglog"
 	"golift.io/starr/prowlarr"
@@ -17,19 +26,108 @@ func (a *Apps) prowlarrHandlers() {
 	a.HandleAPIpath(starr.Prowlarr, "/notification", prowlarrGetNotifications, "GET")
 	a.HandleAPIpath(starr.Prowlarr, "/notification", prowlarrUpdateNotification, "PUT")
 	a.HandleAPIpath(starr.Prowlarr, "/notification", prowlarrAddNotification, "POST")
+	a.HandleAPIpath(starr.Prowlarr, "/indexers", prowlarrGetIndexers, "GET")
+	a.HandleAPIpath(starr.Prowlarr, "/indexers/stats", prowlarrGetIndexerStats, "GET")
+	a.HandleAPIpath(starr.Prowlarr, "/indexers/test", prowlarrTestAllIndexers, "POST")
+	a.HandleAPIpath(starr.Prowlarr, "/indexer/{indexerID:[0-9]+}/test", prowlarrTestIndexer, "POST")
+	a.HandleAPIpath(starr.Prowlarr, "/indexer/{indexerID:[0-9]+}/{action:enable|disable}", prowlarrEnableIndexer, "PUT")
+	a.HandleAPIpath(starr.Prowlarr, "/applications", prowlarrGetApplications, "GET")
+	a.HandleAPIpath(starr.Prowlarr, "/search/{query}", prowlarrSearch, "GET")
+}
+
+// ProwlarrIndexerStatus is returned by Prowlarr for indexers that have failed recently.
+// Prowlarr disables an indexer until DisabledTill when it keeps failing.
+type ProwlarrIndexerStatus struct {
+	ID                int64     `json:"id"`
+	IndexerID         int64     `json:"indexerId"`
+	DisabledTill      time.Time `json:"disabledTill"`
+	MostRecentFailure time.Time `json:"mostRecentFailure"`
+	InitialFailure    time.Time `json:"initialFailure"`
+}
+
+// ProwlarrIndexer is an indexer combined with its status.
+// Status is nil if the indexer has not failed recently.
+type ProwlarrIndexer struct {
+	*prowlarr.IndexerOutput
+	Status *ProwlarrIndexerStatus `json:"status,omitempty"`
+}
+
+// ProwlarrIndexerStats contains the query, grab and failure counters for each indexer.
+type ProwlarrIndexerStats struct {
+	ID       int64 `json:"id"`
+	Indexers []*struct {
+		IndexerID                 int64  `json:"indexerId"`
+		IndexerName               string `json:"indexerName"`
+		AverageResponseTime       int64  `json:"averageResponseTime"`
+		AverageGrabResponseTime   int64  `json:"averageGrabResponseTime"`
+		NumberOfQueries           int64  `json:"numberOfQueries"`
+		NumberOfGrabs             int64  `json:"numberOfGrabs"`
+		NumberOfRssQueries        int64  `json:"numberOfRssQueries"`
+		NumberOfAuthQueries       int64  `json:"numberOfAuthQueries"`
+		NumberOfFailedQueries     int64  `json:"numberOfFailedQueries"`
+		NumberOfFailedGrabs       int64  `json:"numberOfFailedGrabs"`
+		NumberOfFailedRssQueries  int64  `json:"numberOfFailedRssQueries"`
+		NumberOfFailedAuthQueries int64  `json:"numberOfFailedAuthQueries"`
+	} `json:"indexers"`
+	UserAgents []*struct {
+		UserAgent       string `json:"userAgent"`
+		NumberOfQueries int64  `json:"numberOfQueries"`
+		NumberOfGrabs   int64  `json:"numberOfGrabs"`
+	} `json:"userAgents"`
+	Hosts []*struct {
+		Host            string `json:"host"`
+		NumberOfQueries int64  `json:"numberOfQueries"`
+		NumberOfGrabs   int64  `json:"numberOfGrabs"`
+	} `json:"hosts"`
+}
+
+// ProwlarrIndexerTest is the result of testing an indexer.
+type ProwlarrIndexerTest struct {
+	ID                 int64                        `json:"id"`
+	Name               string                       `json:"name,omitempty"`
+	IsValid            bool                         `json:"isValid"`
+	ValidationFailures []*ProwlarrValidationFailure `json:"validationFailures"`
+}
+
+// ProwlarrValidationFailure is part of ProwlarrIndexerTest.
+type ProwlarrValidationFailure struct {
+	PropertyName string `json:"propertyName"`
+	ErrorMessage string `json:"errorMessage"`
+	Severity     string `json:"severity"`
+}
+
+// ProwlarrApplication is an app Prowlarr syncs indexers to.
+type ProwlarrApplication struct {
+	ID                 int64                `json:"id"`
+	Name               string               `json:"name"`
+	SyncLevel          string               `json:"syncLevel"`
+	Implementation     string               `json:"implementation"`
+	ImplementationName string               `json:"implementationName"`
+	Tags               []int                `json:"tags"`
+	Fields             []*starr.FieldOutput `json:"fields,omitempty"`
+}
+
+// ProwlarrHealth is a health check message from Prowlarr.
+type ProwlarrHealth struct {
+	Source  string `json:"source"`
+	Type    string `json:"type"`
+	Message string `json:"message"`
+	WikiURL string `json:"wikiUrl"`
 }
 
 // ProwlarrConfig represents the input data for a Prowlarr server.
 type ProwlarrConfig struct {
 	ExtraConfig
 	*starr.Config
+	// IndexerCritical is how long an indexer may fail before its service check goes critical.
+	IndexerCritical    cnfg.Duration `json:"indexerCritical" toml:"indexer_critical" xml:"indexer_critical"`
 	*prowlarr.Prowlarr `json:"-" toml:"-" xml:"-"`
 	errorf             func(string, ...interface{}) `json:"-" toml:"-" xml:"-"`
 }
 
-func getProwlarr(r *http.Request) *prowlarr.Prowlarr {
+func getProwlarr(r *http.Request) *ProwlarrConfig {
 	app, _ := r.Context().Value(starr.Prowlarr).(*ProwlarrConfig)
-	return app.Prowlarr
+	return app
 }
 
 // Enabled returns true if the Prowlarr instance is enabled and usable.
@@ -65,6 +163,78 @@ func (a *Apps) setupProwlarr() error {
 	return nil
 }
 
+// GetIndexerStatusContext returns the status of every indexer that has failed recently.
+func (p *ProwlarrConfig) GetIndexerStatusContext(ctx context.Context) ([]*ProwlarrIndexerStatus, error) {
+	var output []*ProwlarrIndexerStatus
+
+	req := starr.Request{URI: path.Join(prowlarr.APIver, "indexerstatus")}
+	if err := p.GetInto(ctx, req, &output); err != nil {
+		return nil, fmt.Errorf("api.Get(%s): %w", &req, err)
+	}
+
+	return output, nil
+}
+
+// GetIndexersWithStatusContext returns all indexers combined with their failure status.
+func (p *ProwlarrConfig) GetIndexersWithStatusContext(ctx context.Context) ([]*ProwlarrIndexer, error) {
+	indexers, err := p.GetIndexersContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting indexers: %w", err)
+	}
+
+	statuses, err := p.GetIndexerStatusContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting indexer status: %w", err)
+	}
+
+	statusMap := make(map[int64]*ProwlarrIndexerStatus)
+	for _, status := range statuses {
+		statusMap[status.IndexerID] = status
+	}
+
+	output := make([]*ProwlarrIndexer, len(indexers))
+	for idx, indexer := range indexers {
+		output[idx] = &ProwlarrIndexer{IndexerOutput: indexer, Status: statusMap[indexer.ID]}
+	}
+
+	return output, nil
+}
+
+// GetHealthContext returns the current health check messages from Prowlarr.
+func (p *ProwlarrConfig) GetHealthContext(ctx context.Context) ([]*ProwlarrHealth, error) {
+	var output []*ProwlarrHealth
+
+	req := starr.Request{URI: path.Join(prowlarr.APIver, "health")}
+	if err := p.GetInto(ctx, req, &output); err != nil {
+		return nil, fmt.Errorf("api.Get(%s): %w", &req, err)
+	}
+
+	return output, nil
+}
+
+// ProwlarrIndexerInput converts an indexer output into an input, so it can be tested or updated.
+func ProwlarrIndexerInput(indexer *prowlarr.IndexerOutput) *prowlarr.IndexerInput {
+	input := &prowlarr.IndexerInput{
+		Enable:         indexer.Enable,
+		Redirect:       indexer.Redirect,
+		Priority:       indexer.Priority,
+		ID:             indexer.ID,
+		AppProfileID:   indexer.AppProfileID,
+		ConfigContract: indexer.ConfigContract,
+		Implementation: indexer.Implementation,
+		Name:           indexer.Name,
+		Protocol:       indexer.Protocol,
+		Tags:           indexer.Tags,
+		Fields:         make([]*starr.FieldInput, len(indexer.Fields)),
+	}
+
+	for idx, field := range indexer.Fields {
+		input.Fields[idx] = &starr.FieldInput{Name: field.Name, Value: field.Value}
+	}
+
+	return input
+}
+
 // @Description  Returns Prowlarr Notifications with a name that matches 'notifiar'.
 // @Summary      Retrieve Prowlarr Notifications
 // @Tags         Prowlarr
@@ -149,3 +319,257 @@ func prowlarrAddNotification(req *http.Request) (int, interface{}) {
 
 	return http.StatusOK, id
 }
+
+// @Description  Returns all Prowlarr indexers. Indexers that failed recently include a status with failure and disabled-until times.
+// @Summary      Retrieve Prowlarr Indexers
+// @Tags         Prowlarr
+// @Produce      json
+// @Param        instance  path   int64  true  "instance ID"
+// @Success      200  {object} apps.Respond.apiResponse{message=[]apps.ProwlarrIndexer} "indexers"
+// @Failure      503  {object} apps.Respond.apiResponse{message=string} "instance error"
+// @Failure      404  {object} string "bad token or api key"
+// @Router       /api/prowlarr/{instance}/indexers [get]
+// @Security     ApiKeyAuth
+//
+//nolint:lll
+func prowlarrGetIndexers(req *http.Request) (int, interface{}) {
+	app := getProwlarr(req)
+
+	indexers, err := app.GetIndexersWithStatusContext(req.Context())
+	if err != nil {
+		return apiError(http.StatusServiceUnavailable, "getting indexers", err)
+	}
+
+	return http.StatusOK, indexers
+}
+
+// @Description  Returns query, grab and failure counters and average response times for Prowlarr indexers.
+// @Description  The optional startDate and endDate parameters are passed to Prowlarr as-is.
+// @Summary      Retrieve Prowlarr Indexer Stats
+// @Tags         Prowlarr
+// @Produce      json
+// @Param        instance   path   int64   true   "instance ID"
+// @Param        startDate  query  string  false  "stats start date"
+// @Param        endDate    query  string  false  "stats end date"
+// @Success      200  {object} apps.Respond.apiResponse{message=apps.ProwlarrIndexerStats} "indexer stats"
+// @Failure      503  {object} apps.Respond.apiResponse{message=string} "instance error"
+// @Failure      404  {object} string "bad token or api key"
+// @Router       /api/prowlarr/{instance}/indexers/stats [get]
+// @Security     ApiKeyAuth
+func prowlarrGetIndexerStats(req *http.Request) (int, interface{}) {
+	params := make(url.Values)
+
+	for _, key := range []string{"startDate", "endDate"} {
+		if val := req.URL.Query().Get(key); val != "" {
+			params.Set(key, val)
+		}
+	}
+
+	var output ProwlarrIndexerStats
+
+	request := starr.Request{URI: path.Join(prowlarr.APIver, "indexerstats"), Query: params}
+	if err := getProwlarr(req).GetInto(req.Context(), request, &output); err != nil {
+		return apiError(http.StatusServiceUnavailable, "getting indexer stats", err)
+	}
+
+	return http.StatusOK, &output
+}
+
+// @Description  Tests every Prowlarr indexer and returns the results.
+// @Summary      Test all Prowlarr Indexers
+// @Tags         Prowlarr
+// @Produce      json
+// @Param        instance  path   int64  true  "instance ID"
+// @Success      200  {object} apps.Respond.apiResponse{message=[]apps.ProwlarrIndexerTest} "test results"
+// @Failure      503  {object} apps.Respond.apiResponse{message=string} "instance error"
+// @Failure      404  {object} string "bad token or api key"
+// @Router       /api/prowlarr/{instance}/indexers/test [post]
+// @Security     ApiKeyAuth
+func prowlarrTestAllIndexers(req *http.Request) (int, interface{}) {
+	app := getProwlarr(req)
+
+	indexers, err := app.GetIndexersContext(req.Context())
+	if err != nil {
+		return apiError(http.StatusServiceUnavailable, "getting indexers", err)
+	}
+
+	var output []*ProwlarrIndexerTest
+
+	// Prowlarr returns a 400 when any indexer fails, but the body still contains every result.
+	request := starr.Request{URI: path.Join(prowlarr.APIver, "indexer", "testall"), Body: bytes.NewBufferString("{}")}
+	if err := app.PostInto(req.Context(), request, &output); err != nil && len(output) == 0 {
+		return apiError(http.StatusServiceUnavailable, "testing indexers", err)
+	}
+
+	names := make(map[int64]string)
+	for _, indexer := range indexers {
+		names[indexer.ID] = indexer.Name
+	}
+
+	for _, result := range output {
+		result.Name = names[result.ID]
+	}
+
+	return http.StatusOK, output
+}
+
+// @Description  Tests a single Prowlarr indexer and returns the result.
+// @Description  A failed test returns isValid false with the validation failures. Connection errors return an error.
+// @Summary      Test Prowlarr Indexer
+// @Tags         Prowlarr
+// @Produce      json
+// @Param        instance   path   int64  true  "instance ID"
+// @Param        indexerID  path   int64  true  "indexer ID"
+// @Success      200  {object} apps.Respond.apiResponse{message=apps.ProwlarrIndexerTest} "test result"
+// @Failure      503  {object} apps.Respond.apiResponse{message=string} "instance error"
+// @Failure      404  {object} string "bad token or api key"
+// @Router       /api/prowlarr/{instance}/indexer/{indexerID}/test [post]
+// @Security     ApiKeyAuth
+func prowlarrTestIndexer(req *http.Request) (int, interface{}) {
+	indexerID, _ := strconv.ParseInt(mux.Vars(req)["indexerID"], mnd.Base10, mnd.Bits64)
+
+	indexer, err := getProwlarr(req).GetIndexerContext(req.Context(), indexerID)
+	if err != nil {
+		return apiError(http.StatusServiceUnavailable, "getting indexer", err)
+	}
+
+	output := &ProwlarrIndexerTest{ID: indexer.ID, Name: indexer.Name, IsValid: true}
+	err = getProwlarr(req).TestIndexerContext(req.Context(), ProwlarrIndexerInput(indexer))
+
+	var reqErr *starr.ReqError
+
+	switch {
+	case err == nil:
+	case errors.As(err, &reqErr) && reqErr.Code == http.StatusBadRequest:
+		// A failed test is not an instance error. Prowlarr returns a 400 with the validation failures.
+		output.IsValid = false
+		if json.Unmarshal(reqErr.Body, &output.ValidationFailures) != nil || len(output.ValidationFailures) == 0 {
+			output.ValidationFailures = []*ProwlarrValidationFailure{{ErrorMessage: err.Error(), Severity: "error"}}
+		}
+	default:
+		return apiError(http.StatusServiceUnavailable, "testing indexer", err)
+	}
+
+	return http.StatusOK, output
+}
+
+// @Description  Enables or disables a Prowlarr indexer.
+// @Summary      Enable or disable Prowlarr Indexer
+// @Tags         Prowlarr
+// @Produce      json
+// @Param        instance   path   int64   true  "instance ID"
+// @Param        indexerID  path   int64   true  "indexer ID"
+// @Param        action     path   string  true  "enable or disable" Enums(enable, disable)
+// @Success      200  {object} apps.Respond.apiResponse{message=prowlarr.IndexerOutput} "updated indexer"
+// @Failure      503  {object} apps.Respond.apiResponse{message=string} "instance error"
+// @Failure      404  {object} string "bad token or api key"
+// @Router       /api/prowlarr/{instance}/indexer/{indexerID}/{action} [put]
+// @Security     ApiKeyAuth
+func prowlarrEnableIndexer(req *http.Request) (int, interface{}) {
+	indexerID, _ := strconv.ParseInt(mux.Vars(req)["indexerID"], mnd.Base10, mnd.Bits64)
+
+	indexer, err := getProwlarr(req).GetIndexerContext(req.Context(), indexerID)
+	if err != nil {
+		return apiError(http.StatusServiceUnavailable, "getting indexer", err)
+	}
+
+	input := ProwlarrIndexerInput(indexer)
+	input.Enable = mux.Vars(req)["action"] == "enable"
+
+	output, err := getProwlarr(req).UpdateIndexerContext(req.Context(), input, false)
+	if err != nil {
+		return apiError(http.StatusServiceUnavailable, "updating indexer", err)
+	}
+
+	return http.StatusOK, output
+}
+
+// @Description  Returns the applications Prowlarr syncs indexers to, and any application sync problems Prowlarr reports.
+// @Summary      Retrieve Prowlarr Application Sync Status
+// @Tags         Prowlarr
+// @Produce      json
+// @Param        instance  path   int64  true  "instance ID"
+// @Success      200  {object} apps.Respond.apiResponse{message=apps.prowlarrGetApplications.appSync} "applications and sync problems"
+// @Failure      503  {object} apps.Respond.apiResponse{message=string} "instance error"
+// @Failure      404  {object} string "bad token or api key"
+// @Router       /api/prowlarr/{instance}/applications [get]
+// @Security     ApiKeyAuth
+//
+//nolint:lll
+func prowlarrGetApplications(req *http.Request) (int, interface{}) {
+	app := getProwlarr(req)
+
+	type appSync struct {
+		Applications []*ProwlarrApplication `json:"applications"`
+		Problems     []*ProwlarrHealth      `json:"problems"`
+	}
+
+	output := &appSync{Problems: []*ProwlarrHealth{}}
+
+	request := starr.Request{URI: path.Join(prowlarr.APIver, "applications")}
+	if err := app.GetInto(req.Context(), request, &output.Applications); err != nil {
+		return apiError(http.StatusServiceUnavailable, "getting applications", err)
+	}
+
+	health, err := app.GetHealthContext(req.Context())
+	if err != nil {
+		return apiError(http.StatusServiceUnavailable, "getting health", err)
+	}
+
+	for _, msg := range health {
+		// These are the Prowlarr health checks that report application sync failures.
+		if strings.HasPrefix(msg.Source, "Application") {
+			output.Problems = append(output.Problems, msg)
+		}
+	}
+
+	return http.StatusOK, output
+}
+
+// @Description  Searches Prowlarr indexers. Optionally limit the search to specific indexers and categories.
+// @Summary      Search Prowlarr Indexers
+// @Tags         Prowlarr
+// @Produce      json
+// @Param        instance    path   int64   true   "instance ID"
+// @Param        query       path   string  true   "search string"
+// @Param        type        query  string  false  "search type, defaults to search" Enums(search, tvsearch, movie, music, book)
+// @Param        indexerIds  query  string  false  "comma separated indexer IDs"
+// @Param        categories  query  string  false  "comma separated category IDs"
+// @Param        limit       query  int     false  "maximum results, default 100"
+// @Param        offset      query  int     false  "skip this many results"
+// @Success      200  {object} apps.Respond.apiResponse{message=[]prowlarr.Search} "search results"
+// @Failure      503  {object} apps.Respond.apiResponse{message=string} "instance error"
+// @Failure      404  {object} string "bad token or api key"
+// @Router       /api/prowlarr/{instance}/search/{query} [get]
+// @Security     ApiKeyAuth
+func prowlarrSearch(req *http.Request) (int, interface{}) {
+	query := req.URL.Query()
+	search := prowlarr.SearchInput{
+		Query:      mux.Vars(req)["query"],
+		Type:       query.Get("type"),
+		IndexerIDs: splitInt64s(query.Get("indexerIds")),
+		Categories: splitInt64s(query.Get("categories")),
+	}
+	search.Limit, _ = strconv.Atoi(query.Get("limit"))
+	search.Offset, _ = strconv.Atoi(query.Get("offset"))
+
+	results, err := getProwlarr(req).SearchContext(req.Context(), search)
+	if err != nil {
+		return apiError(http.StatusServiceUnavailable, "searching indexers", err)
+	}
+
+	return http.StatusOK, results
+}
+
+// splitInt64s turns a comma separated list of numbers into a slice. Invalid numbers are skipped.
+func splitInt64s(list string) []int64 {
+	output := []int64{}
+
+	for _, str := range strings.Split(list, ",") {
+		if num, err := strconv.ParseInt(strings.TrimSpace(str), mnd.Base10, mnd.Bits64); err == nil {
+			output = append(output, num)
+		}
+	}
+
+	return output
+}
@@ -0,0 +1,74 @@
+package apps //nolint:testpackage // the handlers are not exported.
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golift.io/starr"
+	"golift.io/starr/prowlarr"
+)
+
+func testProwlarrRequest(appURL string) *http.Request {
+	config := starr.New("apikey", appURL, time.Second)
+	app := &ProwlarrConfig{Config: config, Prowlarr: prowlarr.New(config)}
+	ctx := context.WithValue(context.Background(), starr.Prowlarr, app)
+	req := httptest.NewRequest(http.MethodPost, "/api/prowlarr/1/indexer/3/test", nil).WithContext(ctx)
+
+	return mux.SetURLVars(req, map[string]string{"indexerID": "3"})
+}
+
+func TestProwlarrTestIndexer(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/api/v1/indexer/3":
+			_, _ = resp.Write([]byte(`{"id":3,"name":"tracker"}`))
+		case "/api/v1/indexer/test":
+			resp.WriteHeader(http.StatusBadRequest)
+			_, _ = resp.Write([]byte(`[{"propertyName":"BaseUrl","errorMessage":"Unable to connect","severity":"error"}]`))
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	code, output := prowlarrTestIndexer(testProwlarrRequest(server.URL))
+	require.Equal(t, http.StatusOK, code, "a failed indexer test is not an instance error")
+
+	result, _ := output.(*ProwlarrIndexerTest)
+	require.NotNil(t, result)
+	assert.False(t, result.IsValid)
+	assert.Equal(t, "tracker", result.Name)
+	require.Len(t, result.ValidationFailures, 1)
+	assert.Equal(t, "Unable to connect", result.ValidationFailures[0].ErrorMessage)
+}
+
+func TestProwlarrTestIndexerDown(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/api/v1/indexer/3" {
+			_, _ = resp.Write([]byte(`{"id":3,"name":"tracker"}`))
+		} else {
+			resp.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	code, output := prowlarrTestIndexer(testProwlarrRequest(server.URL))
+	assert.Equal(t, http.StatusBadGateway, code, "instance errors must not be reported as a failed test")
+	assert.Error(t, output.(error)) //nolint:forcetypeassert
+
+	server.Close()
+
+	code, output = prowlarrTestIndexer(testProwlarrRequest(server.URL))
+	assert.Equal(t, http.StatusServiceUnavailable, code, "connection errors must not be reported as a failed test")
+	assert.Error(t, output.(error)) //nolint:forcetypeassert
+}
@@ -18,45 +18,50 @@ import (
 
 // radarrHandlers is called once on startup to register the web API paths.
 func (a *Apps) radarrHandlers() {
-	a.HandleAPIpath(starr.Radarr, "/add", radarrAddMovie, "POST")
-	a.HandleAPIpath(starr.Radarr, "/check/{tmdbid:[0-9]+}", radarrCheckMovie, "GET")
-	a.HandleAPIpath(starr.Radarr, "/get/{movieid:[0-9]+}", radarrGetMovie, "GET")
-	a.HandleAPIpath(starr.Radarr, "/get", radarrGetAllMovies, "GET")
-	a.HandleAPIpath(starr.Radarr, "/qualityProfiles", radarrQualityProfiles, "GET")
-	a.HandleAPIpath(starr.Radarr, "/qualityProfile", radarrQualityProfile, "GET")
-	a.HandleAPIpath(starr.Radarr, "/qualityProfile", radarrAddQualityProfile, "POST")
-	a.HandleAPIpath(starr.Radarr, "/qualityProfile/{profileID:[0-9]+}", radarrUpdateQualityProfile, "PUT")
-	a.HandleAPIpath(starr.Radarr, "/qualityProfile/{profileID:[0-9]+}", radarrDeleteQualityProfile, "DELETE")
-	a.HandleAPIpath(starr.Radarr, "/qualityProfiles/all", radarrDeleteAllQualityProfiles, "DELETE")
-	a.HandleAPIpath(starr.Radarr, "/rootFolder", radarrRootFolders, "GET")
-	a.HandleAPIpath(starr.Radarr, "/naming", radarrGetNaming, "GET")
-	a.HandleAPIpath(starr.Radarr, "/naming", radarrUpdateNaming, "PUT")
-	a.HandleAPIpath(starr.Radarr, "/search/{query}", radarrSearchMovie, "GET")
-	a.HandleAPIpath(starr.Radarr, "/tag", radarrGetTags, "GET")
-	a.HandleAPIpath(starr.Radarr, "/tag/{tid:[0-9]+}/{label}", radarrUpdateTag, "PUT")
-	a.HandleAPIpath(starr.Radarr, "/tag/{label}", radarrSetTag, "PUT")
-	a.HandleAPIpath(starr.Radarr, "/update", radarrUpdateMovie, "PUT")
-	a.HandleAPIpath(starr.Radarr, "/exclusions", radarrGetExclusions, "GET")
-	a.HandleAPIpath(starr.Radarr, "/exclusions", radarrAddExclusions, "POST")
-	a.HandleAPIpath(starr.Radarr, "/exclusions/{eid:(?:[0-9],?)+}", radarrDelExclusions, "DELETE")
-	a.HandleAPIpath(starr.Radarr, "/customformats", radarrGetCustomFormats, "GET")
-	a.HandleAPIpath(starr.Radarr, "/customformats", radarrAddCustomFormat, "POST")
-	a.HandleAPIpath(starr.Radarr, "/customformats", radarrUpdateCustomFormat, "PUT")
-	a.HandleAPIpath(starr.Radarr, "/customformats/{cfid:[0-9]+}", radarrUpdateCustomFormat, "PUT")
-	a.HandleAPIpath(starr.Radarr, "/customformats/{cfid:[0-9]+}", radarrDeleteCustomFormat, "DELETE")
-	a.HandleAPIpath(starr.Radarr, "/qualitydefinitions", radarrGetQualityDefinitions, "GET")
-	a.HandleAPIpath(starr.Radarr, "/qualitydefinition", radarrUpdateQualityDefinition, "PUT")
-	a.HandleAPIpath(starr.Radarr, "/customformats/all", radarrDeleteAllCustomFormats, "DELETE")
-	a.HandleAPIpath(starr.Radarr, "/importlist", radarrGetImportLists, "GET")
-	a.HandleAPIpath(starr.Radarr, "/importlist", radarrAddImportList, "POST")
-	a.HandleAPIpath(starr.Radarr, "/importlist/{ilid:[0-9]+}", radarrUpdateImportList, "PUT")
-	a.HandleAPIpath(starr.Radarr, "/command/search/{movieid:[0-9]+}", radarrTriggerSearchMovie, "GET")
-	a.HandleAPIpath(starr.Radarr, "/notification", radarrGetNotifications, "GET")
-	a.HandleAPIpath(starr.Radarr, "/notification", radarrUpdateNotification, "PUT")
-	a.HandleAPIpath(starr.Radarr, "/notification", radarrAddNotification, "POST")
-	a.HandleAPIpath(starr.Radarr, "/queue/{queueID}", radarrDeleteQueue, "DELETE")
-	a.HandleAPIpath(starr.Radarr, "/delete/{movieID:[0-9]+}", radarrDeleteMovie, "POST")
-	a.HandleAPIpath(starr.Radarr, "/delete/{movieFileID:[0-9]+}", radarrDeleteContent, "DELETE")
+	a.radarrRoutes(starr.Radarr)
+}
+
+// radarrRoutes registers the Radarr API paths for Radarr or a Radarr fork (Whisparr).
+func (a *Apps) radarrRoutes(app starr.App) {
+	a.HandleAPIpath(app, "/add", radarrAddMovie, "POST")
+	a.HandleAPIpath(app, "/check/{tmdbid:[0-9]+}", radarrCheckMovie, "GET")
+	a.HandleAPIpath(app, "/get/{movieid:[0-9]+}", radarrGetMovie, "GET")
+	a.HandleAPIpath(app, "/get", radarrGetAllMovies, "GET")
+	a.HandleAPIpath(app, "/qualityProfiles", radarrQualityProfiles, "GET")
+	a.HandleAPIpath(app, "/qualityProfile", radarrQualityProfile, "GET")
+	a.HandleAPIpath(app, "/qualityProfile", radarrAddQualityProfile, "POST")
+	a.HandleAPIpath(app, "/qualityProfile/{profileID:[0-9]+}", radarrUpdateQualityProfile, "PUT")
+	a.HandleAPIpath(app, "/qualityProfile/{profileID:[0-9]+}", radarrDeleteQualityProfile, "DELETE")
+	a.HandleAPIpath(app, "/qualityProfiles/all", radarrDeleteAllQualityProfiles, "DELETE")
+	a.HandleAPIpath(app, "/rootFolder", radarrRootFolders, "GET")
+	a.HandleAPIpath(app, "/naming", radarrGetNaming, "GET")
+	a.HandleAPIpath(app, "/naming", radarrUpdateNaming, "PUT")
+	a.HandleAPIpath(app, "/search/{query}", radarrSearchMovie, "GET")
+	a.HandleAPIpath(app, "/tag", radarrGetTags, "GET")
+	a.HandleAPIpath(app, "/tag/{tid:[0-9]+}/{label}", radarrUpdateTag, "PUT")
+	a.HandleAPIpath(app, "/tag/{label}", radarrSetTag, "PUT")
+	a.HandleAPIpath(app, "/update", radarrUpdateMovie, "PUT")
+	a.HandleAPIpath(app, "/exclusions", radarrGetExclusions, "GET")
+	a.HandleAPIpath(app, "/exclusions", radarrAddExclusions, "POST")
+	a.HandleAPIpath(app, "/exclusions/{eid:(?:[0-9],?)+}", radarrDelExclusions, "DELETE")
+	a.HandleAPIpath(app, "/customformats", radarrGetCustomFormats, "GET")
+	a.HandleAPIpath(app, "/customformats", radarrAddCustomFormat, "POST")
+	a.HandleAPIpath(app, "/customformats", radarrUpdateCustomFormat, "PUT")
+	a.HandleAPIpath(app, "/customformats/{cfid:[0-9]+}", radarrUpdateCustomFormat, "PUT")
+	a.HandleAPIpath(app, "/customformats/{cfid:[0-9]+}", radarrDeleteCustomFormat, "DELETE")
+	a.HandleAPIpath(app, "/qualitydefinitions", radarrGetQualityDefinitions, "GET")
+	a.HandleAPIpath(app, "/qualitydefinition", radarrUpdateQualityDefinition, "PUT")
+	a.HandleAPIpath(app, "/customformats/all", radarrDeleteAllCustomFormats, "DELETE")
+	a.HandleAPIpath(app, "/importlist", radarrGetImportLists, "GET")
+	a.HandleAPIpath(app, "/importlist", radarrAddImportList, "POST")
+	a.HandleAPIpath(app, "/importlist/{ilid:[0-9]+}", radarrUpdateImportList, "PUT")
+	a.HandleAPIpath(app, "/command/search/{movieid:[0-9]+}", radarrTriggerSearchMovie, "GET")
+	a.HandleAPIpath(app, "/notification", radarrGetNotifications, "GET")
+	a.HandleAPIpath(app, "/notification", radarrUpdateNotification, "PUT")
+	a.HandleAPIpath(app, "/notification", radarrAddNotification, "POST")
+	a.HandleAPIpath(app, "/queue/{queueID}", radarrDeleteQueue, "DELETE")
+	a.HandleAPIpath(app, "/delete/{movieID:[0-9]+}", radarrDeleteMovie, "POST")
+	a.HandleAPIpath(app, "/delete/{movieFileID:[0-9]+}", radarrDeleteContent, "DELETE")
 }
 
 // RadarrConfig represents the input data for a Radarr server.
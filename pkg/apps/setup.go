@@ -1,5 +1,5 @@
 // Package apps provides the _incoming_ HTTP methods for notifiarr.com integrations.
-// Methods are included for Radarr, Readrr, Lidarr and Sonarr. This library also
+// Methods are included for Radarr, Readrr, Lidarr, Sonarr and Whisparr. This library also
 // holds the site API Key and the base HTTP server abstraction used throughout
 // the Notifiarr client application. The configuration should be derived from
 // a config file; a Router and an Error Log logger must also be provided.
@@ -12,7 +12,8 @@ import (
 	"strings"
 
 	"github.com/CAFxX/httpcompression"
-	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/plex"
+	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/jellyfin"
+	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/overseerr"
 	"github.com/Notifiarr/notifiarr/pkg/mnd"
 	"github.com/gorilla/mux"
 	"golang.org/x/time/rate"
@@ -34,6 +35,8 @@ type Apps struct {
 	Lidarr       []*LidarrConfig   `json:"lidarr,omitempty"       toml:"lidarr"       xml:"lidarr"       yaml:"lidarr,omitempty"`
 	Readarr      []*ReadarrConfig  `json:"readarr,omitempty"      toml:"readarr"      xml:"readarr"      yaml:"readarr,omitempty"`
 	Prowlarr     []*ProwlarrConfig `json:"prowlarr,omitempty"     toml:"prowlarr"     xml:"prowlarr"     yaml:"prowlarr,omitempty"`
+	Whisparr     []*WhisparrConfig `json:"whisparr,omitempty"     toml:"whisparr"     xml:"whisparr"     yaml:"whisparr,omitempty"`
+	Bazarr       []*BazarrConfig   `json:"bazarr,omitempty"       toml:"bazarr"       xml:"bazarr"       yaml:"bazarr,omitempty"`
 	Deluge       []*DelugeConfig   `json:"deluge,omitempty"       toml:"deluge"       xml:"deluge"       yaml:"deluge,omitempty"`
 	Qbit         []*QbitConfig     `json:"qbit,omitempty"         toml:"qbit"         xml:"qbit"         yaml:"qbit,omitempty"`
 	Rtorrent     []*RtorrentConfig `json:"rtorrent,omitempty"     toml:"rtorrent"     xml:"rtorrent"     yaml:"rtorrent,omitempty"`
@@ -41,7 +44,9 @@ type Apps struct {
 	NZBGet       []*NZBGetConfig   `json:"nzbget,omitempty"       toml:"nzbget"       xml:"nzbget"       yaml:"nzbget,omitempty"`
 	Transmission []*XmissionConfig `json:"transmission,omitempty" toml:"transmission" xml:"transmission" yaml:"transmission,omitempty"`
 	Tautulli     *TautulliConfig   `json:"tautulli,omitempty"     toml:"tautulli"     xml:"tautulli"     yaml:"tautulli,omitempty"`
-	Plex         *PlexConfig       `json:"plex"                   toml:"plex"         xml:"plex"         yaml:"plex"`
+	Plex         PlexServers       `json:"plex,omitempty"         toml:"plex"         xml:"plex"         yaml:"plex,omitempty"`
+	Jellyfin     *JellyfinConfig   `json:"jellyfin,omitempty"     toml:"jellyfin"     xml:"jellyfin"     yaml:"jellyfin,omitempty"`
+	Overseerr    *OverseerrConfig  `json:"overseerr,omitempty"    toml:"overseerr"    xml:"overseerr"    yaml:"overseerr,omitempty"`
 	Router       *mux.Router       `json:"-"                      toml:"-"            xml:"-"            yaml:"-"`
 	mnd.Logger   `json:"-"                      toml:"-"            xml:"-"`
 	keys         map[string]struct{} // for fast key lookup.
@@ -49,11 +54,12 @@ type Apps struct {
 }
 
 type ExtraConfig struct {
-	Name     string        `json:"name"     toml:"name"      xml:"name"`
-	Timeout  cnfg.Duration `json:"timeout"  toml:"timeout"   xml:"timeout"`
-	Interval cnfg.Duration `json:"interval" toml:"interval"  xml:"interval"`
-	ValidSSL bool          `json:"validSsl" toml:"valid_ssl" xml:"valid_ssl"`
-	Deletes  int           `json:"deletes"  toml:"deletes"   xml:"deletes"`
+	Name     string        `json:"name"     toml:"name"         xml:"name"`
+	Timeout  cnfg.Duration `json:"timeout"  toml:"timeout"      xml:"timeout"`
+	Interval cnfg.Duration `json:"interval" toml:"interval"     xml:"interval"`
+	ValidSSL bool          `json:"validSsl" toml:"valid_ssl"    xml:"valid_ssl"`
+	Deletes  int           `json:"deletes"  toml:"deletes"      xml:"deletes"`
+	Health   bool          `json:"health"   toml:"health_check" xml:"health_check"` // starr apps only.
 	delLimit *rate.Limiter
 }
 
@@ -68,6 +74,7 @@ var (
 	ErrNoLidarr   = fmt.Errorf("configured %s ID not found", starr.Lidarr)
 	ErrNoReadarr  = fmt.Errorf("configured %s ID not found", starr.Readarr)
 	ErrNoProwlarr = fmt.Errorf("configured %s ID not found", starr.Prowlarr)
+	ErrNoWhisparr = fmt.Errorf("configured %s ID not found", starr.Whisparr)
 	ErrNotFound   = errors.New("the request returned an empty payload")
 	ErrNonZeroID  = errors.New("provided ID must be non-zero")
 	// ErrWrongCount is returned when an app returns the wrong item count.
@@ -102,6 +109,14 @@ func (a *Apps) Setup() error { //nolint:cyclop
 		return err
 	}
 
+	if err := a.setupWhisparr(); err != nil {
+		return err
+	}
+
+	if err := a.setupBazarr(); err != nil {
+		return err
+	}
+
 	if err := a.setupDeluge(); err != nil {
 		return err
 	}
@@ -126,16 +141,33 @@ func (a *Apps) Setup() error { //nolint:cyclop
 		return err
 	}
 
+	if err := a.setupPlex(); err != nil {
+		return err
+	}
+
 	if a.Tautulli == nil {
 		a.Tautulli = &TautulliConfig{}
 	}
 
-	if a.Plex == nil {
-		a.Plex = &PlexConfig{Config: &plex.Config{}}
+	if a.Jellyfin == nil {
+		a.Jellyfin = &JellyfinConfig{}
+	}
+
+	if a.Jellyfin.Config == nil {
+		a.Jellyfin.Config = &jellyfin.Config{}
+	}
+
+	if a.Overseerr == nil {
+		a.Overseerr = &OverseerrConfig{}
+	}
+
+	if a.Overseerr.Config == nil {
+		a.Overseerr.Config = &overseerr.Config{}
 	}
 
 	a.Tautulli.Setup(a.MaxBody, a.Logger)
-	a.Plex.Setup(a.MaxBody, a.Logger)
+	a.Jellyfin.Setup(a.MaxBody, a.Logger)
+	a.Overseerr.Setup(a.MaxBody, a.Logger)
 
 	return nil
 }
@@ -154,6 +186,9 @@ func (a *Apps) InitHandlers() {
 	a.radarrHandlers()
 	a.readarrHandlers()
 	a.sonarrHandlers()
+	a.whisparrHandlers()
+	a.bazarrHandlers()
+	a.plexHandlers()
 }
 
 // DelOK returns true if the delete limit isn't reached.
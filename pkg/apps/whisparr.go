@@ -0,0 +1,59 @@
+package apps
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+	"golift.io/starr"
+	"golift.io/starr/debuglog"
+	"golift.io/starr/radarr"
+)
+
+// whisparrHandlers is called once on startup to register the web API paths.
+// Whisparr v3 is a Radarr fork with the same API, so it gets the same paths and handlers as Radarr.
+func (a *Apps) whisparrHandlers() {
+	a.radarrRoutes(starr.Whisparr)
+}
+
+// WhisparrConfig represents the input data for a Whisparr server.
+// It has the same layout as RadarrConfig, and the Radarr library talks to Whisparr.
+type WhisparrConfig RadarrConfig
+
+// Enabled returns true if the Whisparr instance is enabled and usable.
+func (w *WhisparrConfig) Enabled() bool {
+	return w != nil && w.Config != nil && w.URL != "" && w.APIKey != "" && w.Timeout.Duration >= 0
+}
+
+func (a *Apps) setupWhisparr() error {
+	for idx, app := range a.Whisparr {
+		if app.Config == nil || app.Config.URL == "" {
+			return fmt.Errorf("%w: missing url: Whisparr config %d", ErrInvalidApp, idx+1)
+		} else if !strings.HasPrefix(app.Config.URL, "http://") && !strings.HasPrefix(app.Config.URL, "https://") {
+			return fmt.Errorf("%w: URL must begin with http:// or https://: Whisparr config %d", ErrInvalidApp, idx+1)
+		}
+
+		if a.Logger.DebugEnabled() {
+			app.Config.Client = starr.ClientWithDebug(app.Timeout.Duration, app.ValidSSL, debuglog.Config{
+				MaxBody: a.MaxBody,
+				Debugf:  a.Debugf,
+				Caller:  metricMakerCallback(string(starr.Whisparr)),
+				Redact:  []string{app.APIKey, app.Password, app.HTTPPass},
+			})
+		} else {
+			app.Config.Client = starr.Client(app.Timeout.Duration, app.ValidSSL)
+			app.Config.Client.Transport = NewMetricsRoundTripper(starr.Whisparr.String(), app.Config.Client.Transport)
+		}
+
+		app.errorf = a.Errorf
+		app.URL = strings.TrimRight(app.URL, "/")
+		app.Radarr = radarr.New(app.Config)
+
+		if app.Deletes > 0 {
+			app.delLimit = rate.NewLimiter(rate.Every(1*time.Hour/time.Duration(app.Deletes)), app.Deletes)
+		}
+	}
+
+	return nil
+}
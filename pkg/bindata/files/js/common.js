@@ -189,6 +189,7 @@ function jsLoader()
         'triggers', 
         'websocket', 
         'filebrowser',
+        'downloads',
     ];
 
     for (const file of files) {
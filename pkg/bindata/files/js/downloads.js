@@ -0,0 +1,29 @@
+// showDownloads displays/shows the downloads page(div) and fills it with the joined download clients and starr queues.
+function showDownloads()
+{
+    swapNavigationTemplate('downloads');
+    // Start with a spinner because this queries every download client and starr app.
+    $('#downloads-content').html('<h4><i class="fas fa-cog fa-spin"></i> Loading downloads...</h4>');
+
+    $.ajax({
+        url: URLBase+'ajax/downloads',
+        success: function (data){
+            $('#downloads-content').html(data);
+            const view = $('#downloads-content').find('.table-responsive');
+            $('#downloads-msg').html("Displaying "+view.data('count')+" downloads, "+view.data('orphans')+" orphaned and "+
+                view.data('missing')+" missing. Updated: "+ new Date().toLocaleTimeString());
+        },
+        error: function (request, status, error) {
+            if (request.status == 0) {
+                $('#downloads-content').html('<h4>Web Server Error</h4>Notifiarr client appears to be down! Hard refresh recommended.');
+            } else {
+                $('#downloads-content').html('<h4>'+ (error!=''?error:'Bad Request') +'</h4>'+ request.responseText);
+            }
+        },
+    });
+}
+
+// Load the downloads when the page is opened with the #downloads hash (like on refresh).
+if ($(location).attr('hash') == '#downloads') {
+    showDownloads();
+}
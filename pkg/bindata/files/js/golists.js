@@ -140,6 +140,7 @@ function addInstance(section, app)
             case "Pass":
             case "Password":
             case "APIKey":
+            case "Token":
                 extra = '<div style="width:35px; max-width:35px;" class="input-group-addon input-sm" onClick="togglePassword(\''+ prefix +'.'+ app +'.'+ index +'.'+ name + '\', $(this).find(\'i\'));"><i class="fas fa-low-vision secret-input"></i></div>';
                 itype = "<input type=\"password\"";
                 break;
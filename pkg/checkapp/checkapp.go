@@ -89,6 +89,8 @@ func testInstance(ctx context.Context, input *Input) (string, int) {
 		return checkAndRun(ctx, testReadarr, input, input.Post.Apps, input.Post.Apps.Readarr)
 	case "sonarr":
 		return checkAndRun(ctx, testSonarr, input, input.Post.Apps, input.Post.Apps.Sonarr)
+	case "whisparr":
+		return checkAndRun(ctx, testWhisparr, input, input.Post.Apps, input.Post.Apps.Whisparr)
 	// snapshots.go
 	case "mysql":
 		return checkAndRun(ctx, testMySQL, input, input.Post.Snapshot, input.Post.Snapshot.Plugins.MySQL)
@@ -106,7 +108,7 @@ func testInstance(ctx context.Context, input *Input) (string, int) {
 		return checkAndRun(ctx, testPing, input, input.Post.Service, input.Post.Service)
 	// media.go
 	case "plex":
-		return testPlex(ctx, input.Post.Plex)
+		return checkAndRun(ctx, testPlex, input, input.Post.Apps, input.Post.Apps.Plex)
 	case "tautulli":
 		return testTautulli(ctx, input.Post.Apps.Tautulli)
 	default:
@@ -56,3 +56,12 @@ func testSonarr(ctx context.Context, config *apps.SonarrConfig) (string, int) {
 
 	return success + status.Version, http.StatusOK
 }
+
+func testWhisparr(ctx context.Context, config *apps.WhisparrConfig) (string, int) {
+	status, err := radarr.New(config.Config).GetSystemStatusContext(ctx)
+	if err != nil {
+		return connecting + err.Error(), http.StatusBadGateway
+	}
+
+	return success + status.Version, http.StatusOK
+}
@@ -12,8 +12,12 @@ import (
 	"time"
 
 	"github.com/CAFxX/httpcompression"
+	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/jellyfin"
+	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/overseerr"
 	"github.com/Notifiarr/notifiarr/pkg/bindata"
 	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/backups"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/downloads"
 	"github.com/gorilla/mux"
 	"golift.io/starr"
 )
@@ -89,6 +93,7 @@ func (c *Client) httpGuiHandlers(base string, compress func(handler http.Handler
 	gui.HandleFunc("/startFileWatch/{index}", c.handleStartFileWatcher).Methods("GET")
 	gui.HandleFunc("/browse", c.handleFileBrowser).Queries("dir", "{dir}").Methods("GET")
 	gui.HandleFunc("/ajax/{path:cmdstats|cmdargs}/{hash}", c.handleCommandStats).Methods("GET")
+	gui.HandleFunc("/ajax/downloads", c.handleDownloads).Methods("GET")
 	gui.HandleFunc("/runCommand/{hash}", c.handleRunCommand).Methods("POST")
 	gui.HandleFunc("/ws", c.handleWebSockets).Queries("source", "{source}", "fileId", "{fileId}").Methods("GET")
 	gui.HandleFunc("/docs/json/{instance}", c.handlerSwaggerDoc).Methods("GET")
@@ -113,16 +118,45 @@ func (c *Client) httpAPIHandlers() {
 
 	// Aggregate handlers. Non-app specific.
 	c.Config.HandleAPIpath("", "/trash/{app}", c.triggers.CFSync.Handler, "POST")
+	c.Config.HandleAPIpath("", "backups/archive", c.triggers.Backups.ArchiveHandler, "GET")
+	c.Config.HandleAPIpath("", "backups/archive/{app:"+backups.ArchiveApps+"}", c.triggers.Backups.ArchiveHandler, "GET")
+	c.Config.HandleAPIpath("", "backups/archive/{app:"+backups.ArchiveApps+"}/{instance:[0-9]+}",
+		c.triggers.Backups.ArchiveHandler, "GET")
+	// Archived backup files are zip files, not json, so this is not a normal API handler.
+	c.Config.Router.Handle(path.Join(c.Config.URLBase, "api", "backups/archive/{app:"+backups.ArchiveApps+"}",
+		"{instance:[0-9]+}/{file}"), c.Config.CheckAPIKey(http.HandlerFunc(c.triggers.Backups.ArchiveFileHandler))).
+		Methods("GET")
+	c.Config.HandleAPIpath("", "dashboard/torrents", c.triggers.Dashboard.TorrentsHandler, "GET")
+	c.Config.HandleAPIpath("", "downloads", c.triggers.Downloads.Handler, "GET")
+	c.Config.HandleAPIpath("", "downloads/failed", c.triggers.Downloads.FailedHandler, "GET")
+	c.Config.HandleAPIpath("", "downloads/orphans", c.triggers.Downloads.OrphansHandler, "GET")
+
+	// Download client control. Client items are identified by torrent hash, nzo_id or NZBID.
+	dlc := "downloads/{client:" + downloads.Clients + "}/{instance:[0-9]+}"
+	c.Config.HandleAPIpath("", dlc+"/{action:pause|resume}", c.triggers.Downloads.PauseHandler, "PUT")
+	c.Config.HandleAPIpath("", dlc+"/{action:pause|resume}/{id}", c.triggers.Downloads.PauseHandler, "PUT")
+	c.Config.HandleAPIpath("", dlc+"/remove/{id}", c.triggers.Downloads.RemoveHandler, "DELETE")
+	c.Config.HandleAPIpath("", dlc+"/category/{id}/{category}", c.triggers.Downloads.CategoryHandler, "PUT")
+	c.Config.HandleAPIpath("", dlc+"/priority/{id}/{priority:"+downloads.Priorities+"}",
+		c.triggers.Downloads.PriorityHandler, "PUT")
+	c.Config.HandleAPIpath("", dlc+"/limits", c.triggers.Downloads.LimitsHandler, "PUT")
 
 	if c.Config.Plex.Enabled() {
-		c.Config.HandleAPIpath(starr.Plex, "sessions", c.Config.Plex.HandleSessions, "GET")
-		c.Config.HandleAPIpath(starr.Plex, "directory", c.Config.Plex.HandleDirectory, "GET")
-		c.Config.HandleAPIpath(starr.Plex, "emptytrash/{key}", c.Config.Plex.HandleEmptyTrash, "GET")
-		c.Config.HandleAPIpath(starr.Plex, "markwatched/{key}", c.Config.Plex.HandleMarkWatched, "GET")
-		c.Config.HandleAPIpath(starr.Plex, "kill", c.Config.Plex.HandleKillSession, "GET").
-			Queries("reason", "{reason:.*}", "sessionId", "{sessionId:.*}")
+		// Watch history is saved by plexcron, so these are not registered with the other Plex API paths.
+		if c.triggers.PlexCron.HistoryEnabled() {
+			c.Config.HandleAPIpath(starr.Plex, "history", c.triggers.PlexCron.HandleHistory, "GET")
+			c.Config.HandleAPIpath(starr.Plex, "history/users", c.triggers.PlexCron.HandleHistoryUsers, "GET")
+		}
+
+		// Any configured Plex token (or the client api key) is accepted. PlexHandler routes the webhook by server uuid.
+		keys := []string{c.Config.Apps.APIKey}
+		for _, server := range c.Config.Plex {
+			if server.Enabled() {
+				keys = append(keys, server.Token)
+			}
+		}
 
-		tokens := fmt.Sprintf("{token:%s|%s}", c.Config.Plex.Token, c.Config.Apps.APIKey)
+		tokens := "{token:" + strings.Join(keys, "|") + "}"
 		c.Config.Router.HandleFunc("/plex", c.PlexHandler).Methods("POST").Queries("token", tokens)
 		c.Config.Router.HandleFunc("/", c.PlexHandler).Methods("POST").Queries("token", tokens)
 
@@ -132,6 +166,29 @@ func (c *Client) httpAPIHandlers() {
 				Methods("POST").Queries("token", tokens)
 		}
 	}
+
+	if c.Config.Jellyfin.Enabled() {
+		c.Config.HandleAPIpath(jellyfin.App, "sessions", c.Config.Jellyfin.HandleSessions, "GET")
+		c.Config.HandleAPIpath(jellyfin.App, "directory", c.Config.Jellyfin.HandleDirectory, "GET")
+		c.Config.HandleAPIpath(jellyfin.App, "markwatched/{userId}/{itemId}", c.Config.Jellyfin.HandleMarkWatched, "GET")
+		c.Config.HandleAPIpath(jellyfin.App, "kill", c.Config.Jellyfin.HandleKillSession, "GET").
+			Queries("reason", "{reason:.*}", "sessionId", "{sessionId:.*}")
+
+		tokens := fmt.Sprintf("{token:%s|%s}", c.Config.Jellyfin.APIKey, c.Config.Apps.APIKey)
+		c.Config.Router.HandleFunc(path.Join(c.Config.URLBase, "jellyfin"), c.JellyfinHandler).
+			Methods("POST").Queries("token", tokens)
+	}
+
+	if c.Config.Overseerr.Enabled() {
+		c.Config.HandleAPIpath(overseerr.App, "pending", c.Config.Overseerr.HandlePending, "GET")
+		c.Config.HandleAPIpath(overseerr.App, "counts", c.Config.Overseerr.HandleCounts, "GET")
+		c.Config.HandleAPIpath(overseerr.App, "request/{requestID:[0-9]+}/{action:approve|decline}",
+			c.Config.Overseerr.HandleUpdateRequest, "POST")
+
+		tokens := fmt.Sprintf("{token:%s|%s}", c.Config.Overseerr.APIKey, c.Config.Apps.APIKey)
+		c.Config.Router.HandleFunc(path.Join(c.Config.URLBase, "overseerr"), c.OverseerrHandler).
+			Methods("POST").Queries("token", tokens)
+	}
 }
 
 // notFound is the handler for paths that are not found: 404s.
@@ -175,8 +232,18 @@ func (c *Client) stripSecrets(next http.Handler) http.Handler {
 	secrets := []string{c.Config.Apps.APIKey}
 	secrets = append(secrets, c.Config.ExKeys...)
 	// gather configured/known secrets.
-	if c.Config.Plex.Enabled() {
-		secrets = append(secrets, c.Config.Plex.Token)
+	for _, server := range c.Config.Plex {
+		if server.Enabled() {
+			secrets = append(secrets, server.Token)
+		}
+	}
+
+	if c.Config.Jellyfin.Enabled() {
+		secrets = append(secrets, c.Config.Jellyfin.APIKey)
+	}
+
+	if c.Config.Overseerr.Enabled() {
+		secrets = append(secrets, c.Config.Overseerr.APIKey)
 	}
 
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { //nolint:varnamelen
@@ -289,6 +356,7 @@ func (c *Client) handleInstancePing(req *http.Request) (int, interface{}) { //no
 			starr.Readarr.Lower(),
 			starr.Sonarr.Lower(),
 			starr.Prowlarr.Lower(),
+			starr.Whisparr.Lower(),
 		}
 	}
 
@@ -314,6 +382,10 @@ func (c *Client) handleInstancePing(req *http.Request) (int, interface{}) { //no
 			for idx := range c.Config.Apps.Prowlarr {
 				c.pingInstance(req.Context(), c.Config.Apps.Prowlarr[idx], app, idx, instance, output)
 			}
+		case starr.Whisparr.Lower():
+			for idx := range c.Config.Apps.Whisparr {
+				c.pingInstance(req.Context(), c.Config.Apps.Whisparr[idx], app, idx, instance, output)
+			}
 		}
 	}
 
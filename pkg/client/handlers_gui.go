@@ -17,6 +17,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Notifiarr/notifiarr/pkg/apps"
 	"github.com/Notifiarr/notifiarr/pkg/bindata"
 	"github.com/Notifiarr/notifiarr/pkg/bindata/docs"
 	"github.com/Notifiarr/notifiarr/pkg/checkapp"
@@ -500,6 +501,15 @@ func (c *Client) handleCommandStats(response http.ResponseWriter, request *http.
 	}
 }
 
+// handleDownloads renders the download client items joined with the starr app queues.
+func (c *Client) handleDownloads(response http.ResponseWriter, request *http.Request) {
+	view := c.triggers.Downloads.View(request.Context())
+
+	if err := c.template.ExecuteTemplate(response, "ajax/downloads.html", view); err != nil {
+		http.Error(response, "template error: "+err.Error(), http.StatusOK)
+	}
+}
+
 // handleRunCommand only handles commands with arguments.
 // Commands without arguments are handled as an instance test.
 func (c *Client) handleRunCommand(response http.ResponseWriter, request *http.Request) {
@@ -701,6 +711,7 @@ func (c *Client) mergeAndValidateNewConfig(config *configfile.Config, request *h
 
 	config.SSLCrtFile = ""
 	config.SSLKeyFile = ""
+	plexServers := config.Plex
 	config.Plex = nil
 	config.WatchFiles = nil
 	config.Commands = nil
@@ -717,9 +728,30 @@ func (c *Client) mergeAndValidateNewConfig(config *configfile.Config, request *h
 		return fmt.Errorf("decoding POST data into Go data structure failed: %w", err)
 	}
 
+	keepPlexScanConfig(plexServers, config.Plex)
+
 	return c.validateNewConfig(config)
 }
 
+// keepPlexScanConfig copies the auto scan settings into the new Plex config.
+// These settings are not in the GUI, so they're matched to the new servers by URL.
+func keepPlexScanConfig(oldServers, newServers apps.PlexServers) {
+	for _, server := range newServers {
+		if server == nil || server.Config == nil {
+			continue
+		}
+
+		for _, old := range oldServers {
+			if old != nil && old.Config != nil && old.URL == server.URL {
+				server.AutoScan = old.AutoScan
+				server.PathMaps = old.PathMaps
+
+				break
+			}
+		}
+	}
+}
+
 func (c *Client) validateNewConfig(config *configfile.Config) error {
 	for idx, cmd := range config.Commands {
 		if err := cmd.SetupRegexpArgs(); err != nil {
@@ -0,0 +1,119 @@
+//nolint:godot
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/jellyfin"
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/Notifiarr/notifiarr/pkg/website"
+)
+
+// JellyfinHandler handles an incoming webhook from Emby or the Jellyfin webhook plugin.
+// @Summary      Accept Jellyfin or Emby Webhook
+// @Description  Accepts a Jellyfin (webhook plugin) or Emby webhook; when conditions are satisfied sends a notification
+// @Description  to the website, and may include snapshot data and/or fetched session data. Does not require X-API-Key header.
+// @Description  Emby sends a multipart form with a data field, the Jellyfin plugin sends a json body.
+// @Tags         Jellyfin
+// @Accept       json
+// @Produce      text/plain
+// @Param        token query   string                   true "Jellyfin API Key or Client API Key"
+// @Param        POST  body    jellyfin.IncomingWebhook true "webhook payload"
+// @Success      202  {string} string "accepted"
+// @Success      208  {string} string "ignored"
+// @Failure      400  {string} string "bad input"
+// @Failure      404  {string} string "bad token or api key"
+// @Router       /jellyfin [post]
+func (c *Client) JellyfinHandler(w http.ResponseWriter, r *http.Request) { //nolint:varnamelen,funlen
+	mnd.Apps.Add("Jellyfin&&Incoming Webhooks", 1)
+
+	start := time.Now()
+
+	r.Body = apps.NewFakeCloser("Jellyfin", "Webhook", r.Body)
+	defer r.Body.Close()
+
+	payload, err := readJellyfinPayload(r)
+	if err != nil {
+		c.Errorf("Reading Jellyfin webhook: %v", err)
+		mnd.Apps.Add("Jellyfin&&Webhook Errors", 1)
+		http.Error(w, "form parse error", http.StatusBadRequest)
+
+		return
+	}
+
+	c.Debugf("Jellyfin Webhook Payload: %s", payload)
+	r.Header.Set("X-Request-Time", fmt.Sprintf("%dms", time.Since(start).Milliseconds()))
+
+	var hook jellyfin.IncomingWebhook
+	if err := json.Unmarshal(payload, &hook); err != nil {
+		mnd.Apps.Add("Jellyfin&&Webhook Errors", 1)
+		http.Error(w, "payload error", http.StatusBadRequest)
+		c.Errorf("Unmarshalling Jellyfin payload: %v", err)
+
+		return
+	}
+
+	hook.Normalize()
+
+	switch hook.Event {
+	case "library.new", "item.rate", "item.markplayed", "user.authenticationfailed",
+		"system.serverrestartrequired", "system.updateavailable", "plugins.pluginupdated":
+		c.Printf("Jellyfin Incoming Webhook: %s, %s '%s' ~> %s (relaying to Notifiarr)",
+			hook.Server.Name, hook.User.Name, hook.Event, hook.ItemName())
+		c.Config.SendData(&website.Request{
+			Route:      website.JellyRoute,
+			Event:      website.EventHook,
+			LogPayload: true,
+			LogMsg:     fmt.Sprintf("Jellyfin Webhook: %s '%s' ~> %s", hook.User.Name, hook.Event, hook.ItemName()),
+			Payload: &website.JellyfinPayload{
+				Snap:     c.triggers.PlexCron.GetMetaSnap(r.Context()),
+				Load:     &hook,
+				Jellyfin: &jellyfin.Sessions{Name: c.Config.Jellyfin.Server.Name()},
+			},
+		})
+		r.Header.Set("X-Request-Time", fmt.Sprintf("%dms", time.Since(start).Milliseconds()))
+		http.Error(w, "process", http.StatusAccepted)
+	case "playback.start", "playback.unpause", "playback.stop":
+		if c.plexTimer.Active("jellyfin"+hook.ItemKey()+hook.Event, c.plexCooldown()) {
+			c.Printf("Jellyfin Incoming Webhook Ignored (cooldown): %s, %s '%s' ~> %s",
+				hook.Server.Name, hook.User.Name, hook.Event, hook.ItemName())
+			http.Error(w, "ignored, cooldown", http.StatusAlreadyReported)
+
+			return
+		}
+
+		c.triggers.JellyCron.SendWebhook(&hook) //nolint:contextcheck,nolintlint
+		c.Printf("Jellyfin Incoming Webhook: %s, %s '%s' ~> %s (collecting sessions)",
+			hook.Server.Name, hook.User.Name, hook.Event, hook.ItemName())
+		r.Header.Set("X-Request-Time", fmt.Sprintf("%dms", time.Since(start).Milliseconds()))
+		http.Error(w, "processing", http.StatusAccepted)
+	default:
+		http.Error(w, "ignored, unsupported", http.StatusAlreadyReported)
+		c.Printf("Jellyfin Incoming Webhook Ignored (unsupported): %s, %s '%s' ~> %s",
+			hook.Server.Name, hook.User.Name, hook.Event, hook.ItemName())
+	}
+}
+
+// readJellyfinPayload returns the json payload from Emby (multipart form) or Jellyfin (json body).
+func readJellyfinPayload(r *http.Request) ([]byte, error) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/") {
+		body, err := io.ReadAll(io.LimitReader(r.Body, mnd.Megabyte))
+		if err != nil {
+			return nil, fmt.Errorf("reading body: %w", err)
+		}
+
+		return body, nil
+	}
+
+	if err := r.ParseMultipartForm(mnd.Megabyte); err != nil {
+		return nil, fmt.Errorf("parsing multipart form: %w", err)
+	}
+
+	return []byte(r.Form.Get("data")), nil
+}
@@ -0,0 +1,73 @@
+//nolint:godot
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/overseerr"
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/Notifiarr/notifiarr/pkg/website"
+)
+
+// OverseerrHandler handles an incoming webhook from Overseerr or Jellyseerr.
+// @Summary      Accept Overseerr or Jellyseerr Webhook
+// @Description  Accepts an Overseerr webhook using the default json payload and relays it to the website.
+// @Description  Does not require X-API-Key header.
+// @Tags         Overseerr
+// @Accept       json
+// @Produce      text/plain
+// @Param        token query   string                    true "Overseerr API Key or Client API Key"
+// @Param        POST  body    overseerr.IncomingWebhook true "webhook payload"
+// @Success      202  {string} string "accepted"
+// @Success      208  {string} string "ignored"
+// @Failure      400  {string} string "bad input"
+// @Failure      404  {string} string "bad token or api key"
+// @Router       /overseerr [post]
+func (c *Client) OverseerrHandler(w http.ResponseWriter, r *http.Request) { //nolint:varnamelen
+	mnd.Apps.Add("Overseerr&&Incoming Webhooks", 1)
+
+	start := time.Now()
+
+	r.Body = apps.NewFakeCloser("Overseerr", "Webhook", r.Body)
+	defer r.Body.Close()
+
+	payload, err := io.ReadAll(io.LimitReader(r.Body, mnd.Megabyte))
+	if err != nil {
+		c.Errorf("Reading Overseerr webhook: %v", err)
+		mnd.Apps.Add("Overseerr&&Webhook Errors", 1)
+		http.Error(w, "body read error", http.StatusBadRequest)
+
+		return
+	}
+
+	c.Debugf("Overseerr Webhook Payload: %s", payload)
+
+	var hook overseerr.IncomingWebhook
+
+	switch err := json.Unmarshal(payload, &hook); {
+	case err != nil:
+		mnd.Apps.Add("Overseerr&&Webhook Errors", 1)
+		http.Error(w, "payload error", http.StatusBadRequest)
+		c.Errorf("Unmarshalling Overseerr payload: %v", err)
+	case hook.NotificationType == "":
+		http.Error(w, "ignored, unsupported", http.StatusAlreadyReported)
+		c.Printf("Overseerr Incoming Webhook Ignored (no notification type): %s ~> %s", hook.Event, hook.Subject)
+	default:
+		c.Printf("Overseerr Incoming Webhook: %s '%s' ~> %s (relaying to Notifiarr)",
+			hook.Username(), hook.NotificationType, hook.Subject)
+		c.Config.SendData(&website.Request{
+			Route:      website.SeerrRoute,
+			Event:      website.EventHook,
+			LogPayload: true,
+			LogMsg:     fmt.Sprintf("Overseerr Webhook: %s '%s' ~> %s", hook.Username(), hook.NotificationType, hook.Subject),
+			Payload:    &hook,
+		})
+		r.Header.Set("X-Request-Time", fmt.Sprintf("%dms", time.Since(start).Milliseconds()))
+		http.Error(w, "process", http.StatusAccepted)
+	}
+}
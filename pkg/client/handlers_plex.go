@@ -11,6 +11,7 @@ import (
 	"github.com/Notifiarr/notifiarr/pkg/apps"
 	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/plex"
 	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
 	"github.com/Notifiarr/notifiarr/pkg/website"
 	"github.com/Notifiarr/notifiarr/pkg/website/clientinfo"
 )
@@ -19,6 +20,7 @@ import (
 // @Summary      Accept Plex Media Server Webhook
 // @Description  Accepts a Plex webhook; when conditions are satisfied sends a notification to the website,
 // @Description  and may include snapshot data and/or fetched session data. Does not require X-API-Key header.
+// @Description  Each event may be relayed, collect sessions, run a custom command or be ignored; see plex_webhook in the config file.
 // @Tags         Plex
 // @Accept       json
 // @Produce      text/plain
@@ -51,20 +53,34 @@ func (c *Client) PlexHandler(w http.ResponseWriter, r *http.Request) { //nolint:
 
 	var hook plex.IncomingWebhook
 
-	switch err := json.Unmarshal([]byte(payload), &hook); {
-	case err != nil:
+	if err := json.Unmarshal([]byte(payload), &hook); err != nil {
 		mnd.Apps.Add("Plex&&Webhook Errors", 1)
 		http.Error(w, "payload error", http.StatusBadRequest)
 		c.Errorf("Unmarshalling Plex payload: %v", err)
-	case strings.EqualFold(hook.Event, "admin.database.backup"):
-		fallthrough
-	case strings.EqualFold(hook.Event, "device.new"):
-		fallthrough
-	case strings.EqualFold(hook.Event, "media.rate"):
-		fallthrough
-	case strings.EqualFold(hook.Event, "library.new"):
-		fallthrough
-	case strings.EqualFold(hook.Event, "admin.database.corrupt"):
+
+		return
+	}
+
+	config := c.plexHookConfig(hook.Event)
+	if config == nil {
+		http.Error(w, "ignored, unsupported", http.StatusAlreadyReported)
+		c.Printf("Plex Incoming Webhook Ignored (unsupported): %s, %s '%s' ~> %s",
+			hook.Server.Title, hook.Account.Title, hook.Event, hook.Metadata.Title)
+
+		return
+	}
+
+	if key, cooldown := c.plexHookCooldown(&hook, config); cooldown > 0 && config.Action != plex.HookIgnore &&
+		c.plexTimer.Active(plexTimerKey(&hook, key), cooldown) {
+		c.Printf("Plex Incoming Webhook Ignored (cooldown): %s, %s '%s' ~> %s",
+			hook.Server.Title, hook.Account.Title, hook.Event, hook.Metadata.Title)
+		http.Error(w, "ignored, cooldown", http.StatusAlreadyReported)
+
+		return
+	}
+
+	switch strings.ToLower(config.Action) {
+	case plex.HookRelay:
 		c.Printf("Plex Incoming Webhook: %s, %s '%s' ~> %s (relaying to Notifiarr)",
 			hook.Server.Title, hook.Account.Title, hook.Event, hook.Metadata.Title)
 		c.Config.SendData(&website.Request{
@@ -75,40 +91,109 @@ func (c *Client) PlexHandler(w http.ResponseWriter, r *http.Request) { //nolint:
 			Payload: &website.Payload{
 				Snap: c.triggers.PlexCron.GetMetaSnap(r.Context()),
 				Load: &hook,
-				Plex: &plex.Sessions{Name: c.Config.Plex.Server.Name()},
+				Plex: &plex.Sessions{Name: c.plexServerName(&hook)},
 			},
 		})
 		r.Header.Set("X-Request-Time", fmt.Sprintf("%dms", time.Since(start).Milliseconds()))
 		http.Error(w, "process", http.StatusAccepted)
-	case strings.EqualFold(hook.Event, "media.resume") && c.plexTimer.Active(hook.Metadata.Key+"resume", c.plexCooldown()):
-		c.Printf("Plex Incoming Webhook Ignored (cooldown): %s, %s '%s' ~> %s",
-			hook.Server.Title, hook.Account.Title, hook.Event, hook.Metadata.Title)
-		http.Error(w, "ignored, cooldown", http.StatusAlreadyReported)
-	case strings.EqualFold(hook.Event, "media.play"), strings.EqualFold(hook.Event, "playback.started"):
-		if c.plexTimer.Active(hook.Metadata.Key+"play", c.plexCooldown()) {
-			c.Printf("Plex Incoming Webhook Ignored (cooldown): %s, %s '%s' ~> %s",
-				hook.Server.Title, hook.Account.Title, hook.Event, hook.Metadata.Title)
-			http.Error(w, "ignored, cooldown", http.StatusAlreadyReported)
-
-			return
-		}
-
-		fallthrough
-	case strings.EqualFold(hook.Event, "media.scrobble"):
-		fallthrough
-	case strings.EqualFold(hook.Event, "media.resume"):
+	case plex.HookSessions:
 		c.triggers.PlexCron.SendWebhook(&hook) //nolint:contextcheck,nolintlint
 		c.Printf("Plex Incoming Webhook: %s, %s '%s' ~> %s (collecting sessions)",
 			hook.Server.Title, hook.Account.Title, hook.Event, hook.Metadata.Title)
 		r.Header.Set("X-Request-Time", fmt.Sprintf("%dms", time.Since(start).Milliseconds()))
 		http.Error(w, "processing", http.StatusAccepted)
-	default:
-		http.Error(w, "ignored, unsupported", http.StatusAlreadyReported)
-		c.Printf("Plex Incoming Webhook Ignored (unsupported): %s, %s '%s' ~> %s",
+	case plex.HookCommand:
+		c.plexHookCommand(w, &hook, config)
+	case plex.HookIgnore:
+		http.Error(w, "ignored", http.StatusAlreadyReported)
+		c.Debugf("Plex Incoming Webhook Ignored (configured): %s, %s '%s' ~> %s",
 			hook.Server.Title, hook.Account.Title, hook.Event, hook.Metadata.Title)
+	default:
+		http.Error(w, "ignored, unsupported action", http.StatusAlreadyReported)
+		c.Errorf("Plex Incoming Webhook Ignored: %s, %s '%s' ~> %s: unsupported action configured: %s",
+			hook.Server.Title, hook.Account.Title, hook.Event, hook.Metadata.Title, config.Action)
 	}
 }
 
+// defaultPlexHooks is how webhook events are handled when they are not in the config file.
+var defaultPlexHooks = []*plex.WebhookConfig{ //nolint:gochecknoglobals
+	{Event: "admin.database.backup", Action: plex.HookRelay},
+	{Event: "admin.database.corrupt", Action: plex.HookRelay},
+	{Event: "device.new", Action: plex.HookRelay},
+	{Event: "media.rate", Action: plex.HookRelay},
+	{Event: "library.new", Action: plex.HookRelay},
+	{Event: "media.play", Action: plex.HookSessions},
+	{Event: "playback.started", Action: plex.HookSessions},
+	{Event: "media.resume", Action: plex.HookSessions},
+	{Event: "media.scrobble", Action: plex.HookSessions},
+}
+
+// plexHookConfig returns the configured handling for a webhook event, the default handling,
+// or nil if the event is not supported.
+func (c *Client) plexHookConfig(event string) *plex.WebhookConfig {
+	if config := plex.FindWebhookConfig(c.Config.PlexHooks, event); config != nil {
+		return config
+	}
+
+	return plex.FindWebhookConfig(defaultPlexHooks, event)
+}
+
+// plexHookCooldown returns the cooldown timer key and duration for a webhook.
+// Play and resume events use the cooldown from the website unless the config overrides it.
+func (c *Client) plexHookCooldown(hook *plex.IncomingWebhook, config *plex.WebhookConfig) (string, time.Duration) {
+	switch event := strings.ToLower(hook.Event); {
+	case config.Cooldown.Duration != 0:
+		return event, config.Cooldown.Duration
+	case event == "media.play", event == "playback.started":
+		return "play", c.plexCooldown()
+	case event == "media.resume":
+		return "resume", c.plexCooldown()
+	default:
+		return event, 0
+	}
+}
+
+// plexHookCommand runs the custom command configured for a webhook event.
+func (c *Client) plexHookCommand(w http.ResponseWriter, hook *plex.IncomingWebhook, config *plex.WebhookConfig) {
+	cmd := c.triggers.Commands.GetByHash(config.Command)
+	if cmd == nil {
+		cmd = c.triggers.Commands.GetByName(config.Command)
+	}
+
+	if cmd == nil {
+		http.Error(w, "command not found", http.StatusNotFound)
+		c.Errorf("Plex Incoming Webhook: %s, %s '%s' ~> %s: command not found: %s",
+			hook.Server.Title, hook.Account.Title, hook.Event, hook.Metadata.Title, config.Command)
+
+		return
+	}
+
+	args := []string{hook.Event, hook.Account.Title, hook.Server.Title, hook.Metadata.Title, hook.Metadata.RatingKey}
+	if cmd.Args < len(args) {
+		args = args[:cmd.Args]
+	}
+
+	cmd.Run(&common.ActionInput{Type: website.EventHook, Args: args})
+	c.Printf("Plex Incoming Webhook: %s, %s '%s' ~> %s (running command: %s)",
+		hook.Server.Title, hook.Account.Title, hook.Event, hook.Metadata.Title, cmd.Name)
+	http.Error(w, "running command", http.StatusAccepted)
+}
+
+// plexTimerKey keeps webhook cooldowns separate for each Plex server.
+func plexTimerKey(hook *plex.IncomingWebhook, event string) string {
+	return hook.Server.UUID + hook.Metadata.Key + event
+}
+
+// plexServerName returns the name of the configured Plex server that sent a webhook.
+// The webhook's own server title is used when it matches no configured server.
+func (c *Client) plexServerName(hook *plex.IncomingWebhook) string {
+	if idx := c.triggers.PlexCron.FindServer(hook.Server.UUID); idx >= 0 {
+		return c.Config.Plex[idx].Server.Name()
+	}
+
+	return hook.Server.Title
+}
+
 func (c *Client) plexCooldown() time.Duration {
 	if ci := clientinfo.Get(); ci != nil {
 		return ci.Actions.Plex.Cooldown.Duration
@@ -52,12 +52,16 @@ func (c *Client) PrintStartupInfo(ctx context.Context, clientInfo *clientinfo.Cl
 	c.printRadarr(&clientInfo.Actions.Apps.Radarr)
 	c.printReadarr(&clientInfo.Actions.Apps.Readarr)
 	c.printSonarr(&clientInfo.Actions.Apps.Sonarr)
+	c.printWhisparr(&clientInfo.Actions.Apps.Whisparr)
+	c.printBazarr()
 	c.printDeluge()
 	c.printNZBGet()
 	c.printQbit()
 	c.printRtorrent()
 	c.printSABnzbd()
 	c.printPlex()
+	c.printJellyfin()
+	c.printOverseerr()
 	c.printTautulli()
 	c.printMySQL()
 	c.Printf(" => Timeout: %s, Quiet: %v", c.Config.Timeout, c.Config.Quiet)
@@ -142,18 +146,53 @@ func (c *Client) printLogFileInfo() { //nolint:cyclop
 
 // printPlex is called on startup to print info about configured Plex instance(s).
 func (c *Client) printPlex() {
-	plex := c.Config.Plex
-	if !plex.Enabled() {
+	if !c.Config.Plex.Enabled() {
 		return
 	}
 
-	name := plex.Server.Name()
+	s := servers
+	if len(c.Config.Plex) == 1 {
+		s = server
+	}
+
+	c.Print(" => Plex Config (enables incoming APIs and webhook):", len(c.Config.Plex), s)
+
+	for i, plex := range c.Config.Plex {
+		name := plex.Server.Name()
+		if name == "" {
+			name = "<connection error?>"
+		}
+
+		c.Printf(" =>    Server %d: %s @ %s timeout:%v check_interval:%s",
+			i+1, name, plex.URL, plex.Timeout, plex.Interval)
+	}
+}
+
+// printJellyfin is called on startup to print info about the configured Jellyfin or Emby server.
+func (c *Client) printJellyfin() {
+	jelly := c.Config.Jellyfin
+	if !jelly.Enabled() {
+		return
+	}
+
+	name := jelly.Server.Name()
 	if name == "" {
 		name = "<connection error?>"
 	}
 
-	c.Printf(" => Plex Config: 1 server: %s @ %s (enables incoming APIs and webhook) timeout:%v check_interval:%s ",
-		name, plex.URL, plex.Timeout, plex.Interval)
+	c.Printf(" => Jellyfin Config: 1 server: %s @ %s (enables incoming APIs and webhook) timeout:%v check_interval:%s ",
+		name, jelly.URL, jelly.Timeout, jelly.Interval)
+}
+
+// printOverseerr is called on startup to print info about the configured Overseerr or Jellyseerr server.
+func (c *Client) printOverseerr() {
+	seerr := c.Config.Overseerr
+	if !seerr.Enabled() {
+		return
+	}
+
+	c.Printf(" => Overseerr Config: 1 server: %s (enables incoming APIs and webhook) timeout:%v check_interval:%s name:%s",
+		seerr.URL, seerr.Timeout, seerr.Interval, seerr.Name)
 }
 
 // printLidarr is called on startup to print info about each configured server.
@@ -241,6 +280,23 @@ func (c *Client) printSonarr(app *clientinfo.InstanceConfig) {
 	}
 }
 
+// printWhisparr is called on startup to print info about each configured server.
+func (c *Client) printWhisparr(app *clientinfo.InstanceConfig) {
+	s := servers
+	if len(c.Config.Whisparr) == 1 {
+		s = server
+	}
+
+	c.Print(" => Whisparr Config:", len(c.Config.Whisparr), s)
+
+	for idx, f := range c.Config.Whisparr {
+		c.Printf(starrLogLine,
+			idx+1, f.URL, f.APIKey != "", f.Timeout, f.ValidSSL, app.Stuck(idx+1), app.Finished(idx+1),
+			app.Corrupt(idx+1) != "" && app.Corrupt(idx+1) != mnd.Disabled, app.Backup(idx+1) != mnd.Disabled,
+			f.HTTPPass != "" && f.HTTPUser != "", f.Password != "" && f.Username != "")
+	}
+}
+
 // printDeluge is called on startup to print info about each configured server.
 func (c *Client) printDeluge() {
 	s := servers
@@ -301,6 +357,21 @@ func (c *Client) printRtorrent() {
 	}
 }
 
+// printBazarr is called on startup to print info about each configured Bazarr server.
+func (c *Client) printBazarr() {
+	s := servers
+	if len(c.Config.Bazarr) == 1 {
+		s = server
+	}
+
+	c.Print(" => Bazarr Config:", len(c.Config.Bazarr), s)
+
+	for i, f := range c.Config.Bazarr {
+		c.Printf(" =>    Server %d: %s, api_key:%v timeout:%s check_interval:%s name:%s",
+			i+1, f.URL, f.APIKey != "", f.Timeout, f.Interval, f.Name)
+	}
+}
+
 // printSABnzbd is called on startup to print info about each configured SAB downloader.
 func (c *Client) printSABnzbd() {
 	s := servers
@@ -49,6 +49,8 @@ type Client struct {
 	cookies    *securecookie.SecureCookie
 	template   *template.Template
 	tunnel     *mulery.Client
+	staleInfo  context.CancelFunc // stops the client info refresher.
+	staleLock  sync.Mutex         // protects staleInfo.
 	webauth    bool
 	noauth     bool
 	authHeader string
@@ -249,11 +251,20 @@ func (c *Client) loadSiteConfig(ctx context.Context) *clientinfo.ClientInfo {
 		if errors.Is(err, website.ErrInvalidAPIKey) {
 			c.ErrorfNoShare("==> Problem validating API key: %v", err)
 			c.ErrorfNoShare("==> NOTICE! No Further requests will be sent to the website until you reload with a valid API Key!")
-		} else {
-			c.Printf("==> [WARNING] Problem validating API key: %v, info: %s", err, clientInfo)
+
+			return nil
 		}
 
-		return nil
+		c.Printf("==> [WARNING] Problem validating API key: %v, info: %s", err, clientInfo)
+
+		if clientInfo, err = c.triggers.CI.LoadCachedClientInfo(); err != nil {
+			c.Printf("==> [WARNING] No cached website configuration available: %v", err)
+			return nil
+		}
+
+		c.Printf("==> [WARNING] Using cached website configuration, stale since %s%s",
+			clientInfo.StaleSince.Format(time.RFC1123), mnd.DurationAge(clientInfo.StaleSince))
+		c.refreshStaleInfo(ctx)
 	}
 
 	// Snapshot is a bit complicated because config-file data (plugins) merges with site-data (snapshot config).
@@ -275,6 +286,7 @@ func (c *Client) configureServices(ctx context.Context) *clientinfo.ClientInfo {
 	}
 
 	c.configureServicesPlex(ctx)
+	c.configureServicesJellyfin(ctx)
 	c.Config.Snapshot.Validate()
 	c.PrintStartupInfo(ctx, clientInfo)
 	c.triggers.Start(ctx, c.sighup, c.sigkil)
@@ -283,16 +295,69 @@ func (c *Client) configureServices(ctx context.Context) *clientinfo.ClientInfo {
 	return clientInfo
 }
 
+// refreshStaleInfo starts a go routine that polls the website for client info while we run on a cached copy.
+// Once the website answers, the app is reloaded so everything gets configured with fresh data.
+func (c *Client) refreshStaleInfo(ctx context.Context) {
+	c.staleLock.Lock()
+	ctx, c.staleInfo = context.WithCancel(ctx)
+	c.staleLock.Unlock()
+
+	go func() {
+		defer c.CapturePanic()
+
+		ticker := time.NewTicker(clientinfo.StaleRefresh)
+		defer ticker.Stop()
+
+		// The website never saw our startup, so the first request is flagged as one. The rest are not.
+		startup := true
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			_, err := c.triggers.CI.SaveClientInfo(ctx, startup)
+			startup = false
+
+			if err != nil {
+				c.Debugf("Website still unavailable, continuing with cached configuration: %v", err)
+				continue
+			}
+
+			c.triggerConfigReload(website.EventStart, "Website available again, replacing cached configuration")
+
+			return
+		}
+	}()
+}
+
 func (c *Client) configureServicesPlex(ctx context.Context) {
-	if !c.Config.Plex.Enabled() {
+	for idx, plex := range c.Config.Plex {
+		if !plex.Enabled() {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, plex.Timeout.Duration)
+		if _, err := plex.GetInfo(ctx); err != nil {
+			c.Errorf("=> Getting Plex Media Server %d info (check url and token): %v", idx+1, err)
+		}
+
+		cancel()
+	}
+}
+
+func (c *Client) configureServicesJellyfin(ctx context.Context) {
+	if !c.Config.Jellyfin.Enabled() {
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, c.Config.Plex.Timeout.Duration)
+	ctx, cancel := context.WithTimeout(ctx, c.Config.Jellyfin.Timeout.Duration)
 	defer cancel()
 
-	if _, err := c.Config.Plex.GetInfo(ctx); err != nil {
-		c.Errorf("=> Getting Plex Media Server info (check url and token): %v", err)
+	if _, err := c.Config.Jellyfin.GetInfoWithContext(ctx); err != nil {
+		c.Errorf("=> Getting Jellyfin server info (check url and api key): %v", err)
 	}
 }
 
@@ -395,6 +460,13 @@ func (c *Client) reloadConfiguration(ctx context.Context, event website.EventTyp
 
 // stop is called from at least two different exit points and on reload.
 func (c *Client) stop(ctx context.Context, event website.EventType) error {
+	c.staleLock.Lock()
+	if c.staleInfo != nil {
+		c.staleInfo()
+		c.staleInfo = nil
+	}
+	c.staleLock.Unlock()
+
 	defer func() {
 		defer c.CapturePanic()
 		c.triggers.Stop(event)
@@ -265,11 +265,13 @@ func (c *Client) notifiarrMenu() {
 	menu["corrRadarr"] = data.AddSubMenuItem("Check Radarr Corruption", "check latest backup database in each instance for corruption")
 	menu["corrReadarr"] = data.AddSubMenuItem("Check Readarr Corruption", "check latest backup database in each instance for corruption")
 	menu["corrSonarr"] = data.AddSubMenuItem("Check Sonarr Corruption", "check latest backup database in each instance for corruption")
+	menu["corrWhisparr"] = data.AddSubMenuItem("Check Whisparr Corruption", "check latest backup database in each instance for corruption")
 	menu["backLidarr"] = data.AddSubMenuItem("Send Lidarr Backups", "send backup file list for each instance to Notifiarr")
 	menu["backProwlarr"] = data.AddSubMenuItem("Send Prowlarr Backups", "send backup file list for each instance to Notifiarr")
 	menu["backRadarr"] = data.AddSubMenuItem("Send Radarr Backups", "send backup file list for each instance to Notifiarr")
 	menu["backReadarr"] = data.AddSubMenuItem("Send Readarr Backups", "send backup file list for each instance to Notifiarr")
 	menu["backSonarr"] = data.AddSubMenuItem("Send Sonarr Backups", "send backup file list for each instance to Notifiarr")
+	menu["backWhisparr"] = data.AddSubMenuItem("Send Whisparr Backups", "send backup file list for each instance to Notifiarr")
 
 	c.notifiarrMenuActions()
 }
@@ -301,6 +303,9 @@ func (c *Client) notifiarrMenuActions() {
 	menu["corrSonarr"].Click(func() {
 		_ = c.triggers.Backups.Corruption(&common.ActionInput{Type: website.EventUser}, starr.Sonarr)
 	})
+	menu["corrWhisparr"].Click(func() {
+		_ = c.triggers.Backups.Corruption(&common.ActionInput{Type: website.EventUser}, starr.Whisparr)
+	})
 	menu["backLidarr"].Click(func() {
 		_ = c.triggers.Backups.Backup(&common.ActionInput{Type: website.EventUser}, starr.Lidarr)
 	})
@@ -316,6 +321,9 @@ func (c *Client) notifiarrMenuActions() {
 	menu["backSonarr"].Click(func() {
 		_ = c.triggers.Backups.Backup(&common.ActionInput{Type: website.EventUser}, starr.Sonarr)
 	})
+	menu["backWhisparr"].Click(func() {
+		_ = c.triggers.Backups.Backup(&common.ActionInput{Type: website.EventUser}, starr.Whisparr)
+	})
 }
 
 func (c *Client) debugMenu() {
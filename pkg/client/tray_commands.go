@@ -107,6 +107,16 @@ func (c *Client) displayConfig() string { //nolint: funlen,cyclop
 		}
 	}
 
+	if count := len(c.Config.Whisparr); count == 1 {
+		out += fmt.Sprintf("\n- Whisparr Config: 1 server: %s, apikey:%v, timeout:%v, verify ssl:%v",
+			c.Config.Whisparr[0].URL, c.Config.Whisparr[0].APIKey != "", c.Config.Whisparr[0].Timeout, c.Config.Whisparr[0].ValidSSL)
+	} else {
+		for _, f := range c.Config.Whisparr {
+			out += fmt.Sprintf("\n- Whisparr Server: %s, apikey:%v, timeout:%v, verify ssl:%v",
+				f.URL, f.APIKey != "", f.Timeout, f.ValidSSL)
+		}
+	}
+
 	return out + "\n"
 }
 
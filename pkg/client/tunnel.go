@@ -37,13 +37,18 @@ func (c *Client) poolMax(info *clientinfo.ClientInfo) int {
 	poolmax := len(c.Config.Apps.Sonarr) + len(c.Config.Apps.Radarr) + len(c.Config.Apps.Lidarr) +
 		len(c.Config.Apps.Readarr) + len(c.Config.Apps.Prowlarr) + len(c.Config.Apps.Deluge) +
 		len(c.Config.Apps.Qbit) + len(c.Config.Apps.Rtorrent) + len(c.Config.Apps.SabNZB) +
-		len(c.Config.Apps.NZBGet) + 1
+		len(c.Config.Apps.NZBGet) + len(c.Config.Apps.Whisparr) + len(c.Config.Apps.Bazarr) +
+		len(c.Config.Apps.Plex) + 1
 
-	if c.Config.Apps.Plex.Enabled() {
+	if c.Config.Apps.Tautulli.Enabled() {
 		poolmax++
 	}
 
-	if c.Config.Apps.Tautulli.Enabled() {
+	if c.Config.Apps.Jellyfin.Enabled() {
+		poolmax++
+	}
+
+	if c.Config.Apps.Overseerr.Enabled() {
 		poolmax++
 	}
 
@@ -20,13 +20,18 @@ import (
 
 	"github.com/BurntSushi/toml"
 	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/plex"
 	"github.com/Notifiarr/notifiarr/pkg/logs"
 	"github.com/Notifiarr/notifiarr/pkg/mnd"
 	"github.com/Notifiarr/notifiarr/pkg/services"
 	"github.com/Notifiarr/notifiarr/pkg/snapshot"
 	"github.com/Notifiarr/notifiarr/pkg/triggers"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/backups"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/commands"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/downloads"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/filewatch"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/plexcron"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/starrqueue"
 	"github.com/Notifiarr/notifiarr/pkg/ui"
 	"github.com/Notifiarr/notifiarr/pkg/website"
 	"github.com/Notifiarr/notifiarr/pkg/website/clientinfo"
@@ -50,22 +55,32 @@ const (
 
 // Config represents the data in our config file.
 type Config struct {
-	HostID     string                 `json:"hostId"      toml:"host_id"       xml:"host_id"       yaml:"hostId"`
-	UIPassword CryptPass              `json:"uiPassword"  toml:"ui_password"   xml:"ui_password"   yaml:"uiPassword"`
-	BindAddr   string                 `json:"bindAddr"    toml:"bind_addr"     xml:"bind_addr"     yaml:"bindAddr"`
-	SSLCrtFile string                 `json:"sslCertFile" toml:"ssl_cert_file" xml:"ssl_cert_file" yaml:"sslCertFile"`
-	SSLKeyFile string                 `json:"sslKeyFile"  toml:"ssl_key_file"  xml:"ssl_key_file"  yaml:"sslKeyFile"`
-	Upstreams  []string               `json:"upstreams"   toml:"upstreams"     xml:"upstreams"     yaml:"upstreams"`
-	AutoUpdate string                 `json:"autoUpdate"  toml:"auto_update"   xml:"auto_update"   yaml:"autoUpdate"`
-	UnstableCh bool                   `json:"unstableCh"  toml:"unstable_ch"   xml:"unstable_ch"   yaml:"unstableCh"`
-	Timeout    cnfg.Duration          `json:"timeout"     toml:"timeout"       xml:"timeout"       yaml:"timeout"`
-	Retries    int                    `json:"retries"     toml:"retries"       xml:"retries"       yaml:"retries"`
-	Snapshot   *snapshot.Config       `json:"snapshot"    toml:"snapshot"      xml:"snapshot"      yaml:"snapshot"`
-	Services   *services.Config       `json:"services"    toml:"services"      xml:"services"      yaml:"services"`
-	Service    []*services.Service    `json:"service"     toml:"service"       xml:"service"       yaml:"service"`
-	EnableApt  bool                   `json:"apt"         toml:"apt"           xml:"apt"           yaml:"apt"`
-	WatchFiles []*filewatch.WatchFile `json:"watchFiles"  toml:"watch_file"    xml:"watch_file"    yaml:"watchFiles"`
-	Commands   []*commands.Command    `json:"commands"    toml:"command"       xml:"command"       yaml:"commands"`
+	HostID     string                  `json:"hostId"      toml:"host_id"       xml:"host_id"       yaml:"hostId"`
+	UIPassword CryptPass               `json:"uiPassword"  toml:"ui_password"   xml:"ui_password"   yaml:"uiPassword"`
+	BindAddr   string                  `json:"bindAddr"    toml:"bind_addr"     xml:"bind_addr"     yaml:"bindAddr"`
+	SSLCrtFile string                  `json:"sslCertFile" toml:"ssl_cert_file" xml:"ssl_cert_file" yaml:"sslCertFile"`
+	SSLKeyFile string                  `json:"sslKeyFile"  toml:"ssl_key_file"  xml:"ssl_key_file"  yaml:"sslKeyFile"`
+	Upstreams  []string                `json:"upstreams"   toml:"upstreams"     xml:"upstreams"     yaml:"upstreams"`
+	AutoUpdate string                  `json:"autoUpdate"  toml:"auto_update"   xml:"auto_update"   yaml:"autoUpdate"`
+	UnstableCh bool                    `json:"unstableCh"  toml:"unstable_ch"   xml:"unstable_ch"   yaml:"unstableCh"`
+	Timeout    cnfg.Duration           `json:"timeout"     toml:"timeout"       xml:"timeout"       yaml:"timeout"`
+	Retries    int                     `json:"retries"     toml:"retries"       xml:"retries"       yaml:"retries"`
+	Snapshot   *snapshot.Config        `json:"snapshot"    toml:"snapshot"      xml:"snapshot"      yaml:"snapshot"`
+	Services   *services.Config        `json:"services"    toml:"services"      xml:"services"      yaml:"services"`
+	Service    []*services.Service     `json:"service"     toml:"service"       xml:"service"       yaml:"service"`
+	EnableApt  bool                    `json:"apt"         toml:"apt"           xml:"apt"           yaml:"apt"`
+	WatchFiles []*filewatch.WatchFile  `json:"watchFiles"  toml:"watch_file"    xml:"watch_file"    yaml:"watchFiles"`
+	Commands   []*commands.Command     `json:"commands"    toml:"command"       xml:"command"       yaml:"commands"`
+	PlexPolicy []*plexcron.Policy      `json:"plexPolicy"  toml:"plex_policy"   xml:"plex_policy"   yaml:"plexPolicy"`
+	PlexHist   *plexcron.HistoryConfig `json:"plexHistory" toml:"plex_history"  xml:"plex_history"  yaml:"plexHistory"`
+	PlexHooks  []*plex.WebhookConfig   `json:"plexHooks"   toml:"plex_webhook"  xml:"plex_webhook"  yaml:"plexHooks"`
+	QueueRules []*starrqueue.Rule      `json:"queueRules"  toml:"queue_rule"    xml:"queue_rule"    yaml:"queueRules"`
+	Stalled    *starrqueue.StallCheck  `json:"stalled"     toml:"stalled"       xml:"stalled"       yaml:"stalled"`
+	SeedRules  []*downloads.SeedRule   `json:"seedRules"   toml:"seed_rule"     xml:"seed_rule"     yaml:"seedRules"`
+	Failed     *downloads.FailedConfig `json:"failedNzb"   toml:"failed_nzb"    xml:"failed_nzb"    yaml:"failedNzb"`
+	DiskGuard  *downloads.DiskGuard    `json:"diskGuard"   toml:"disk_guard"    xml:"disk_guard"    yaml:"diskGuard"`
+	Orphans    *downloads.OrphanScan   `json:"orphans"     toml:"orphans"       xml:"orphans"       yaml:"orphans"`
+	Archive    *backups.Archive        `json:"archive"     toml:"archive"       xml:"archive"       yaml:"archive"`
 	*logs.LogConfig
 	*apps.Apps
 	*website.Server `json:"-" toml:"-" xml:"-" yaml:"-"`
@@ -137,13 +152,32 @@ func (c *Config) Get(flag *Flags) (*Config, error) {
 		}
 	}
 
-	if _, err := cnfg.UnmarshalENV(c, flag.EnvPrefix); err != nil {
+	env := cnfg.MapEnvPairs(flag.EnvPrefix, os.Environ())
+	legacyPlexEnv(flag.EnvPrefix, env)
+
+	if _, err := (&cnfg.ENV{Pfx: flag.EnvPrefix, Tag: cnfg.ENVTag}).UnmarshalMap(env, c); err != nil {
 		return nil, fmt.Errorf("environment variables: %w", err)
 	}
 
 	return c.CopyConfig()
 }
 
+// legacyPlexEnv copies single-server Plex variables (ie. DN_PLEX_URL) to the first server (ie. DN_PLEX_0_URL).
+// Plex used to allow only one server, and this keeps existing environments working.
+// Only the parsed variables are changed, not the process environment.
+func legacyPlexEnv(prefix string, env cnfg.Pairs) {
+	for _, name := range []string{"NAME", "URL", "TOKEN", "INTERVAL", "TIMEOUT", "VALID_SSL"} {
+		val, ok := env[prefix+"_PLEX_"+name]
+		if !ok {
+			continue
+		}
+
+		if _, ok := env[prefix+"_PLEX_0_"+name]; !ok {
+			env[prefix+"_PLEX_0_"+name] = val
+		}
+	}
+}
+
 // ExpandHomedir expands a ~ to a homedir, or returns the original path in case of any error.
 func ExpandHomedir(filePath string) string {
 	expanded, err := homedir.Expand(filePath)
@@ -231,10 +265,17 @@ func (c *Config) setup(logger *logs.Logger, flag *Flags) *triggers.Actions {
 		c.LogConfig.AppName = mnd.Title
 	}
 
+	// The website's client info is cached next to the config file.
+	cacheFile := ""
+	if flag.ConfigFile != "" {
+		cacheFile = filepath.Join(filepath.Dir(flag.ConfigFile), clientinfo.CacheFileName)
+	}
+
 	// Ordering.....
 	clientinfo := &clientinfo.Config{
-		Server: c.Server,
-		Apps:   c.Apps,
+		Server:    c.Server,
+		Apps:      c.Apps,
+		CacheFile: cacheFile,
 	}
 	triggers := triggers.New(&triggers.Config{
 		Apps:       c.Apps,
@@ -243,6 +284,15 @@ func (c *Config) setup(logger *logs.Logger, flag *Flags) *triggers.Actions {
 		WatchFiles: c.WatchFiles,
 		LogFiles:   c.LogConfig.GetActiveLogFilePaths(),
 		Commands:   c.Commands,
+		PlexPolicy: c.PlexPolicy,
+		PlexHist:   c.PlexHist,
+		QueueRules: c.QueueRules,
+		Stalled:    c.Stalled,
+		SeedRules:  c.SeedRules,
+		Failed:     c.Failed,
+		DiskGuard:  c.DiskGuard,
+		Orphans:    c.Orphans,
+		Archive:    c.Archive,
 		ClientInfo: clientinfo,
 		ConfigFile: flag.ConfigFile,
 		AutoUpdate: c.AutoUpdate,
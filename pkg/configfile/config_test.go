@@ -0,0 +1,76 @@
+package configfile //nolint:testpackage // legacyPlexEnv is not exported.
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/downloads"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/plexcron"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golift.io/cnfg"
+)
+
+func TestLegacyPlexEnv(t *testing.T) {
+	t.Parallel()
+
+	env := cnfg.Pairs{
+		"TEST_PLEX_URL":     "http://legacy",
+		"TEST_PLEX_TOKEN":   "legacy-token",
+		"TEST_PLEX_0_TOKEN": "new-token",
+		"TEST_PLEX_1_URL":   "http://second",
+	}
+
+	legacyPlexEnv("TEST", env)
+
+	assert.Equal(t, "http://legacy", env["TEST_PLEX_0_URL"], "legacy variables must be copied to the first server")
+	assert.Equal(t, "new-token", env["TEST_PLEX_0_TOKEN"], "legacy variables must not replace first server variables")
+	assert.NotContains(t, env, "TEST_PLEX_0_NAME")
+
+	config := &Config{}
+	_, err := (&cnfg.ENV{Pfx: "TEST", Tag: cnfg.ENVTag}).UnmarshalMap(env, config)
+	require.NoError(t, err)
+	require.Len(t, config.Plex, 2)
+	assert.Equal(t, "http://legacy", config.Plex[0].URL)
+	assert.Equal(t, "new-token", config.Plex[0].Token)
+	assert.Equal(t, "http://second", config.Plex[1].URL)
+
+	_, set := os.LookupEnv("TEST_PLEX_0_URL")
+	assert.False(t, set, "the process environment must not be changed")
+}
+
+func TestTemplatePlexHistory(t *testing.T) {
+	t.Parallel()
+
+	for _, history := range []*plexcron.HistoryConfig{nil, {Enabled: true, MaxSize: 5, SaveIPs: true}} {
+		config := NewConfig(nil)
+		config.PlexHist = history
+
+		var buf bytes.Buffer
+		require.NoError(t, Template.Execute(&buf, config))
+
+		output := &Config{}
+		_, err := toml.Decode(buf.String(), output)
+		require.NoError(t, err, "the config template must render valid toml")
+		assert.Equal(t, history, output.PlexHist)
+	}
+}
+
+func TestTemplateFailed(t *testing.T) {
+	t.Parallel()
+
+	for _, failed := range []*downloads.FailedConfig{nil, {Enabled: true}} {
+		config := NewConfig(nil)
+		config.Failed = failed
+
+		var buf bytes.Buffer
+		require.NoError(t, Template.Execute(&buf, config))
+
+		output := &Config{}
+		_, err := toml.Decode(buf.String(), output)
+		require.NoError(t, err, "the config template must render valid toml")
+		assert.Equal(t, failed, output.Failed)
+	}
+}
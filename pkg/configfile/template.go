@@ -181,6 +181,9 @@ retries = {{.Retries}}
   interval = "{{.Interval}}" # Service check duration (if name is not empty).
   timeout  = "{{.Timeout}}"
   deletes  = {{.Deletes}}
+  {{- if .Health}}
+  health_check = true # Include health messages in the service check.
+  {{- end}}
   {{- if .ValidSSL}}
   valid_ssl = true
   {{- end}}
@@ -202,6 +205,12 @@ retries = {{.Retries}}
   http_pass = '''{{.HTTPPass}}'''{{end}}
   interval = "{{.Interval}}" # Service check duration (if name is not empty).
   timeout  = "{{.Timeout}}"
+  {{- if .IndexerCritical.Duration}}
+  indexer_critical = "{{.IndexerCritical}}" # Indexer checks go critical after failing this long.
+  {{- end}}
+  {{- if .Health}}
+  health_check = true # Include health messages in the service check.
+  {{- end}}
   {{- if .ValidSSL}}
   valid_ssl = true
   {{- end}}
@@ -224,6 +233,9 @@ retries = {{.Retries}}
   interval = "{{.Interval}}" # Service check duration (if name is not empty).
   timeout  = "{{.Timeout}}"
   deletes  = {{.Deletes}}
+  {{- if .Health}}
+  health_check = true # Include health messages in the service check.
+  {{- end}}
   {{- if .ValidSSL}}
   valid_ssl = true
   {{- end}}
@@ -246,6 +258,9 @@ retries = {{.Retries}}
   interval = "{{.Interval}}" # Service check duration (if name is not empty).
   timeout  = "{{.Timeout}}"
   deletes  = {{.Deletes}}
+  {{- if .Health}}
+  health_check = true # Include health messages in the service check.
+  {{- end}}
   {{- if .ValidSSL}}
   valid_ssl = true
   {{- end}}
@@ -268,6 +283,9 @@ retries = {{.Retries}}
   interval = "{{.Interval}}" # Service check duration (if name is not empty).
   timeout  = "{{.Timeout}}"
   deletes  = {{.Deletes}}
+  {{- if .Health}}
+  health_check = true # Include health messages in the service check.
+  {{- end}}
   {{- if .ValidSSL}}
   valid_ssl = true
   {{- end}}
@@ -279,8 +297,113 @@ retries = {{.Retries}}
 #api_key   = ""
 
 
+{{end}}{{if .Whisparr}}{{range .Whisparr}}[[whisparr]]
+  name     = '''{{.Name}}'''
+  url      = '''{{.URL}}'''
+  api_key  = '''{{.APIKey}}'''{{if .Username}}
+  username = '''{{.Username}}'''
+  password = '''{{.Password}}'''{{end}}{{if .HTTPUser}}
+  http_user = '''{{.HTTPUser}}'''
+  http_pass = '''{{.HTTPPass}}'''{{end}}
+  interval = "{{.Interval}}" # Service check duration (if name is not empty).
+  timeout  = "{{.Timeout}}"
+  deletes  = {{.Deletes}}
+  {{- if .Health}}
+  health_check = true # Include health messages in the service check.
+  {{- end}}
+  {{- if .ValidSSL}}
+  valid_ssl = true
+  {{- end}}
+
+{{end}}
+{{else}}#[[whisparr]]
+#name      = ""  # Set a name to enable checks of your service.
+#url       = "http://whisparr:6969/"
+#api_key   = ""
+
+
+{{end}}{{if .Bazarr}}{{range .Bazarr}}[[bazarr]]
+  name     = '''{{.Name}}'''
+  url      = '''{{.URL}}'''
+  api_key  = '''{{.APIKey}}'''{{if .HTTPUser}}
+  http_user = '''{{.HTTPUser}}'''
+  http_pass = '''{{.HTTPPass}}'''{{end}}
+  interval = "{{.Interval}}" # Service check duration (if name is not empty).
+  timeout  = "{{.Timeout}}"
+  {{- if .ValidSSL}}
+  valid_ssl = true
+  {{- end}}
+
+{{end}}
+{{else}}#[[bazarr]]
+#name      = ""  # Set a name to enable checks of your service.
+#url       = "http://bazarr:6767/"
+#api_key   = ""
+
+
 {{end -}}
 
+## Stalled downloads are included with stuck items. A downloading item is stalled when its size left
+## does not change for the window, and any queued item is stalled when it's been queued longer than max_age.
+## Both are measured from when the client first saw the item. Set either to 0 to disable it.
+##
+{{if .Stalled}}[stalled]
+  window  = "{{.Stalled.Window}}"
+  max_age = "{{.Stalled.MaxAge}}"
+{{else}}#[stalled]
+#window  = "6h"
+#max_age = "48h"
+{{end}}
+## Queue Rules remove stuck items from a starr app's queue. An item matches a rule when it has
+## one of the statuses (completed, warning, failed, error, importBlocked, importPending, etc), and a
+## status or error message that contains message, and has been stuck for age (since the client saw it).
+## Set app to lidarr, radarr, readarr, sonarr or whisparr. Instance 0 applies to every instance of the app.
+## remove deletes the download from the download client, blocklist blocks the release,
+## and search looks for a replacement (requires blocklist). dry_run only writes the audit log.
+## Removals are limited by the app's deletes setting (per hour), and are written to queueaudit.jsonl.
+##
+{{if .QueueRules}}{{range $item := .QueueRules}}{{if $item}}[[queue_rule]]
+  name      = '''{{toml $item.Name}}'''
+  app       = "{{$item.App}}"
+  instance  = {{$item.Instance}}
+  status    = [{{range $s := $item.Status}}"{{$s}}",{{end}}]
+  message   = '''{{toml $item.Message}}'''
+  age       = "{{$item.Age}}"
+  remove    = {{$item.Remove}}
+  blocklist = {{$item.Blocklist}}
+  search    = {{$item.Search}}
+  dry_run   = {{$item.DryRun}}
+
+{{end}}{{end}}
+{{- else}}#[[queue_rule]]
+#name      = "Sonarr Warnings"
+#app       = "sonarr"
+#instance  = 0
+#status    = ["warning"]
+#age       = "6h"
+#remove    = true
+#blocklist = true
+#search    = true
+#dry_run   = true
+{{end}}
+
+## Backup Archive copies each starr backup that passes a corruption check into path, in a folder for each
+## app and instance (like path/sonarr/1). Put it on a different disk than your starr apps. The newest backup
+## from each of the last daily days, weekly weeks and monthly months is kept, and the rest are deleted.
+## Set all three to 0 to keep every backup. Backups are only checked when corruption checks are enabled on the website.
+##
+{{if .Archive}}[archive]
+  path    = '''{{toml .Archive.Path}}'''
+  daily   = {{.Archive.Daily}}
+  weekly  = {{.Archive.Weekly}}
+  monthly = {{.Archive.Monthly}}
+{{else}}#[archive]
+#path    = '/backups/starr'
+#daily   = 7
+#weekly  = 4
+#monthly = 6
+{{end}}
+
 # Download Client Configs (below) are used for dashboard state and service checks.
 
 {{if .Deluge}}{{range .Deluge }}[[deluge]]
@@ -395,23 +518,234 @@ retries = {{.Retries}}
 
 {{end -}}
 
+## Failed Usenet Downloads sends the failed downloads in your SABnzbd and NZBGet history to the website every
+## hour, grouped by category and indexer. Only the failures that are newer than the last report are sent.
+##
+{{if .Failed}}[failed_nzb]
+  enabled = {{.Failed.Enabled}}
+{{else}}#[failed_nzb]
+#enabled = false
+{{end}}
+
+## Seed Rules pause or remove finished torrents in qbittorrent, deluge, transmission and rtorrent once they
+## reach their seeding goals: min_ratio and min_seed_time, or max_seed_time. A torrent matches a rule when it
+## is in client, has category, and its tracker contains tracker. Leave any of those empty to match everything.
+## Only the first rule that matches a torrent is checked, so put specific rules before general rules.
+## Set action to pause or remove. delete_data also deletes the files (remove only), but only after a
+## starr app imported the download, and never for rtorrent. dry_run only writes the audit log.
+## Torrents are checked every 10 minutes, and every action is written to seedaudit.jsonl.
+##
+{{if .SeedRules}}{{range $item := .SeedRules}}{{if $item}}[[seed_rule]]
+  name          = '''{{toml $item.Name}}'''
+  client        = "{{$item.Client}}"
+  category      = '''{{toml $item.Category}}'''
+  tracker       = '''{{toml $item.Tracker}}'''
+  min_ratio     = {{printf "%.2f" $item.MinRatio}}
+  min_seed_time = "{{$item.MinSeedTime}}"
+  max_seed_time = "{{$item.MaxSeedTime}}"
+  action        = "{{$item.Action}}"
+  delete_data   = {{$item.DeleteData}}
+  dry_run       = {{$item.DryRun}}
+
+{{end}}{{end}}
+{{- else}}#[[seed_rule]]
+#name          = "Movies"
+#client        = "qbittorrent"
+#category      = "radarr"
+#tracker       = ""
+#min_ratio     = 2.0
+#min_seed_time = "72h"
+#max_seed_time = "720h"
+#action        = "remove"
+#delete_data   = true
+#dry_run       = true
+{{end}}
+
+## Disk Guard pauses every running download in your download clients when any volume with one of paths
+## passes high percent used. They are resumed when every volume drops below low percent used, and the
+## website is notified both times. Only downloads the guard paused are resumed. Set new_only to leave
+## running downloads alone, and only pause the downloads added after the high-water mark was passed.
+## Volumes are checked every minute. Add your download and media paths, like your Sonarr database path.
+## Percent used is counted like df does. The paused downloads are saved in diskguard.json, so they are
+## still resumed after a restart.
+##
+{{if .DiskGuard}}[disk_guard]
+  paths    = [{{range $s := .DiskGuard.Paths}}'''{{toml $s}}''',{{end}}]
+  high     = {{printf "%.2f" .DiskGuard.High}}
+  low      = {{printf "%.2f" .DiskGuard.Low}}
+  new_only = {{.DiskGuard.NewOnly}}
+{{else}}#[disk_guard]
+#paths    = ['/downloads', '/config']
+#high     = 95.0
+#low      = 90.0
+#new_only = false
+{{end}}
+
+## Orphan Scan finds the files and folders in paths that no download client is tracking, and no starr queue
+## or history item references, and sends them to the website. List the folders your downloads are saved in.
+## Set action to quarantine or delete to act on the orphans once they have been orphans for the grace period.
+## quarantine moves them into the quarantine folder, which must be on the same disk. delete only runs when
+## allow_delete is also true. Nothing is moved or deleted if any download client or starr app returns an error.
+## Client save paths and category folders are never orphans; the files inside them are checked instead.
+## The folders are scanned every 6 hours.
+##
+{{if .Orphans}}[orphans]
+  paths        = [{{range $s := .Orphans.Paths}}'''{{toml $s}}''',{{end}}]
+  action       = "{{.Orphans.Action}}"
+  allow_delete = {{.Orphans.AllowDelete}}
+  quarantine   = '''{{toml .Orphans.Quarantine}}'''
+  grace        = "{{.Orphans.Grace}}"
+{{else}}#[orphans]
+#paths        = ['/downloads/complete']
+#action       = ""
+#allow_delete = false
+#quarantine   = '/downloads/quarantine'
+#grace        = "24h"
+{{end}}
+
 #################
 # Plex Settings #
 #################
 
 ## Find your token: https://support.plex.tv/articles/204059436-finding-an-authentication-token-x-plex-token/
+## Add one [[plex]] section for each Plex Media Server. Webhooks are matched to a server by its machine identifier.
+## The name is used for the service check; the first server defaults to "Plex Server".
+## Set auto_scan to scan the folder of each new Radarr and Sonarr import instead of waiting for a full library scan.
+## Add a [[plex.path_map]] if Radarr or Sonarr see your media at a different path than Plex, like with Docker mounts.
 ##
-{{if and .Plex (not force)}}[plex]
-  url     = '''{{.Plex.URL}}'''   # Your plex URL
-  token   = '''{{.Plex.Token}}'''   # your plex token; get this from a web inspector
-  interval = "{{.Plex.Interval}}" # Service check duration (if name is not empty).
-  timeout = "{{.Plex.Timeout}}"  # how long to wait for HTTP responses
-  {{- if .Plex.ValidSSL}}
+{{if and .Plex (not force)}}{{range .Plex}}[[plex]]
+  name    = '''{{.ExtraConfig.Name}}''' # service check name.
+  url     = '''{{.URL}}'''   # Your plex URL
+  token   = '''{{.Token}}'''   # your plex token; get this from a web inspector
+  interval = "{{.Interval}}" # Service check duration.
+  timeout = "{{.Timeout}}"  # how long to wait for HTTP responses
+  {{- if .ValidSSL}}
   valid_ssl = true
   {{- end}}
-{{- else}}#[plex]
+  {{- if .AutoScan}}
+  auto_scan = true
+  {{- end}}
+  {{- range .PathMaps}}
+  [[plex.path_map]]
+    from = '''{{.From}}''' # Radarr or Sonarr path
+    to   = '''{{.To}}''' # Plex path
+  {{- end}}
+
+{{end}}
+{{- else}}#[[plex]]
+#name    = "" # service check name.
 #url     = "http://localhost:32400/" # Your plex URL
 #token   = "" # your plex token; get this from a web inspector
+#auto_scan = false
+#  [[plex.path_map]]
+#  from = "/movies" # Radarr or Sonarr path
+#  to   = "/data/movies" # Plex path
+{{- end }}
+
+## Plex History saves every finished Plex session to plexhistory.jsonl next to this config file,
+## and adds the /api/plex/history API. Items older than max_age are pruned (0 keeps them all), and the file
+## is rotated when it is larger than max_size megabytes (default 10). Player IPs are only saved with save_ips.
+##
+{{if .PlexHist}}[plex_history]
+  enabled  = {{.PlexHist.Enabled}}
+  max_age  = "{{.PlexHist.MaxAge}}"
+  max_size = {{.PlexHist.MaxSize}}
+  save_ips = {{.PlexHist.SaveIPs}}
+{{else}}#[plex_history]
+#enabled  = false
+#max_age  = "2160h"
+#max_size = 10
+#save_ips = false
+{{end}}
+## Plex Policies check every session on every Plex server once a minute.
+## Rules: no_4k_transcode, no_transcode, max_remote_streams, max_bandwidth (kbps).
+## no_transcode applies to the listed libraries (titles or IDs), or all libraries if none are listed.
+## Actions: notify sends a notification, kill ends the session with the reason,
+## and warn sends a notification, then ends the session if it still breaks the rule after the grace period.
+## Users (names or IDs) listed in exempt are never checked.
+##
+{{if .PlexPolicy}}{{range $item := .PlexPolicy}}{{if $item}}[[plex_policy]]
+  name      = '''{{toml $item.Name}}'''
+  rule      = "{{$item.Rule}}"
+  action    = "{{$item.Action}}"
+  max       = {{$item.Max}}
+  libraries = [{{range $s := $item.Libraries}}'''{{toml $s}}''',{{end}}]
+  exempt    = [{{range $s := $item.Exempt}}'''{{toml $s}}''',{{end}}]
+  reason    = '''{{toml $item.Reason}}'''
+  grace     = "{{$item.Grace}}"
+
+{{end}}{{end}}
+{{- else}}#[[plex_policy]]
+#name   = "No 4K Transcodes"
+#rule   = "no_4k_transcode"
+#action = "kill"
+#reason = "4K transcoding is not allowed. Use a device that can direct play 4K, or pick a 1080p version."
+{{- end }}
+
+## Plex Webhooks control what happens to each incoming Plex webhook event.
+## Actions: relay sends the webhook to Notifiarr, sessions also collects sessions from the Plex server,
+## command runs a custom command (by name or hash), and ignore drops the event.
+## Commands that take arguments receive the event, user, server, title and rating key, in that order.
+## Event may end with * to match many events, ie. admin.database.*
+## Events not listed here use the built-in handling. Cooldown skips repeats of the same event and item.
+## A negative cooldown disables the default cooldown on media.play and media.resume.
+##
+{{if .PlexHooks}}{{range $item := .PlexHooks}}{{if $item}}[[plex_webhook]]
+  event    = '''{{toml $item.Event}}'''
+  action   = "{{$item.Action}}"
+  command  = '''{{toml $item.Command}}'''
+  cooldown = "{{$item.Cooldown}}"
+
+{{end}}{{end}}
+{{- else}}#[[plex_webhook]]
+#event    = "media.stop"
+#action   = "command"
+#command  = "My Plex Command"
+#cooldown = "30s"
+{{- end }}
+
+#####################
+# Jellyfin Settings #
+#####################
+
+## Works with Jellyfin or Emby. Create an API key in the server's dashboard.
+## Set a name to change the service check name. Webhooks go to /jellyfin?token=<api key>
+##
+{{if and .Jellyfin .Jellyfin.Config .Jellyfin.URL (not force)}}[jellyfin]
+  name     = '''{{.Jellyfin.ExtraConfig.Name}}''' # service check name.
+  url      = '''{{.Jellyfin.URL}}''' # Your Jellyfin or Emby URL
+  api_key  = "{{.Jellyfin.APIKey}}" # your api key; get this from the dashboard
+  interval = "{{.Jellyfin.Interval}}" # Service check duration.
+  timeout  = "{{.Jellyfin.Timeout}}" # how long to wait for HTTP responses
+  {{- if .Jellyfin.ValidSSL}}
+  valid_ssl = true
+  {{- end}}
+{{- else}}#[jellyfin]
+#name    = "" # service check name.
+#url     = "http://localhost:8096/" # Your Jellyfin or Emby URL
+#api_key = "" # your api key; get this from the dashboard
+{{- end }}
+
+######################
+# Overseerr Settings #
+######################
+
+## Works with Overseerr or Jellyseerr. Find the API key in Settings -> General.
+## Set a name to change the service check name. Webhooks go to /overseerr?token=<api key>
+##
+{{if and .Overseerr .Overseerr.Config .Overseerr.URL (not force)}}[overseerr]
+  name     = '''{{.Overseerr.Name}}''' # service check name.
+  url      = '''{{.Overseerr.URL}}''' # Your Overseerr or Jellyseerr URL
+  api_key  = "{{.Overseerr.APIKey}}" # your api key; get this from settings
+  interval = "{{.Overseerr.Interval}}" # Service check duration.
+  timeout  = "{{.Overseerr.Timeout}}" # how long to wait for HTTP responses
+  {{- if .Overseerr.ValidSSL}}
+  valid_ssl = true
+  {{- end}}
+{{- else}}#[overseerr]
+#name    = "" # service check name.
+#url     = "http://localhost:5055/" # Your Overseerr or Jellyseerr URL
+#api_key = "" # your api key; get this from settings
 {{- end }}
 
 #####################
@@ -490,10 +824,15 @@ retries = {{.Retries}}
   parallel = {{.Services.Parallel}}     # How many services to check concurrently. 1 should be enough.
   interval = "{{.Services.Interval}}" # How often to send service states to Notifiarr.com. Minimum = 5m.
   log_file = '{{.Services.LogFile}}'    # Service Check logs go to the app log by default. Change that by setting a services.log file here.
+  ## Free space thresholds for Lidarr, Radarr, Readarr, Sonarr and Whisparr root folders; a percent like "10%" or a size like "50GB".
+  ## Setting either one adds a Disk Space service check for every named starr app. Leave both empty to disable.
+  disk_warning  = "{{.Services.DiskWarning}}"
+  disk_critical = "{{.Services.DiskCritical}}"
 
 ## Uncomment the following section to create a service check on a URL or IP:port.
 ## You may include as many [[service]] sections as you have services to check.
-## Do not add Radarr, Sonarr, Readarr, Prowlarr, or Lidarr here! Add a name to enable their checks.
+## Do not add Radarr, Sonarr, Readarr, Prowlarr, Whisparr or Lidarr here! Add a name to enable their checks.
+## Set health_check = true on those apps to include their health warnings and errors in the check.
 ##
 ## Example with comments follows.
 #[[service]]
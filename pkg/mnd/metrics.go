@@ -69,6 +69,8 @@ func GetKeys(mapName *expvar.Map) map[string]interface{} {
 			output[keyval.Key] = v.Value()
 		case expvar.Func:
 			output[keyval.Key], _ = v.Value().(int64)
+		case *expvar.String:
+			output[keyval.Key] = v.Value()
 		default:
 			output[keyval.Key] = keyval.Value
 		}
@@ -1,20 +1,38 @@
 package services
 
 import (
+	"fmt"
 	"net/url"
 	"strings"
 
+	"github.com/Notifiarr/notifiarr/pkg/apps"
 	"golift.io/cnfg"
 )
 
-// This name is hard coded as the service name for Plex.
+// PlexServerName is the default service name for the first Plex server.
 const PlexServerName = "Plex Server"
 
+// JellyfinServerName is used when the Jellyfin (or Emby) config has no name.
+const JellyfinServerName = "Jellyfin Server"
+
+// OverseerrServerName is used when the Overseerr (or Jellyseerr) config has no name.
+const OverseerrServerName = "Overseerr"
+
 const (
 	starrV3StatusURI = "/api/v3/system/status|X-API-Key:"
 	starrV1StatusURI = "/api/v1/system/status|X-API-Key:"
 )
 
+// starrExpect returns the expect value for a starr app service check.
+// Adding health makes the check fold the app's health messages into the result.
+func starrExpect(health bool) string {
+	if health {
+		return "200," + healthstring
+	}
+
+	return "200"
+}
+
 // collectApps turns app configs into service checks if they have a name.
 func (c *Config) collectApps() []*Service {
 	svcs := []*Service{}
@@ -23,10 +41,15 @@ func (c *Config) collectApps() []*Service {
 	svcs = c.collectRadarrApps(svcs)
 	svcs = c.collectReadarrApps(svcs)
 	svcs = c.collectSonarrApps(svcs)
+	svcs = c.collectWhisparrApps(svcs)
+	svcs = c.collectBazarrApps(svcs)
 	svcs = c.collectDownloadApps(svcs)
 	svcs = c.collectTautulliApp(svcs)
-	svcs = c.collectPlexApp(svcs)
+	svcs = c.collectPlexApps(svcs)
+	svcs = c.collectJellyfinApp(svcs)
+	svcs = c.collectOverseerrApp(svcs)
 	svcs = c.collectMySQLApps(svcs)
+	svcs = c.collectDiskApps(svcs)
 
 	return svcs
 }
@@ -47,7 +70,7 @@ func (c *Config) collectLidarrApps(svcs []*Service) []*Service {
 				Name:     app.Name,
 				Type:     CheckHTTP,
 				Value:    app.URL + starrV1StatusURI + app.APIKey,
-				Expect:   "200",
+				Expect:   starrExpect(app.Health),
 				Timeout:  cnfg.Duration{Duration: app.Timeout.Duration},
 				Interval: interval,
 				validSSL: app.ValidSSL,
@@ -74,7 +97,7 @@ func (c *Config) collectProwlarrApps(svcs []*Service) []*Service {
 				Name:     app.Name,
 				Type:     CheckHTTP,
 				Value:    app.URL + starrV1StatusURI + app.APIKey,
-				Expect:   "200",
+				Expect:   starrExpect(app.Health),
 				Timeout:  cnfg.Duration{Duration: app.Timeout.Duration},
 				Interval: interval,
 				validSSL: app.ValidSSL,
@@ -101,7 +124,7 @@ func (c *Config) collectRadarrApps(svcs []*Service) []*Service {
 				Name:     app.Name,
 				Type:     CheckHTTP,
 				Value:    app.URL + starrV3StatusURI + app.APIKey,
-				Expect:   "200",
+				Expect:   starrExpect(app.Health),
 				Timeout:  cnfg.Duration{Duration: app.Timeout.Duration},
 				Interval: interval,
 				validSSL: app.ValidSSL,
@@ -128,7 +151,7 @@ func (c *Config) collectReadarrApps(svcs []*Service) []*Service {
 				Name:     app.Name,
 				Type:     CheckHTTP,
 				Value:    app.URL + starrV1StatusURI + app.APIKey,
-				Expect:   "200",
+				Expect:   starrExpect(app.Health),
 				Timeout:  cnfg.Duration{Duration: app.Timeout.Duration},
 				Interval: interval,
 				validSSL: app.ValidSSL,
@@ -155,7 +178,7 @@ func (c *Config) collectSonarrApps(svcs []*Service) []*Service {
 				Name:     app.Name,
 				Type:     CheckHTTP,
 				Value:    app.URL + starrV3StatusURI + app.APIKey,
-				Expect:   "200",
+				Expect:   starrExpect(app.Health),
 				Timeout:  cnfg.Duration{Duration: app.Timeout.Duration},
 				Interval: interval,
 				validSSL: app.ValidSSL,
@@ -166,6 +189,58 @@ func (c *Config) collectSonarrApps(svcs []*Service) []*Service {
 	return svcs
 }
 
+func (c *Config) collectWhisparrApps(svcs []*Service) []*Service {
+	for _, app := range c.Apps.Whisparr {
+		if !app.Enabled() || app.Name == "" || app.Interval.Duration < 0 {
+			continue
+		}
+
+		interval := app.Interval
+		if interval.Duration == 0 {
+			interval.Duration = DefaultCheckInterval
+		}
+
+		if app.Name != "" {
+			svcs = append(svcs, &Service{
+				Name:     app.Name,
+				Type:     CheckHTTP,
+				Value:    app.URL + starrV3StatusURI + app.APIKey,
+				Expect:   starrExpect(app.Health),
+				Timeout:  cnfg.Duration{Duration: app.Timeout.Duration},
+				Interval: interval,
+				validSSL: app.ValidSSL,
+			})
+		}
+	}
+
+	return svcs
+}
+
+func (c *Config) collectBazarrApps(svcs []*Service) []*Service {
+	for _, app := range c.Apps.Bazarr {
+		if !app.Enabled() || app.Name == "" || app.Interval.Duration < 0 {
+			continue
+		}
+
+		interval := app.Interval
+		if interval.Duration == 0 {
+			interval.Duration = DefaultCheckInterval
+		}
+
+		svcs = append(svcs, &Service{
+			Name:     app.Name,
+			Type:     CheckHTTP,
+			Value:    app.URL + "/api/system/status|X-API-KEY:" + app.APIKey,
+			Expect:   "200",
+			Timeout:  cnfg.Duration{Duration: app.Timeout.Duration},
+			Interval: interval,
+			validSSL: app.ValidSSL,
+		})
+	}
+
+	return svcs
+}
+
 //nolint:funlen,cyclop,gocognit,gocyclo // split this one up.
 func (c *Config) collectDownloadApps(svcs []*Service) []*Service {
 	// Deluge instanceapp.
@@ -354,8 +429,75 @@ func (c *Config) collectTautulliApp(svcs []*Service) []*Service {
 	return svcs
 }
 
-func (c *Config) collectPlexApp(svcs []*Service) []*Service {
-	app := c.Apps.Plex
+func (c *Config) collectPlexApps(svcs []*Service) []*Service {
+	for idx, app := range c.Apps.Plex {
+		if !app.Enabled() || app.Interval.Duration < 0 {
+			continue
+		}
+
+		interval := app.Interval
+		if interval.Duration == 0 {
+			interval.Duration = DefaultCheckInterval
+		}
+
+		svcs = append(svcs, &Service{
+			Name:     plexServiceName(idx, app),
+			Type:     CheckHTTP,
+			Value:    app.URL + "|X-Plex-Token:" + app.Token,
+			Expect:   "200",
+			Timeout:  app.Timeout,
+			Interval: interval,
+			validSSL: app.ValidSSL,
+		})
+	}
+
+	return svcs
+}
+
+// plexServiceName returns the configured name, or a default name for a Plex service check.
+// The first server keeps the original default name, so existing service states carry over.
+func plexServiceName(idx int, app *apps.PlexConfig) string {
+	switch {
+	case app.ExtraConfig.Name != "":
+		return app.ExtraConfig.Name
+	case idx == 0:
+		return PlexServerName
+	default:
+		return fmt.Sprintf("%s %d", PlexServerName, idx+1)
+	}
+}
+
+func (c *Config) collectJellyfinApp(svcs []*Service) []*Service {
+	app := c.Apps.Jellyfin
+	if !app.Enabled() || app.Interval.Duration < 0 {
+		return svcs
+	}
+
+	interval := app.Interval
+	if interval.Duration == 0 {
+		interval.Duration = DefaultCheckInterval
+	}
+
+	name := app.ExtraConfig.Name
+	if name == "" {
+		name = JellyfinServerName
+	}
+
+	svcs = append(svcs, &Service{
+		Name:     name,
+		Type:     CheckHTTP,
+		Value:    app.URL + "/System/Info|X-Emby-Token:" + app.APIKey,
+		Expect:   "200",
+		Timeout:  app.Timeout,
+		Interval: interval,
+		validSSL: app.ValidSSL,
+	})
+
+	return svcs
+}
+
+func (c *Config) collectOverseerrApp(svcs []*Service) []*Service {
+	app := c.Apps.Overseerr
 	if !app.Enabled() || app.Interval.Duration < 0 {
 		return svcs
 	}
@@ -365,10 +507,15 @@ func (c *Config) collectPlexApp(svcs []*Service) []*Service {
 		interval.Duration = DefaultCheckInterval
 	}
 
+	name := app.Name
+	if name == "" {
+		name = OverseerrServerName
+	}
+
 	svcs = append(svcs, &Service{
-		Name:     PlexServerName,
+		Name:     name,
 		Type:     CheckHTTP,
-		Value:    app.URL + "|X-Plex-Token:" + app.Token,
+		Value:    app.URL + "/api/v1/status|X-Api-Key:" + app.APIKey,
 		Expect:   "200",
 		Timeout:  app.Timeout,
 		Interval: interval,
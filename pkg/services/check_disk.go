@@ -0,0 +1,290 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"golift.io/cnfg"
+	"golift.io/starr"
+)
+
+// Errors returned by the disk checks.
+var (
+	ErrBadDiskThreshold = errors.New("disk thresholds must be a percent like 10% or a size like 50GB")
+	ErrNoDisk           = errors.New("disk checks are created automatically from starr apps")
+)
+
+// diskThreshold is a free space limit, either a percent of the disk or a number of bytes.
+type diskThreshold struct {
+	percent float64
+	bytes   int64
+}
+
+// diskExpect ties a service check to a starr app's root folders.
+type diskExpect struct {
+	api  starr.APIer
+	ver  string // starr api version.
+	warn *diskThreshold
+	crit *diskThreshold
+}
+
+// diskFolder is a root folder or disk from a starr app. Not every app returns every field.
+type diskFolder struct {
+	Path       string `json:"path"`
+	Accessible *bool  `json:"accessible,omitempty"`
+	FreeSpace  int64  `json:"freeSpace"`
+	TotalSpace int64  `json:"totalSpace"`
+}
+
+// parseDiskThreshold turns a string like "10%", "500MB" or "1.5TiB" into a threshold.
+// An empty string returns nil, which never matches.
+func parseDiskThreshold(original string) (*diskThreshold, error) {
+	input := strings.ToUpper(strings.TrimSpace(original))
+	if input == "" {
+		return nil, nil //nolint:nilnil // no threshold is not an error.
+	}
+
+	if strings.HasSuffix(input, "%") {
+		percent, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(input, "%")), mnd.Bits64)
+		if err != nil || percent <= 0 || percent >= 100 { //nolint:mnd
+			return nil, fmt.Errorf("%w: %s", ErrBadDiskThreshold, original)
+		}
+
+		return &diskThreshold{percent: percent}, nil
+	}
+
+	multiplier := float64(1)
+	input = strings.TrimSuffix(strings.TrimSuffix(input, "B"), "I")
+
+	for idx, unit := range []string{"K", "M", "G", "T"} {
+		if strings.HasSuffix(input, unit) {
+			multiplier = float64(int64(1) << (10 * (idx + 1))) //nolint:mnd
+			input = strings.TrimSuffix(input, unit)
+
+			break
+		}
+	}
+
+	size, err := strconv.ParseFloat(strings.TrimSpace(input), mnd.Bits64)
+	if err != nil || size <= 0 {
+		return nil, fmt.Errorf("%w: %s", ErrBadDiskThreshold, original)
+	}
+
+	return &diskThreshold{bytes: int64(size * multiplier)}, nil
+}
+
+// isPercent returns true if the threshold is a percent of the disk size.
+func (t *diskThreshold) isPercent() bool {
+	return t != nil && t.percent > 0
+}
+
+// crossed returns true if the free space is below the threshold.
+func (t *diskThreshold) crossed(free, total int64) bool {
+	switch {
+	case t == nil:
+		return false
+	case t.percent > 0:
+		return total > 0 && float64(free)/float64(total)*100 < t.percent //nolint:mnd
+	default:
+		return free < t.bytes
+	}
+}
+
+// setupDiskThresholds parses the disk thresholds from the config file.
+func (c *Config) setupDiskThresholds() (err error) {
+	if c.diskWarn, err = parseDiskThreshold(c.DiskWarning); err != nil {
+		return fmt.Errorf("disk_warning: %w", err)
+	}
+
+	if c.diskCrit, err = parseDiskThreshold(c.DiskCritical); err != nil {
+		return fmt.Errorf("disk_critical: %w", err)
+	}
+
+	return nil
+}
+
+// collectDiskApps creates a root folder free space check for each starr app with a name.
+// These are only created when a disk threshold is configured.
+func (c *Config) collectDiskApps(svcs []*Service) []*Service {
+	if c.diskWarn == nil && c.diskCrit == nil {
+		return svcs
+	}
+
+	add := func(name, url string, interval, timeout cnfg.Duration, api starr.APIer, ver string) {
+		if name == "" || interval.Duration < 0 {
+			return
+		}
+
+		if interval.Duration == 0 {
+			interval.Duration = DefaultCheckInterval
+		}
+
+		svcs = append(svcs, &Service{
+			Name:     name + " Disk Space",
+			Type:     CheckDISK,
+			Value:    url,
+			Timeout:  timeout,
+			Interval: interval,
+			svc:      service{disk: &diskExpect{api: api, ver: ver, warn: c.diskWarn, crit: c.diskCrit}},
+		})
+	}
+
+	for _, app := range c.Apps.Lidarr {
+		if app.Enabled() {
+			add(app.Name, app.URL, app.Interval, app.Timeout, app.Config, "v1")
+		}
+	}
+
+	for _, app := range c.Apps.Radarr {
+		if app.Enabled() {
+			add(app.Name, app.URL, app.Interval, app.Timeout, app.Config, "v3")
+		}
+	}
+
+	for _, app := range c.Apps.Readarr {
+		if app.Enabled() {
+			add(app.Name, app.URL, app.Interval, app.Timeout, app.Config, "v1")
+		}
+	}
+
+	for _, app := range c.Apps.Sonarr {
+		if app.Enabled() {
+			add(app.Name, app.URL, app.Interval, app.Timeout, app.Config, "v3")
+		}
+	}
+
+	for _, app := range c.Apps.Whisparr {
+		if app.Enabled() {
+			add(app.Name, app.URL, app.Interval, app.Timeout, app.Config, "v3")
+		}
+	}
+
+	return svcs
+}
+
+// checkDisk checks the free space in every root folder of a starr app.
+// Root folders that do not report a total size are matched to the disk they live on.
+func (s *Service) checkDisk(ctx context.Context) *result {
+	ctx, cancel := context.WithTimeout(ctx, s.Timeout.Duration)
+	defer cancel()
+
+	expect := s.svc.disk
+
+	var folders, disks []*diskFolder
+
+	req := starr.Request{URI: path.Join(expect.ver, "rootfolder")}
+	if err := expect.api.GetInto(ctx, req, &folders); err != nil {
+		return &result{state: StateUnknown, output: &Output{str: "getting root folders: " + err.Error()}}
+	}
+
+	req = starr.Request{URI: path.Join(expect.ver, "diskspace")}
+	if err := expect.api.GetInto(ctx, req, &disks); err != nil {
+		return &result{state: StateUnknown, output: &Output{str: "getting disk space: " + err.Error()}}
+	}
+
+	if len(folders) == 0 {
+		return &result{state: StateOK, output: &Output{str: "no root folders configured"}}
+	}
+
+	state, problems := expect.checkFolders(folders, disks)
+	res := &result{state: state}
+
+	if len(problems) == 0 {
+		res.output = &Output{str: fmt.Sprintf("%d root folders have enough free space", len(folders))}
+		return res
+	}
+
+	res.output = truncOutput(strings.Join(problems, "; "))
+
+	return res
+}
+
+// checkFolders checks every root folder, and returns the most severe state with a message for each problem.
+func (d *diskExpect) checkFolders(folders, disks []*diskFolder) (CheckState, []string) {
+	worst := StateOK
+	problems := []string{}
+
+	for _, folder := range folders {
+		state, msg := d.checkFolder(folder, disks)
+		if state == StateOK {
+			continue
+		}
+
+		if severity(state) > severity(worst) {
+			worst = state
+		}
+
+		problems = append(problems, msg)
+	}
+
+	return worst, problems
+}
+
+// severity ranks the check states: Critical is worse than Warning, and Warning is worse than Unknown.
+func severity(state CheckState) int {
+	switch state {
+	case StateCritical:
+		return 3 //nolint:mnd
+	case StateWarning:
+		return 2 //nolint:mnd
+	case StateUnknown:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (d *diskExpect) checkFolder(folder *diskFolder, disks []*diskFolder) (CheckState, string) {
+	if folder.Accessible != nil && !*folder.Accessible {
+		return StateCritical, folder.Path + ": not accessible"
+	}
+
+	if folder.TotalSpace == 0 {
+		// Find the disk with the longest path that contains this folder.
+		var match *diskFolder
+
+		for _, disk := range disks {
+			if onDisk(disk.Path, folder.Path) && (match == nil || len(disk.Path) > len(match.Path)) {
+				match = disk
+			}
+		}
+
+		if match != nil {
+			folder.TotalSpace = match.TotalSpace
+		}
+	}
+
+	msg := folder.Path + ": " + mnd.FormatBytes(folder.FreeSpace) + " free"
+	if folder.TotalSpace > 0 {
+		msg += fmt.Sprintf(" (%.1f%%)", float64(folder.FreeSpace)/float64(folder.TotalSpace)*100) //nolint:mnd
+	}
+
+	switch {
+	case d.crit.crossed(folder.FreeSpace, folder.TotalSpace):
+		return StateCritical, msg
+	case folder.TotalSpace <= 0 && (d.crit.isPercent() || d.warn.isPercent()):
+		// A percent threshold cannot be checked without a size, and that must not look healthy.
+		return StateUnknown, msg + ", total size unknown"
+	case d.warn.crossed(folder.FreeSpace, folder.TotalSpace):
+		return StateWarning, msg
+	default:
+		return StateOK, msg
+	}
+}
+
+// onDisk returns true if a folder path is the disk path or inside it.
+// Paths are compared on separator boundaries, so /data2 is not on /data.
+func onDisk(disk, folder string) bool {
+	if disk == folder {
+		return true
+	}
+
+	disk = strings.TrimRight(disk, `/\`)
+
+	return strings.HasPrefix(folder, disk+"/") || strings.HasPrefix(folder, disk+`\`)
+}
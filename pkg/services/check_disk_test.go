@@ -0,0 +1,161 @@
+package services //nolint:testpackage // the disk check is not exported.
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDiskThreshold(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input   string
+		want    *diskThreshold
+		wantErr bool
+	}{
+		{input: "", want: nil},
+		{input: "  ", want: nil},
+		{input: "10%", want: &diskThreshold{percent: 10}},
+		{input: " 2.5 % ", want: &diskThreshold{percent: 2.5}},
+		{input: "0%", wantErr: true},
+		{input: "100%", wantErr: true},
+		{input: "abc%", wantErr: true},
+		{input: "500", want: &diskThreshold{bytes: 500}},
+		{input: "500B", want: &diskThreshold{bytes: 500}},
+		{input: "1k", want: &diskThreshold{bytes: 1024}},
+		{input: "50GB", want: &diskThreshold{bytes: 50 << 30}},
+		{input: "1.5TiB", want: &diskThreshold{bytes: 3 << 39}},
+		{input: "500 MB", want: &diskThreshold{bytes: 500 << 20}},
+		{input: "0GB", wantErr: true},
+		{input: "-1GB", wantErr: true},
+		{input: "lots", wantErr: true},
+	}
+
+	for _, test := range tests {
+		got, err := parseDiskThreshold(test.input)
+		if test.wantErr {
+			require.ErrorIs(t, err, ErrBadDiskThreshold, "input: %q", test.input)
+			continue
+		}
+
+		require.NoError(t, err, "input: %q", test.input)
+		assert.Equal(t, test.want, got, "input: %q", test.input)
+	}
+}
+
+func TestDiskThresholdCrossed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		threshold *diskThreshold
+		free      int64
+		total     int64
+		want      bool
+	}{
+		{name: "nil threshold", threshold: nil, free: 0, total: 100, want: false},
+		{name: "percent below", threshold: &diskThreshold{percent: 10}, free: 5, total: 100, want: true},
+		{name: "percent equal", threshold: &diskThreshold{percent: 10}, free: 10, total: 100, want: false},
+		{name: "percent above", threshold: &diskThreshold{percent: 10}, free: 50, total: 100, want: false},
+		{name: "percent no total", threshold: &diskThreshold{percent: 10}, free: 0, total: 0, want: false},
+		{name: "bytes below", threshold: &diskThreshold{bytes: 1000}, free: 999, total: 0, want: true},
+		{name: "bytes equal", threshold: &diskThreshold{bytes: 1000}, free: 1000, total: 5000, want: false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, test.threshold.crossed(test.free, test.total), test.name)
+	}
+}
+
+func TestOnDisk(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		disk   string
+		folder string
+		want   bool
+	}{
+		{disk: "/data", folder: "/data", want: true},
+		{disk: "/data", folder: "/data/tv", want: true},
+		{disk: "/data/", folder: "/data/tv", want: true},
+		{disk: "/data", folder: "/data2/tv", want: false},
+		{disk: "/data", folder: "/dat", want: false},
+		{disk: "/", folder: "/anything", want: true},
+		{disk: `C:\`, folder: `C:\Media\TV`, want: true},
+		{disk: `D:\Media`, folder: `D:\Media2`, want: false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, onDisk(test.disk, test.folder), "disk: %s, folder: %s", test.disk, test.folder)
+	}
+}
+
+func TestCheckFolder(t *testing.T) {
+	t.Parallel()
+
+	disks := []*diskFolder{
+		{Path: "/", FreeSpace: 900, TotalSpace: 1000},
+		{Path: "/data", FreeSpace: 50, TotalSpace: 1000},
+		{Path: "/data2", FreeSpace: 900, TotalSpace: 1000},
+	}
+	inaccessible := false
+	expect := &diskExpect{warn: &diskThreshold{percent: 20}, crit: &diskThreshold{percent: 10}}
+
+	tests := []struct {
+		name   string
+		folder *diskFolder
+		want   CheckState
+	}{
+		{name: "longest disk wins", folder: &diskFolder{Path: "/data/tv", FreeSpace: 50}, want: StateCritical},
+		{name: "no prefix match", folder: &diskFolder{Path: "/data2/tv", FreeSpace: 150}, want: StateWarning},
+		{name: "own size", folder: &diskFolder{Path: "/data/tv", FreeSpace: 500, TotalSpace: 1000}, want: StateOK},
+		{name: "no size", folder: &diskFolder{Path: `X:\tv`, FreeSpace: 500}, want: StateUnknown},
+		{name: "inaccessible", folder: &diskFolder{Path: "/data", Accessible: &inaccessible}, want: StateCritical},
+	}
+
+	for _, test := range tests {
+		state, _ := expect.checkFolder(test.folder, disks)
+		assert.Equal(t, test.want, state, test.name)
+	}
+
+	// A byte threshold works without a size.
+	expect = &diskExpect{crit: &diskThreshold{bytes: 100}}
+	state, _ := expect.checkFolder(&diskFolder{Path: `X:\tv`, FreeSpace: 50}, nil)
+	assert.Equal(t, StateCritical, state)
+
+	state, _ = expect.checkFolder(&diskFolder{Path: `X:\tv`, FreeSpace: 500}, nil)
+	assert.Equal(t, StateOK, state)
+}
+
+func TestCheckFolders(t *testing.T) {
+	t.Parallel()
+
+	expect := &diskExpect{warn: &diskThreshold{percent: 20}, crit: &diskThreshold{percent: 10}}
+	unknown := &diskFolder{Path: `X:\unknown`, FreeSpace: 500}
+	warning := &diskFolder{Path: "/warning", FreeSpace: 150, TotalSpace: 1000}
+	critical := &diskFolder{Path: "/critical", FreeSpace: 50, TotalSpace: 1000}
+	healthy := &diskFolder{Path: "/ok", FreeSpace: 900, TotalSpace: 1000}
+
+	tests := []struct {
+		name     string
+		folders  []*diskFolder
+		want     CheckState
+		problems int
+	}{
+		{name: "healthy", folders: []*diskFolder{healthy}, want: StateOK, problems: 0},
+		{name: "unknown", folders: []*diskFolder{healthy, unknown}, want: StateUnknown, problems: 1},
+		{name: "unknown then warning", folders: []*diskFolder{unknown, warning}, want: StateWarning, problems: 2},
+		{name: "warning then unknown", folders: []*diskFolder{warning, unknown}, want: StateWarning, problems: 2},
+		{name: "unknown then critical", folders: []*diskFolder{unknown, critical}, want: StateCritical, problems: 2},
+		{name: "critical then warning", folders: []*diskFolder{critical, warning}, want: StateCritical, problems: 2},
+		{name: "all", folders: []*diskFolder{unknown, warning, healthy, critical}, want: StateCritical, problems: 3},
+	}
+
+	for _, test := range tests {
+		state, problems := expect.checkFolders(test.folders, nil)
+		assert.Equal(t, test.want, state, test.name)
+		assert.Len(t, problems, test.problems, test.name)
+	}
+}
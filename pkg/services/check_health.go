@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// healthstring is an expect value that folds starr /health messages into an http check.
+const healthstring = "health"
+
+// starrHealth is one message from a starr app's /health endpoint.
+type starrHealth struct {
+	Source  string `json:"source"`
+	Type    string `json:"type"` // ok, notice, warning, error
+	Message string `json:"message"`
+}
+
+// checkStarrHealth queries the /health endpoint next to a starr app's /system/status endpoint.
+// Warnings make the check a warning, errors make it critical. The messages become the output.
+func (s *Service) checkStarrHealth(ctx context.Context, client *http.Client, req *http.Request, res *result) *result {
+	if !strings.HasSuffix(req.URL.Path, "/system/status") {
+		res.output.str += ", health not checked: not a starr status URL"
+		return res
+	}
+
+	req = req.Clone(ctx)
+	req.URL.Path = strings.TrimSuffix(req.URL.Path, "/system/status") + "/health"
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return &result{state: StateUnknown, output: &Output{str: "checking health: " + RemoveSecrets(s.Value, err.Error())}}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return &result{state: StateUnknown, output: &Output{str: "checking health: " + resp.Status}}
+	}
+
+	var health []*starrHealth
+	if err := json.NewDecoder(resp.Body).Decode(&health); err != nil {
+		return &result{state: StateUnknown, output: &Output{str: "decoding health: " + err.Error()}}
+	}
+
+	messages := []string{}
+
+	for _, msg := range health {
+		switch strings.ToLower(msg.Type) {
+		case "error":
+			res.state = StateCritical
+		case "warning":
+			if res.state == StateOK {
+				res.state = StateWarning
+			}
+		default:
+			continue
+		}
+
+		messages = append(messages, msg.Type+": "+msg.Message)
+	}
+
+	if len(messages) == 0 {
+		return res
+	}
+
+	return &result{state: res.state, output: truncOutput(strings.Join(messages, "; "))}
+}
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"golift.io/cnfg"
+	"golift.io/starr"
+	"golift.io/starr/prowlarr"
+)
+
+// DefaultIndexerCritical is how long a Prowlarr indexer may fail before its check goes critical.
+const DefaultIndexerCritical = 3 * time.Hour
+
+// ErrNoIndexer is returned when an indexer check is not tied to a Prowlarr instance.
+// Indexer checks are created automatically and cannot be added to the config file.
+var ErrNoIndexer = errors.New("indexer checks are created automatically from Prowlarr instances")
+
+// indexerExpect ties a service check to a Prowlarr indexer.
+type indexerExpect struct {
+	app      *apps.ProwlarrConfig
+	id       int64
+	critical time.Duration
+	// The indexer is only tested again when Prowlarr records a new failure.
+	// This keeps us from sending test searches to a tracker that is in backoff.
+	mu      sync.Mutex
+	failure time.Time
+	reason  string
+}
+
+// collectProwlarrIndexers creates a service check for every indexer in every Prowlarr instance with a name.
+// This runs on startup (not in Setup) because it needs a working Prowlarr client.
+// It makes network requests, so do not call this while holding the stop lock.
+func (c *Config) collectProwlarrIndexers(ctx context.Context) []*Service {
+	svcs := []*Service{}
+
+	for _, app := range c.Apps.Prowlarr {
+		if !app.Enabled() || app.Name == "" || app.Interval.Duration < 0 {
+			continue
+		}
+
+		interval := app.Interval
+		if interval.Duration == 0 {
+			interval.Duration = DefaultCheckInterval
+		}
+
+		critical := app.IndexerCritical.Duration
+		if critical == 0 {
+			critical = DefaultIndexerCritical
+		}
+
+		timeout := app.Timeout.Duration
+		if timeout <= 0 {
+			timeout = DefaultTimeout
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		indexers, err := app.GetIndexersContext(ctx)
+		cancel()
+
+		if err != nil {
+			c.Errorf("Getting Prowlarr indexers for service checks from %s: %v", app.Name, err)
+			continue
+		}
+
+		for _, indexer := range indexers {
+			svcs = append(svcs, &Service{
+				Name:     app.Name + " Indexer: " + indexer.Name,
+				Type:     CheckINDEXER,
+				Value:    strconv.FormatInt(indexer.ID, 10), //nolint:mnd
+				Timeout:  cnfg.Duration{Duration: app.Timeout.Duration},
+				Interval: interval,
+				Tags:     map[string]any{"prowlarr": app.Name, "indexerId": indexer.ID},
+				svc: service{
+					indexer: &indexerExpect{app: app, id: indexer.ID, critical: critical},
+				},
+			})
+		}
+	}
+
+	return svcs
+}
+
+func (s *Service) checkIndexer(ctx context.Context) *result {
+	ctx, cancel := context.WithTimeout(ctx, s.Timeout.Duration)
+	defer cancel()
+
+	expect := s.svc.indexer
+
+	indexer, err := expect.app.GetIndexerContext(ctx, expect.id)
+	if err != nil {
+		return &result{state: StateUnknown, output: &Output{str: "getting indexer: " + err.Error()}}
+	}
+
+	if !indexer.Enable {
+		return &result{state: StateOK, output: &Output{str: "indexer disabled in Prowlarr"}}
+	}
+
+	statuses, err := expect.app.GetIndexerStatusContext(ctx)
+	if err != nil {
+		return &result{state: StateUnknown, output: &Output{str: "getting indexer status: " + err.Error()}}
+	}
+
+	var status *apps.ProwlarrIndexerStatus
+
+	for _, stat := range statuses {
+		if stat.IndexerID == expect.id {
+			status = stat
+			break
+		}
+	}
+
+	// Prowlarr clears the initial failure when the indexer works again.
+	if status == nil || status.InitialFailure.IsZero() {
+		return &result{state: StateOK, output: &Output{str: "indexer working"}}
+	}
+
+	failing := time.Since(status.InitialFailure).Round(time.Second)
+	reason := expect.failReason(ctx, indexer, status)
+
+	switch {
+	case failing > expect.critical:
+		return &result{state: StateCritical, output: truncOutput(fmt.Sprintf("failing for %v: %s", failing, reason))}
+	case status.DisabledTill.After(time.Now()):
+		return &result{state: StateWarning, output: truncOutput(fmt.Sprintf("disabled by backoff until %s, failing for %v: %s",
+			status.DisabledTill.Local().Format(time.Kitchen), failing, reason))}
+	default:
+		return &result{state: StateWarning, output: truncOutput(fmt.Sprintf("failing for %v: %s", failing, reason))}
+	}
+}
+
+// failReason returns why an indexer fails. The reason is saved until Prowlarr records another failure.
+func (i *indexerExpect) failReason(
+	ctx context.Context,
+	indexer *prowlarr.IndexerOutput,
+	status *apps.ProwlarrIndexerStatus,
+) string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	failure := status.MostRecentFailure
+	if failure.IsZero() {
+		failure = status.InitialFailure
+	}
+
+	if i.reason == "" || !i.failure.Equal(failure) {
+		i.failure = failure
+		i.reason = indexerFailReason(ctx, i.app, indexer)
+	}
+
+	return i.reason
+}
+
+// indexerFailReason tests a failing indexer to find out why it fails. Prowlarr does not store the reason.
+func indexerFailReason(ctx context.Context, app *apps.ProwlarrConfig, indexer *prowlarr.IndexerOutput) string {
+	err := app.TestIndexerContext(ctx, apps.ProwlarrIndexerInput(indexer))
+	if err == nil {
+		return "last test passed"
+	}
+
+	var (
+		reqErr   *starr.ReqError
+		failures []*apps.ProwlarrValidationFailure
+	)
+
+	if !errors.As(err, &reqErr) || json.Unmarshal(reqErr.Body, &failures) != nil || len(failures) == 0 {
+		return err.Error()
+	}
+
+	reasons := make([]string, len(failures))
+	for idx, failure := range failures {
+		reasons[idx] = failure.ErrorMessage
+	}
+
+	return strings.Join(reasons, "; ")
+}
+
+// truncOutput makes sure a check's output does not exceed the maximum length.
+func truncOutput(str string) *Output {
+	if len(str) > maxOutput {
+		str = str[:maxOutput]
+	}
+
+	return &Output{str: str}
+}
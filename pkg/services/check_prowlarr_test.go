@@ -0,0 +1,63 @@
+package services //nolint:testpackage // the indexer check is not exported.
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"github.com/stretchr/testify/assert"
+	"golift.io/starr"
+	"golift.io/starr/prowlarr"
+)
+
+func TestIndexerFailReason(t *testing.T) {
+	t.Parallel()
+
+	var tests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, _ *http.Request) {
+		tests.Add(1)
+		resp.WriteHeader(http.StatusBadRequest)
+		_, _ = resp.Write([]byte(`[{"errorMessage":"site down"},{"errorMessage":"bad cookie"}]`))
+	}))
+	defer server.Close()
+
+	config := starr.New("apikey", server.URL, time.Second)
+	expect := &indexerExpect{app: &apps.ProwlarrConfig{Config: config, Prowlarr: prowlarr.New(config)}, id: 1}
+	indexer := &prowlarr.IndexerOutput{ID: 1, Name: "tracker"}
+	status := &apps.ProwlarrIndexerStatus{IndexerID: 1, InitialFailure: time.Now().Add(-time.Hour)}
+
+	assert.Equal(t, "site down; bad cookie", expect.failReason(context.Background(), indexer, status))
+	assert.Equal(t, "site down; bad cookie", expect.failReason(context.Background(), indexer, status))
+	assert.EqualValues(t, 1, tests.Load(), "the indexer must not be tested again without a new failure")
+
+	status.MostRecentFailure = time.Now()
+	expect.failReason(context.Background(), indexer, status)
+	assert.EqualValues(t, 2, tests.Load(), "a new failure must test the indexer again")
+}
+
+func TestIndexerFailReasonError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, _ *http.Request) {
+		resp.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	config := starr.New("apikey", server.URL, time.Second)
+	app := &apps.ProwlarrConfig{Config: config, Prowlarr: prowlarr.New(config)}
+	reason := indexerFailReason(context.Background(), app, &prowlarr.IndexerOutput{ID: 1})
+	assert.Contains(t, reason, "502", "errors without validation failures are returned as-is")
+}
+
+func TestTruncOutput(t *testing.T) {
+	t.Parallel()
+
+	long := make([]byte, maxOutput+10)
+	assert.Len(t, truncOutput(string(long)).str, maxOutput)
+	assert.Equal(t, "short", truncOutput("short").str)
+}
@@ -56,6 +56,10 @@ func (s *Service) Validate() error { //nolint:cyclop
 			if strings.EqualFold(code, sslstring) {
 				s.validSSL = true
 			}
+
+			if strings.EqualFold(code, healthstring) {
+				s.health = true
+			}
 		}
 	case CheckTCP:
 		if !strings.Contains(s.Value, ":") {
@@ -69,6 +73,14 @@ func (s *Service) Validate() error { //nolint:cyclop
 		if err := s.checkPingValues(s.Type == CheckICMP); err != nil {
 			return err
 		}
+	case CheckINDEXER:
+		if s.svc.indexer == nil {
+			return fmt.Errorf("%s: %w", s.Name, ErrNoIndexer)
+		}
+	case CheckDISK:
+		if s.svc.disk == nil {
+			return fmt.Errorf("%s: %w", s.Name, ErrNoDisk)
+		}
 	default:
 		return ErrInvalidType
 	}
@@ -110,6 +122,10 @@ func (s *Service) checkNow(ctx context.Context) *result {
 		return s.checkPING()
 	case CheckPROC:
 		return s.checkProccess(ctx)
+	case CheckINDEXER:
+		return s.checkIndexer(ctx)
+	case CheckDISK:
+		return s.checkDisk(ctx)
 	default:
 		return nil
 	}
@@ -218,6 +234,10 @@ func (s *Service) checkHTTP(ctx context.Context) *result {
 			res.state = StateOK
 			res.output = &Output{str: resp.Status}
 
+			if s.health {
+				return s.checkStarrHealth(ctx, client, req, res)
+			}
+
 			return res
 		}
 	}
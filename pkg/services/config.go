@@ -38,21 +38,25 @@ var (
 
 // Config for this Services plugin comes from a config file.
 type Config struct {
-	Interval    cnfg.Duration     `json:"interval" toml:"interval" xml:"interval"`
-	Parallel    uint              `json:"parallel" toml:"parallel" xml:"parallel"`
-	Disabled    bool              `json:"disabled" toml:"disabled" xml:"disabled"`
-	LogFile     string            `json:"logFile"  toml:"log_file" xml:"log_file"`
-	Apps        *apps.Apps        `json:"-"        toml:"-"`
-	website     *website.Server   `json:"-"        toml:"-"`
-	Plugins     *snapshot.Plugins `json:"-"        toml:"-"` // pass this in so we can service-check mysql
-	mnd.Logger  `json:"-"`        // log file writer
-	services    map[string]*Service
-	checks      chan *Service
-	done        chan bool
-	stopChan    chan struct{}
-	triggerChan chan website.EventType
-	checkChan   chan triggerCheck
-	stopLock    sync.Mutex
+	Interval     cnfg.Duration     `json:"interval"     toml:"interval"      xml:"interval"`
+	Parallel     uint              `json:"parallel"     toml:"parallel"      xml:"parallel"`
+	Disabled     bool              `json:"disabled"     toml:"disabled"      xml:"disabled"`
+	LogFile      string            `json:"logFile"      toml:"log_file"      xml:"log_file"`
+	DiskWarning  string            `json:"diskWarning"  toml:"disk_warning"  xml:"disk_warning"`  // starr root folders.
+	DiskCritical string            `json:"diskCritical" toml:"disk_critical" xml:"disk_critical"` // starr root folders.
+	Apps         *apps.Apps        `json:"-"            toml:"-"`
+	website      *website.Server   `json:"-"            toml:"-"`
+	Plugins      *snapshot.Plugins `json:"-"            toml:"-"` // pass this in so we can service-check mysql
+	mnd.Logger   `json:"-"`        // log file writer
+	diskWarn     *diskThreshold
+	diskCrit     *diskThreshold
+	services     map[string]*Service
+	checks       chan *Service
+	done         chan bool
+	stopChan     chan struct{}
+	triggerChan  chan website.EventType
+	checkChan    chan triggerCheck
+	stopLock     sync.Mutex
 }
 
 // CheckType locks us into a few specific types of checks.
@@ -65,6 +69,10 @@ const (
 	CheckPING CheckType = "ping"
 	CheckICMP CheckType = "icmp"
 	CheckPROC CheckType = "process"
+	// CheckINDEXER checks are created automatically for each Prowlarr indexer.
+	CheckINDEXER CheckType = "indexer"
+	// CheckDISK checks are created automatically for starr app root folders when disk thresholds are set.
+	CheckDISK CheckType = "disk"
 )
 
 // CheckState represents the current state of a service check.
@@ -111,6 +119,7 @@ type Service struct {
 	Interval cnfg.Duration  `json:"interval" toml:"interval" xml:"interval"` // 1m
 	Tags     map[string]any `json:"tags"     toml:"tags"     xml:"tags"`     // copied to Metadata.
 	validSSL bool           // can be set for https checks.
+	health   bool           // can be set for starr http checks.
 	svc      service
 }
 
@@ -120,8 +129,10 @@ type service struct {
 	Since        time.Time  `json:"since"`
 	LastCheck    time.Time  `json:"lastCheck"`
 	log          mnd.Logger
-	proc         *procExpect // only used for process checks.
-	ping         *pingExpect // only used for icmp/udp ping checks.
+	proc         *procExpect    // only used for process checks.
+	ping         *pingExpect    // only used for icmp/udp ping checks.
+	indexer      *indexerExpect // only used for prowlarr indexer checks.
+	disk         *diskExpect    // only used for starr root folder checks.
 	sync.RWMutex `json:"-"`
 }
 
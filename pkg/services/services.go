@@ -29,6 +29,10 @@ func (c *Config) Setup(services []*Service) error {
 		c.Interval.Duration = MinimumSendInterval
 	}
 
+	if err := c.setupDiskThresholds(); err != nil {
+		return err
+	}
+
 	services = append(services, c.collectApps()...)
 
 	return c.setup(services)
@@ -37,21 +41,30 @@ func (c *Config) Setup(services []*Service) error {
 func (c *Config) setup(services []*Service) error {
 	c.services = make(map[string]*Service)
 
-	for idx, check := range services {
-		if err := services[idx].Validate(); err != nil {
+	for _, check := range services {
+		if err := c.addService(check); err != nil {
 			return err
 		}
+	}
 
-		mnd.ServiceChecks.Add(check.Name+"&&Total", 0)
-		mnd.ServiceChecks.Add(check.Name+"&&"+StateUnknown.String(), 0)
-		mnd.ServiceChecks.Add(check.Name+"&&"+StateOK.String(), 0)
-		mnd.ServiceChecks.Add(check.Name+"&&"+StateWarning.String(), 0)
-		mnd.ServiceChecks.Add(check.Name+"&&"+StateCritical.String(), 0)
+	return nil
+}
 
-		// Add this validated service to our service map.
-		c.services[services[idx].Name] = services[idx]
+// addService validates a service check and adds it to our service map.
+func (c *Config) addService(check *Service) error {
+	if err := check.Validate(); err != nil {
+		return err
 	}
 
+	mnd.ServiceChecks.Add(check.Name+"&&Total", 0)
+	mnd.ServiceChecks.Add(check.Name+"&&"+StateUnknown.String(), 0)
+	mnd.ServiceChecks.Add(check.Name+"&&"+StateOK.String(), 0)
+	mnd.ServiceChecks.Add(check.Name+"&&"+StateWarning.String(), 0)
+	mnd.ServiceChecks.Add(check.Name+"&&"+StateCritical.String(), 0)
+
+	// Add this validated service to our service map.
+	c.services[check.Name] = check
+
 	return nil
 }
 
@@ -67,6 +80,9 @@ func (c *Config) Start(ctx context.Context) {
 		return
 	}
 
+	// This makes network requests, so a slow Prowlarr must not hold the lock.
+	indexers := c.collectProwlarrIndexers(ctx)
+
 	c.stopLock.Lock()
 	defer c.stopLock.Unlock()
 
@@ -74,6 +90,12 @@ func (c *Config) Start(ctx context.Context) {
 		c.Logger = logs.CustomLog(c.LogFile, "Services")
 	}
 
+	for _, svc := range indexers {
+		if err := c.addService(svc); err != nil {
+			c.Errorf("Adding Prowlarr indexer service check: %v", err)
+		}
+	}
+
 	for name := range c.services {
 		c.services[name].svc.log = c.Logger
 	}
@@ -115,21 +137,15 @@ func (c *Config) Start(ctx context.Context) {
 }
 
 func (c *Config) applyLocalOverrides() {
-	if !c.Apps.Plex.Enabled() {
-		return
-	}
-
-	name := c.Apps.Plex.Server.Name()
-	if name == "" {
-		return
-	}
+	for idx, app := range c.Apps.Plex {
+		if !app.Enabled() || app.Server.Name() == "" {
+			continue
+		}
 
-	// This is how we shoehorn the plex servr name into the service check.
-	// We do this because we don't have the name when the config file is parsed.
-	for _, svc := range c.services {
-		if svc.Name == PlexServerName {
-			svc.Tags = map[string]any{"name": name}
-			return
+		// This is how we shoehorn the plex server name into the service check.
+		// We do this because we don't have the name when the config file is parsed.
+		if svc, ok := c.services[plexServiceName(idx, app)]; ok {
+			svc.Tags = map[string]any{"name": app.Server.Name()}
 		}
 	}
 }
@@ -161,3 +161,23 @@ func (s *Snapshot) getZFSPoolData(ctx context.Context, pools []string) error {
 
 	return runCommand(cmd, waitg)
 }
+
+// GetPathUsage returns the usage for the volume that contains a path.
+func GetPathUsage(ctx context.Context, path string) (*Partition, error) {
+	usage, err := disk.UsageWithContext(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get path usage: %s: %w", path, err)
+	}
+
+	if usage.Used == 0 && usage.Free > 0 && usage.Total > usage.Free {
+		usage.Used = usage.Total - usage.Free
+	}
+
+	return &Partition{
+		Device: path,
+		Total:  usage.Total,
+		Free:   usage.Free,
+		Used:   usage.Used,
+		FSType: usage.Fstype,
+	}, nil
+}
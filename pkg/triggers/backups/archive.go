@@ -0,0 +1,306 @@
+package backups
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/Notifiarr/notifiarr/pkg/website"
+	"github.com/gorilla/mux"
+	"golift.io/starr"
+)
+
+/* This file contains the procedures to keep a local copy of verified starr backups. */
+
+// ArchiveApps is the route pattern for the app names in the archive API paths.
+const ArchiveApps = "lidarr|prowlarr|radarr|readarr|sonarr|whisparr"
+
+// ErrInvalidArchive is returned when the backup archive has no path or bad retention rules.
+var ErrInvalidArchive = errors.New("invalid backup archive")
+
+// Archive copies each backup that passes a corruption check into Path, in a folder for each app and instance.
+// The newest backup from each of the last Daily days, Weekly weeks and Monthly months is kept,
+// and the rest are deleted. Every backup is kept when all three are 0.
+type Archive struct {
+	Path    string `json:"path"    toml:"path"    xml:"path"    yaml:"path"`
+	Daily   int    `json:"daily"   toml:"daily"   xml:"daily"   yaml:"daily"`
+	Weekly  int    `json:"weekly"  toml:"weekly"  xml:"weekly"  yaml:"weekly"`
+	Monthly int    `json:"monthly" toml:"monthly" xml:"monthly" yaml:"monthly"`
+}
+
+// Archived is a backup file in the archive.
+type Archived struct {
+	App      string    `json:"app"`
+	Instance int       `json:"instance"`
+	File     string    `json:"file"`
+	Size     int64     `json:"size"`
+	Date     time.Time `json:"date"`
+}
+
+// Validate makes sure the archive has a path and usable retention rules.
+func (a *Archive) Validate() error {
+	switch {
+	case a.Path == "":
+		return fmt.Errorf("%w: no path", ErrInvalidArchive)
+	case a.Daily < 0 || a.Weekly < 0 || a.Monthly < 0:
+		return fmt.Errorf("%w: daily, weekly and monthly may not be negative", ErrInvalidArchive)
+	default:
+		return nil
+	}
+}
+
+func (c *cmd) setupArchive() {
+	if c.archive == nil {
+		return
+	}
+
+	if err := c.archive.Validate(); err != nil {
+		c.Errorf("Backup archive disabled: %v", err)
+		c.archive = nil
+
+		return
+	}
+
+	c.Printf("==> Backup Archive Enabled, path: %s, keep daily: %d, weekly: %d, monthly: %d",
+		c.archive.Path, c.archive.Daily, c.archive.Weekly, c.archive.Monthly)
+}
+
+// dir returns the archive folder for an app instance.
+func (a *Archive) dir(app string, instance int) string {
+	return filepath.Join(a.Path, strings.ToLower(app), strconv.Itoa(instance))
+}
+
+// archiveBackup copies a verified backup file into the archive, and prunes the old backups.
+// Errors are logged, because a failed copy does not change the corruption check.
+func (c *cmd) archiveBackup(input *genericInstance, fileName string, backup *starr.BackupFile) {
+	if c.archive == nil {
+		return
+	}
+
+	dir := c.archive.dir(input.name.String(), input.int)
+	dest := filepath.Join(dir, path.Base(backup.Path))
+
+	if _, err := os.Stat(dest); err == nil {
+		c.Debugf("[%s requested] %s backup file (%d) already archived: %s", input.event, input.name, input.int, dest)
+		return
+	}
+
+	if err := copyBackup(fileName, dir, dest, backup.Time); err != nil {
+		c.Errorf("[%s requested] Archiving %s backup file (%d): %v", input.event, input.name, input.int, err)
+		return
+	}
+
+	c.Printf("[%s requested] Archived %s backup file (%d): %s", input.event, input.name, input.int, dest)
+
+	for _, file := range c.archive.prune(dir) {
+		if err := os.Remove(filepath.Join(dir, file.File)); err != nil {
+			c.Errorf("[%s requested] Pruning %s backup archive (%d): %v", input.event, input.name, input.int, err)
+		} else {
+			c.Printf("[%s requested] Pruned %s backup archive (%d): %s", input.event, input.name, input.int, file.File)
+		}
+	}
+}
+
+// copyBackup copies a file into the archive. The file time is set to the backup's time, so it's used for retention.
+func copyBackup(fileName, dir, dest string, date time.Time) error {
+	if err := os.MkdirAll(dir, mnd.Mode0750); err != nil {
+		return fmt.Errorf("creating archive folder: %w", err)
+	}
+
+	src, err := os.Open(fileName)
+	if err != nil {
+		return fmt.Errorf("opening backup file: %w", err)
+	}
+	defer src.Close()
+
+	// Write to a temporary name, so a partial copy is never mistaken for a backup.
+	file, err := os.CreateTemp(dir, ".archive_*")
+	if err != nil {
+		return fmt.Errorf("creating archive file: %w", err)
+	}
+	defer os.Remove(file.Name()) // Does nothing after the rename.
+
+	if _, err := io.Copy(file, src); err != nil {
+		file.Close()
+		return fmt.Errorf("writing archive file: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("closing archive file: %w", err)
+	}
+
+	if err := os.Chtimes(file.Name(), date, date); err != nil {
+		return fmt.Errorf("setting archive file time: %w", err)
+	}
+
+	if err := os.Rename(file.Name(), dest); err != nil {
+		return fmt.Errorf("renaming archive file: %w", err)
+	}
+
+	return nil
+}
+
+// prune returns the backups in an archive folder that the retention rules do not keep.
+// The newest backup is always kept.
+func (a *Archive) prune(dir string) []*Archived {
+	files := listArchive(dir)
+	if len(files) == 0 || a.Daily+a.Weekly+a.Monthly == 0 {
+		return nil
+	}
+
+	var (
+		days   = make(map[string]bool)
+		weeks  = make(map[string]bool)
+		months = make(map[string]bool)
+		prune  = []*Archived{}
+	)
+
+	// The files are sorted newest first, so the first file seen in a day, week or month is the one kept.
+	for idx, file := range files {
+		year, week := file.Date.ISOWeek()
+		keep := idx == 0
+
+		for _, period := range []struct {
+			seen  map[string]bool
+			key   string
+			count int
+		}{
+			{seen: days, key: file.Date.Format("2006-01-02"), count: a.Daily},
+			{seen: weeks, key: fmt.Sprint(year, "-", week), count: a.Weekly},
+			{seen: months, key: file.Date.Format("2006-01"), count: a.Monthly},
+		} {
+			if !period.seen[period.key] && len(period.seen) < period.count {
+				period.seen[period.key] = true
+				keep = true
+			}
+		}
+
+		if !keep {
+			prune = append(prune, file)
+		}
+	}
+
+	return prune
+}
+
+// listArchive returns the backup files in an archive folder, newest first.
+func listArchive(dir string) []*Archived {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	files := []*Archived{}
+
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		if info, err := entry.Info(); err == nil {
+			files = append(files, &Archived{File: entry.Name(), Size: info.Size(), Date: info.ModTime().Round(time.Second)})
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Date.After(files[j].Date) })
+
+	return files
+}
+
+// ArchiveHandler returns the backups in the local archive.
+// @Description  Returns the starr backup files copied into the local archive after they passed a corruption check.
+// @Description  Provide an app, or an app and an instance, to only list their backups. Sorted newest first.
+// @Summary      List archived backups
+// @Tags         Backups
+// @Produce      json
+// @Param        app       path  string  false  "Starr app" Enums(lidarr, prowlarr, radarr, readarr, sonarr, whisparr)
+// @Param        instance  path  int64   false  "App instance (1-index)."
+// @Success      200  {object} apps.Respond.apiResponse{message=[]Archived} "archived backups"
+// @Failure      404  {object} apps.Respond.apiResponse{message=string} "backup archive is not configured"
+// @Router       /api/backups/archive [get]
+// @Router       /api/backups/archive/{app} [get]
+// @Router       /api/backups/archive/{app}/{instance} [get]
+// @Security     ApiKeyAuth
+func (a *Action) ArchiveHandler(req *http.Request) (int, interface{}) {
+	if a.cmd.archive == nil {
+		return http.StatusNotFound, "backup archive is not configured"
+	}
+
+	list := []*Archived{}
+
+	for _, app := range strings.Split(ArchiveApps, "|") {
+		if want := mux.Vars(req)["app"]; want != "" && want != app {
+			continue
+		}
+
+		instances, _ := os.ReadDir(filepath.Join(a.cmd.archive.Path, app))
+		for _, dir := range instances {
+			instance, err := strconv.Atoi(dir.Name())
+			if err != nil || !dir.IsDir() {
+				continue
+			} else if want := mux.Vars(req)["instance"]; want != "" && want != dir.Name() {
+				continue
+			}
+
+			for _, file := range listArchive(a.cmd.archive.dir(app, instance)) {
+				file.App, file.Instance = app, instance
+				list = append(list, file)
+			}
+		}
+	}
+
+	sort.SliceStable(list, func(i, j int) bool { return list[i].Date.After(list[j].Date) })
+
+	return http.StatusOK, list
+}
+
+// ArchiveFileHandler sends a backup file from the local archive.
+// This is not a normal API handler, because it sends a zip file instead of json.
+// @Description  Downloads a starr backup file from the local archive.
+// @Summary      Download an archived backup
+// @Tags         Backups
+// @Produce      application/zip
+// @Param        app       path  string  true  "Starr app" Enums(lidarr, prowlarr, radarr, readarr, sonarr, whisparr)
+// @Param        instance  path  int64   true  "App instance (1-index)."
+// @Param        file      path  string  true  "Backup file name"
+// @Success      200  {file} file "backup zip file"
+// @Failure      404  {object} string "backup file not found"
+// @Router       /api/backups/archive/{app}/{instance}/{file} [get]
+// @Security     ApiKeyAuth
+func (a *Action) ArchiveFileHandler(resp http.ResponseWriter, req *http.Request) {
+	vars := mux.Vars(req)
+	instance, _ := strconv.Atoi(vars["instance"])
+	name := vars["file"]
+
+	if a.cmd.archive == nil || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		http.Error(resp, "backup file not found", http.StatusNotFound)
+		return
+	}
+
+	file, err := os.Open(filepath.Join(a.cmd.archive.dir(vars["app"], instance), name))
+	if err != nil {
+		http.Error(resp, "backup file not found", http.StatusNotFound)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil || info.IsDir() {
+		http.Error(resp, "backup file not found", http.StatusNotFound)
+		return
+	}
+
+	a.cmd.Printf("[%s requested] Sending archived %s backup file (%d): %s", website.EventAPI, vars["app"], instance, name)
+	resp.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name}))
+	http.ServeContent(resp, req, name, info.ModTime(), file)
+}
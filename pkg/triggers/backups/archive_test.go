@@ -0,0 +1,109 @@
+package backups //nolint:testpackage // the retention rules are checked by unexported methods.
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/logs"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchivePrune(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	date := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2024, month, day, hour, 0, 0, 0, time.Local) //nolint:gosmopolitan // file times are local.
+	}
+	files := map[string]time.Time{
+		"a.zip": date(time.June, 15, 12), // Saturday, week 24.
+		"b.zip": date(time.June, 15, 8),
+		"c.zip": date(time.June, 14, 12),
+		"d.zip": date(time.June, 10, 12), // Monday, week 24.
+		"e.zip": date(time.June, 9, 12),  // Sunday, week 23.
+		"f.zip": date(time.May, 31, 12),
+		"g.zip": date(time.April, 30, 12),
+	}
+
+	for name, date := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte("zip"), 0o600))
+		require.NoError(t, os.Chtimes(path, date, date))
+	}
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".archive_123"), []byte("partial"), 0o600))
+
+	tests := []struct {
+		name    string
+		archive Archive
+		pruned  []string
+	}{
+		{name: "keep all", archive: Archive{}, pruned: nil},
+		{name: "daily", archive: Archive{Daily: 2}, pruned: []string{"b.zip", "d.zip", "e.zip", "f.zip", "g.zip"}},
+		{name: "weekly", archive: Archive{Weekly: 2}, pruned: []string{"b.zip", "c.zip", "d.zip", "f.zip", "g.zip"}},
+		{name: "monthly", archive: Archive{Monthly: 2}, pruned: []string{"b.zip", "c.zip", "d.zip", "e.zip", "g.zip"}},
+		{name: "mixed", archive: Archive{Daily: 1, Weekly: 2, Monthly: 3}, pruned: []string{"b.zip", "c.zip", "d.zip"}},
+		{name: "many", archive: Archive{Daily: 30}, pruned: []string{"b.zip"}},
+	}
+
+	for _, test := range tests {
+		var pruned []string
+		for _, file := range test.archive.prune(dir) {
+			pruned = append(pruned, file.File)
+		}
+
+		assert.ElementsMatch(t, test.pruned, pruned, test.name)
+	}
+
+	assert.Empty(t, (&Archive{Daily: 1}).prune(filepath.Join(dir, "missing")))
+}
+
+func TestArchiveValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, (&Archive{Path: "/backups"}).Validate())
+	require.NoError(t, (&Archive{Path: "/backups", Daily: 7, Weekly: 4, Monthly: 12}).Validate())
+	require.ErrorIs(t, (&Archive{Daily: 7}).Validate(), ErrInvalidArchive)
+	require.ErrorIs(t, (&Archive{Path: "/backups", Weekly: -1}).Validate(), ErrInvalidArchive)
+}
+
+func TestArchiveFileHandler(t *testing.T) {
+	t.Parallel()
+
+	archive := &Archive{Path: t.TempDir()}
+	name := `sonarr_backup "v4";2024.zip`
+	require.NoError(t, os.MkdirAll(archive.dir("sonarr", 1), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(archive.dir("sonarr", 1), name), []byte("zip"), 0o600))
+
+	action := &Action{cmd: &cmd{Config: &common.Config{Logger: logs.New()}, archive: archive}}
+	tests := []struct {
+		file   string
+		status int
+	}{
+		{file: name, status: http.StatusOK},
+		{file: "missing.zip", status: http.StatusNotFound},
+		{file: "../1/" + name, status: http.StatusNotFound},
+		{file: ".hidden", status: http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/", nil),
+			map[string]string{"app": "sonarr", "instance": "1", "file": test.file})
+		resp := httptest.NewRecorder()
+		action.ArchiveFileHandler(resp, req)
+		assert.Equal(t, test.status, resp.Code, test.file)
+
+		if test.status == http.StatusOK {
+			assert.Equal(t, `attachment; filename="sonarr_backup \"v4\";2024.zip"`,
+				resp.Header().Get("Content-Disposition"), "the file name must be quoted")
+			assert.Equal(t, "zip", resp.Body.String())
+		}
+	}
+}
@@ -26,6 +26,7 @@ func (a *Action) Backup(input *common.ActionInput, app starr.App) error {
 		a.cmd.Exec(input, TrigRadarrBackup)
 		a.cmd.Exec(input, TrigReadarrBackup)
 		a.cmd.Exec(input, TrigSonarrBackup)
+		a.cmd.Exec(input, TrigWhisparrBackup)
 	case starr.Lidarr:
 		a.cmd.Exec(input, TrigLidarrBackup)
 	case starr.Prowlarr:
@@ -36,6 +37,8 @@ func (a *Action) Backup(input *common.ActionInput, app starr.App) error {
 		a.cmd.Exec(input, TrigReadarrBackup)
 	case starr.Sonarr:
 		a.cmd.Exec(input, TrigSonarrBackup)
+	case starr.Whisparr:
+		a.cmd.Exec(input, TrigWhisparrBackup)
 	}
 
 	return nil
@@ -156,6 +159,29 @@ func (c *cmd) makeBackupTriggersProwlarr(info *clientinfo.ClientInfo) {
 	}
 }
 
+func (c *cmd) makeBackupTriggersWhisparr(info *clientinfo.ClientInfo) {
+	action := &common.Action{
+		Name: TrigWhisparrBackup,
+		Fn:   c.sendWhisparrBackups,
+		C:    make(chan *common.ActionInput, 1),
+	}
+	defer c.Add(action)
+
+	if info == nil {
+		return
+	}
+
+	for idx, app := range c.Apps.Whisparr {
+		if app.Enabled() && info.Actions.Apps.Whisparr.Backup(idx+1) != mnd.Disabled {
+			randomTime := time.Duration(c.Config.Rand().Intn(randomMinutes))*time.Second +
+				time.Duration(c.Config.Rand().Intn(randomMinutes))*time.Minute
+			action.D = cnfg.Duration{Duration: checkInterval + randomTime}
+
+			break
+		}
+	}
+}
+
 func (c *cmd) sendLidarrBackups(ctx context.Context, input *common.ActionInput) {
 	for idx, app := range c.Apps.Lidarr {
 		if ci := clientinfo.Get(); input.Type != website.EventCron ||
@@ -236,6 +262,22 @@ func (c *cmd) sendSonarrBackups(ctx context.Context, input *common.ActionInput)
 	}
 }
 
+func (c *cmd) sendWhisparrBackups(ctx context.Context, input *common.ActionInput) {
+	for idx, app := range c.Apps.Whisparr {
+		if ci := clientinfo.Get(); input.Type != website.EventCron ||
+			(ci != nil && ci.Actions.Apps.Whisparr.Backup(idx+1) != mnd.Disabled) {
+			c.sendBackups(ctx, &genericInstance{
+				event: input.Type,
+				name:  starr.Whisparr,
+				int:   idx + 1,
+				app:   app,
+				cName: app.Name,
+				skip:  !app.Enabled(),
+			})
+		}
+	}
+}
+
 func (c *cmd) sendBackups(ctx context.Context, input *genericInstance) {
 	if input.skip {
 		return
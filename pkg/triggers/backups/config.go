@@ -23,6 +23,9 @@ type cmd struct {
 	radarr   map[int]string
 	readarr  map[int]string
 	sonarr   map[int]string
+	whisparr map[int]string
+	// archive keeps a local copy of each verified backup.
+	archive *Archive
 }
 
 // Errors returned by this package.
@@ -43,11 +46,13 @@ const (
 	TrigRadarrCorrupt   common.TriggerName = "Checking Radarr for database backup corruption."
 	TrigReadarrCorrupt  common.TriggerName = "Checking Readarr for database backup corruption."
 	TrigSonarrCorrupt   common.TriggerName = "Checking Sonarr for database backup corruption."
+	TrigWhisparrCorrupt common.TriggerName = "Checking Whisparr for database backup corruption."
 	TrigLidarrBackup    common.TriggerName = "Sending Lidarr Backup File List to Notifiarr."
 	TrigProwlarrBackup  common.TriggerName = "Sending Prowlarr Backup File List to Notifiarr."
 	TrigRadarrBackup    common.TriggerName = "Sending Radarr Backup File List to Notifiarr."
 	TrigReadarrBackup   common.TriggerName = "Sending Readarr Backup File List to Notifiarr."
 	TrigSonarrBackup    common.TriggerName = "Sending Sonarr Backup File List to Notifiarr."
+	TrigWhisparrBackup  common.TriggerName = "Sending Whisparr Backup File List to Notifiarr."
 )
 
 // Info contains a pile of information about a Starr database (backup).
@@ -91,28 +96,33 @@ type Payload struct {
 }
 
 // New configures the library.
-func New(config *common.Config) *Action {
+func New(config *common.Config, archive *Archive) *Action {
 	return &Action{cmd: &cmd{
 		Config:   config,
+		archive:  archive,
 		lidarr:   make(map[int]string),
 		prowlarr: make(map[int]string),
 		radarr:   make(map[int]string),
 		readarr:  make(map[int]string),
 		sonarr:   make(map[int]string),
+		whisparr: make(map[int]string),
 	}}
 }
 
 // Create sets up all the triggers.
 func (a *Action) Create() {
 	info := clientinfo.Get()
+	a.cmd.setupArchive()
 	a.cmd.makeBackupTriggersLidarr(info)
 	a.cmd.makeBackupTriggersRadarr(info)
 	a.cmd.makeBackupTriggersReadarr(info)
 	a.cmd.makeBackupTriggersSonarr(info)
 	a.cmd.makeBackupTriggersProwlarr(info)
+	a.cmd.makeBackupTriggersWhisparr(info)
 	a.cmd.makeCorruptionTriggersLidarr(info)
 	a.cmd.makeCorruptionTriggersRadarr(info)
 	a.cmd.makeCorruptionTriggersReadarr(info)
 	a.cmd.makeCorruptionTriggersSonarr(info)
 	a.cmd.makeCorruptionTriggersProwlarr(info)
+	a.cmd.makeCorruptionTriggersWhisparr(info)
 }
@@ -33,6 +33,7 @@ func (a *Action) Corruption(input *common.ActionInput, app starr.App) error {
 		a.cmd.Exec(input, TrigRadarrCorrupt)
 		a.cmd.Exec(input, TrigReadarrCorrupt)
 		a.cmd.Exec(input, TrigSonarrCorrupt)
+		a.cmd.Exec(input, TrigWhisparrCorrupt)
 	case starr.Lidarr:
 		a.cmd.Exec(input, TrigLidarrCorrupt)
 	case starr.Prowlarr:
@@ -43,6 +44,8 @@ func (a *Action) Corruption(input *common.ActionInput, app starr.App) error {
 		a.cmd.Exec(input, TrigReadarrCorrupt)
 	case starr.Sonarr:
 		a.cmd.Exec(input, TrigSonarrCorrupt)
+	case starr.Whisparr:
+		a.cmd.Exec(input, TrigWhisparrCorrupt)
 	}
 
 	return nil
@@ -168,6 +171,30 @@ func (c *cmd) makeCorruptionTriggersSonarr(info *clientinfo.ClientInfo) {
 	}
 }
 
+func (c *cmd) makeCorruptionTriggersWhisparr(info *clientinfo.ClientInfo) {
+	action := &common.Action{
+		Name: TrigWhisparrCorrupt,
+		Fn:   c.sendWhisparrCorruption,
+		C:    make(chan *common.ActionInput, 1),
+	}
+	defer c.Add(action)
+
+	if info == nil {
+		return
+	}
+
+	for idx, app := range c.Apps.Whisparr {
+		if app.Enabled() {
+			c.whisparr[idx] = info.Actions.Apps.Whisparr.Corrupt(idx + 1) // mandatory
+			if c.whisparr[idx] != mnd.Disabled {
+				randomTime := time.Duration(c.Config.Rand().Intn(randomMinutes))*time.Second +
+					time.Duration(c.Config.Rand().Intn(randomMinutes))*time.Minute
+				action.D = cnfg.Duration{Duration: checkInterval + randomTime}
+			}
+		}
+	}
+}
+
 func (c *cmd) sendLidarrCorruption(ctx context.Context, input *common.ActionInput) {
 	for idx, app := range c.Apps.Lidarr {
 		c.lidarr[idx] = c.sendAndLogAppCorruption(ctx, &genericInstance{
@@ -238,6 +265,20 @@ func (c *cmd) sendSonarrCorruption(ctx context.Context, input *common.ActionInpu
 	}
 }
 
+func (c *cmd) sendWhisparrCorruption(ctx context.Context, input *common.ActionInput) {
+	for idx, app := range c.Apps.Whisparr {
+		c.whisparr[idx] = c.sendAndLogAppCorruption(ctx, &genericInstance{
+			event: input.Type,
+			last:  c.whisparr[idx],
+			name:  starr.Whisparr,
+			int:   idx + 1,
+			app:   app.Radarr,
+			cName: app.Name,
+			skip:  !app.Enabled(),
+		})
+	}
+}
+
 func (c *cmd) sendAndLogAppCorruption(ctx context.Context, input *genericInstance) string { //nolint:cyclop
 	if input.skip {
 		c.Debugf("Skipping corruption check on %s: %s (%d), instance disabled.", input.name, input.cName, input.int)
@@ -266,7 +307,7 @@ func (c *cmd) sendAndLogAppCorruption(ctx context.Context, input *genericInstanc
 		return input.last
 	}
 
-	backup, err := c.checkBackupFileCorruption(ctx, input, latest)
+	backup, err := c.checkBackupFileCorruption(ctx, input, fileList[0])
 	if err != nil {
 		c.Errorf("[%s requested] Checking %s Backup File Corruption (%d): %s: %v (last file: %s)",
 			input.event, input.name, input.int, latest, err, input.last)
@@ -298,8 +339,10 @@ func (c *cmd) sendAndLogAppCorruption(ctx context.Context, input *genericInstanc
 func (c *cmd) checkBackupFileCorruption(
 	ctx context.Context,
 	input *genericInstance,
-	remotePath string,
+	backupFile *starr.BackupFile,
 ) (*Info, error) {
+	remotePath := backupFile.Path
+
 	folder, err := os.MkdirTemp("", "notifiarr_tmp_dir")
 	if err != nil {
 		const moreInfo = "click here for help with this: https://notifiarr.wiki/en/Client/Configuration#tmp-not-found"
@@ -330,7 +373,13 @@ func (c *cmd) checkBackupFileCorruption(
 		if path.Ext(filePath) == ".db" {
 			c.Debugf("[%s requested] Checking %s backup sqlite3 file (%d): %s",
 				input.event, input.name, input.int, filePath)
-			return input.checkCorruptSQLite(ctx, filePath)
+
+			backup, err := input.checkCorruptSQLite(ctx, filePath)
+			if err == nil && backup.Integ == "ok" {
+				c.archiveBackup(input, fileName, backupFile) // Only verified backups are archived.
+			}
+
+			return backup, err
 		}
 	}
 
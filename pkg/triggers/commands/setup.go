@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 	"sync"
 	"time"
 
@@ -89,6 +90,17 @@ func (a *Action) GetByHash(hash string) *Command {
 	return nil
 }
 
+// GetByName returns a command by its name, or nil if there is no command with that name.
+func (a *Action) GetByName(name string) *Command {
+	for _, cmd := range a.cmd.cmdlist {
+		if strings.EqualFold(cmd.Name, name) {
+			return cmd
+		}
+	}
+
+	return nil
+}
+
 // Create initializes the library.
 func (a *Action) Create() {
 	a.cmd.create()
@@ -0,0 +1,164 @@
+// Package jsonl appends json encoded items to a file, one per line, and rotates the file when it grows too big.
+// This is used for the local history and audit logs that triggers write next to the config file.
+package jsonl
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+)
+
+// File is a json lines file. Items are appended to Path, and when Path is larger than MaxSize it is
+// renamed to Path.1 (and Path.1 to Path.2, etc) before more items are appended. Only Backups rotated
+// files are kept. A MaxSize of 0 never rotates the file.
+type File[T any] struct {
+	Path    string
+	MaxSize int64
+	Backups int
+	mu      sync.Mutex
+}
+
+// Append writes items to the end of the file. Returns true if the file was rotated first.
+func (f *File[T]) Append(items ...*T) (bool, error) {
+	if f.Path == "" || len(items) == 0 {
+		return false, nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(f.Path), mnd.Mode0750); err != nil {
+		return false, fmt.Errorf("making dir: %w", err)
+	}
+
+	rotated, err := f.rotate()
+	if err != nil {
+		return false, err
+	}
+
+	file, err := os.OpenFile(f.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, mnd.Mode0600)
+	if err != nil {
+		return rotated, fmt.Errorf("opening file: %w", err)
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	for _, item := range items {
+		if err := encoder.Encode(item); err != nil {
+			return rotated, fmt.Errorf("writing file: %w", err)
+		}
+	}
+
+	return rotated, nil
+}
+
+// rotate moves the file out of the way if it's too big. Call this with the lock held.
+func (f *File[T]) rotate() (bool, error) {
+	if stat, err := os.Stat(f.Path); err != nil || f.MaxSize <= 0 || stat.Size() < f.MaxSize {
+		return false, nil //nolint:nilerr // a missing file does not need to be rotated.
+	}
+
+	if err := os.Remove(f.backup(f.Backups)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return false, fmt.Errorf("removing old file: %w", err)
+	}
+
+	for idx := f.Backups; idx > 0; idx-- {
+		err := os.Rename(f.backup(idx-1), f.backup(idx))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return false, fmt.Errorf("rotating file: %w", err)
+		}
+	}
+
+	return true, nil
+}
+
+// backup returns the path to a rotated file. 0 is the current file.
+func (f *File[T]) backup(idx int) string {
+	if idx == 0 {
+		return f.Path
+	}
+
+	return f.Path + "." + strconv.Itoa(idx)
+}
+
+// Each reads every item from the rotated files and the current file, oldest first, and calls fn for each.
+// Lines that do not decode are skipped; a crash may leave one behind. Missing files are not an error.
+func (f *File[T]) Each(fn func(*T)) error {
+	if f.Path == "" {
+		return nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for idx := f.Backups; idx >= 0; idx-- {
+		if err := f.read(f.backup(idx), fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *File[T]) read(path string, fn func(*T)) error {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("opening file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), mnd.Megabyte)
+
+	for scanner.Scan() {
+		var item T
+		if err := json.Unmarshal(scanner.Bytes(), &item); err == nil {
+			fn(&item)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading file: %w", err)
+	}
+
+	return nil
+}
+
+// PruneBefore deletes the rotated files that were last written before cutoff.
+// Every item in those files is older than cutoff. The current file is never deleted.
+// Returns true if any file was deleted.
+func (f *File[T]) PruneBefore(cutoff time.Time) (bool, error) {
+	if f.Path == "" {
+		return false, nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	pruned := false
+
+	for idx := 1; idx <= f.Backups; idx++ {
+		stat, err := os.Stat(f.backup(idx))
+		if err != nil || !stat.ModTime().Before(cutoff) {
+			continue
+		}
+
+		if err := os.Remove(f.backup(idx)); err != nil {
+			return pruned, fmt.Errorf("removing old file: %w", err)
+		}
+
+		pruned = true
+	}
+
+	return pruned, nil
+}
@@ -0,0 +1,94 @@
+package jsonl_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common/jsonl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testItem struct {
+	ID int `json:"id"`
+}
+
+// ids returns the id of every item in the file, oldest first.
+func ids(t *testing.T, file *jsonl.File[testItem]) []int {
+	t.Helper()
+
+	output := []int{}
+	require.NoError(t, file.Each(func(item *testItem) { output = append(output, item.ID) }))
+
+	return output
+}
+
+func TestFileRotate(t *testing.T) {
+	t.Parallel()
+
+	// Each item is 9 bytes: {"id":1}\n
+	file := &jsonl.File[testItem]{Path: filepath.Join(t.TempDir(), "dir", "test.jsonl"), MaxSize: 18, Backups: 2}
+	assert.Empty(t, ids(t, file), "a missing file is empty")
+
+	tests := []struct {
+		id      int
+		rotated bool
+		want    []int
+	}{
+		{id: 1, rotated: false, want: []int{1}},
+		{id: 2, rotated: false, want: []int{1, 2}},
+		{id: 3, rotated: true, want: []int{1, 2, 3}},
+		{id: 4, rotated: false, want: []int{1, 2, 3, 4}},
+		{id: 5, rotated: true, want: []int{1, 2, 3, 4, 5}},
+		{id: 6, rotated: false, want: []int{1, 2, 3, 4, 5, 6}},
+		{id: 7, rotated: true, want: []int{3, 4, 5, 6, 7}},
+	}
+
+	for _, test := range tests {
+		rotated, err := file.Append(&testItem{ID: test.id})
+		require.NoError(t, err, test.id)
+		assert.Equal(t, test.rotated, rotated, test.id)
+		assert.Equal(t, test.want, ids(t, file), test.id)
+	}
+
+	assert.NoFileExists(t, file.Path+".3", "only the backups are kept")
+}
+
+func TestFileBrokenLine(t *testing.T) {
+	t.Parallel()
+
+	file := &jsonl.File[testItem]{Path: filepath.Join(t.TempDir(), "test.jsonl")}
+	require.NoError(t, os.WriteFile(file.Path, []byte("{\"id\":1}\n{\"id\":\n"), 0o600))
+
+	_, err := file.Append(&testItem{ID: 2}, &testItem{ID: 3})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, ids(t, file), "broken lines are skipped")
+
+	_, err = (&jsonl.File[testItem]{}).Append(&testItem{ID: 1})
+	require.NoError(t, err, "a file with no path is not written")
+}
+
+func TestFilePruneBefore(t *testing.T) {
+	t.Parallel()
+
+	file := &jsonl.File[testItem]{Path: filepath.Join(t.TempDir(), "test.jsonl"), MaxSize: 1, Backups: 2}
+	for id := range 3 {
+		_, err := file.Append(&testItem{ID: id})
+		require.NoError(t, err)
+	}
+
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(file.Path+".2", old, old))
+
+	pruned, err := file.PruneBefore(time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	assert.True(t, pruned)
+	assert.Equal(t, []int{1, 2}, ids(t, file))
+
+	pruned, err = file.PruneBefore(time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.True(t, pruned)
+	assert.Equal(t, []int{2}, ids(t, file), "the current file is never pruned")
+}
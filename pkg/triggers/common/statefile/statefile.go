@@ -0,0 +1,61 @@
+// Package statefile saves small json encoded states next to the config file,
+// so triggers may pick up where they left off after a restart.
+package statefile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+)
+
+// Save writes state to path as json. A temp file is written and renamed,
+// so a crash mid-write does not destroy a good state file. An empty path does nothing.
+func Save(path string, state any) error {
+	if path == "" {
+		return nil
+	}
+
+	payload, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("encoding state: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), mnd.Mode0750); err != nil {
+		return fmt.Errorf("making state dir: %w", err)
+	}
+
+	tmpFile := path + ".tmp"
+	if err := os.WriteFile(tmpFile, payload, mnd.Mode0600); err != nil {
+		return fmt.Errorf("writing state: %w", err)
+	}
+
+	if err := os.Rename(tmpFile, path); err != nil {
+		return fmt.Errorf("moving state into place: %w", err)
+	}
+
+	return nil
+}
+
+// Load reads the json state in path into state. An empty path or a missing file is not an error.
+func Load(path string, state any) error {
+	if path == "" {
+		return nil
+	}
+
+	payload, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("reading state: %w", err)
+	}
+
+	if err := json.Unmarshal(payload, state); err != nil {
+		return fmt.Errorf("decoding state: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package statefile_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common/statefile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveLoad(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "sub", "state.json")
+	state := map[string]int{}
+
+	require.NoError(t, statefile.Load(path, &state), "a missing file is not an error")
+	assert.Empty(t, state)
+
+	require.NoError(t, statefile.Save(path, map[string]int{"one": 1}))
+	require.NoError(t, statefile.Load(path, &state))
+	assert.Equal(t, map[string]int{"one": 1}, state)
+	assert.NoFileExists(t, path+".tmp", "the temp file must be moved into place")
+
+	require.NoError(t, os.WriteFile(path, []byte("not json"), 0o600))
+	require.Error(t, statefile.Load(path, &state))
+
+	require.NoError(t, statefile.Save("", state), "an empty path does nothing")
+	require.NoError(t, statefile.Load("", &state), "an empty path does nothing")
+}
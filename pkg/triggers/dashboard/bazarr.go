@@ -0,0 +1,52 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+)
+
+func (c *Cmd) getBazarrStates(ctx context.Context) []*State {
+	states := []*State{}
+
+	for instance, app := range c.Apps.Bazarr {
+		if !app.Enabled() {
+			continue
+		}
+
+		c.Debugf("Getting Bazarr State: %d:%s", instance+1, app.URL)
+
+		state, err := c.getBazarrState(ctx, instance+1, app)
+		if err != nil {
+			state.Error = err.Error()
+			c.Errorf("Getting Bazarr Data from %d:%s: %v", instance+1, app.URL, err)
+		}
+
+		states = append(states, state)
+	}
+
+	return states
+}
+
+// getBazarrState only needs the totals, so it asks for one item from each wanted list.
+func (c *Cmd) getBazarrState(ctx context.Context, instance int, app *apps.BazarrConfig) (*State, error) {
+	state := &State{Instance: instance, Name: app.Name}
+	start := time.Now()
+	movies, err := app.GetWantedMoviesContext(ctx, 0, 1)
+	episodes, err2 := app.GetWantedEpisodesContext(ctx, 0, 1)
+	state.Elapsed.Duration = time.Since(start)
+
+	if err != nil {
+		return state, fmt.Errorf("getting wanted movies from instance %d: %w", instance, err)
+	} else if err2 != nil {
+		return state, fmt.Errorf("getting wanted episodes from instance %d: %w", instance, err2)
+	}
+
+	state.Movies = movies.Total
+	state.Episodes = episodes.Total
+	state.Missing = movies.Total + episodes.Total
+
+	return state, nil
+}
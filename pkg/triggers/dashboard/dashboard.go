@@ -63,9 +63,9 @@ type State struct {
 	OnDisk   int64         `json:"onDisk,omitempty"`
 	Elapsed  cnfg.Duration `json:"elapsed"` // How long it took.
 	Name     string        `json:"name"`
-	// Radarr
+	// Radarr, Whisparr, Overseerr, Bazarr
 	Movies int64 `json:"movies,omitempty"`
-	// Sonarr
+	// Sonarr, Overseerr, Bazarr
 	Shows    int64 `json:"shows,omitempty"`
 	Episodes int64 `json:"episodes,omitempty"`
 	// Readarr
@@ -89,6 +89,15 @@ type State struct {
 	Month       int64 `json:"month,omitempty"`
 	Week        int64 `json:"week,omitempty"`
 	Day         int64 `json:"day,omitempty"`
+	// Torrent clients, only when the website asks for them.
+	Trackers   []*TorrentStat `json:"trackers,omitempty"`
+	Categories []*TorrentStat `json:"categories,omitempty"`
+	// Overseerr
+	Requests  int64 `json:"requests,omitempty"`
+	Pending   int64 `json:"pending,omitempty"`
+	Approved  int64 `json:"approved,omitempty"`
+	Declined  int64 `json:"declined,omitempty"`
+	Available int64 `json:"available,omitempty"`
 }
 
 // States is our compiled states for the dashboard.
@@ -97,13 +106,17 @@ type States struct {
 	Radarr   []*State `json:"radarr"`
 	Readarr  []*State `json:"readarr"`
 	Sonarr   []*State `json:"sonarr"`
+	Whisparr []*State `json:"whisparr"`
+	Bazarr   []*State `json:"bazarr"`
 	NZBGet   []*State `json:"nzbget"`
 	RTorrent []*State `json:"rtorrent"`
 	Qbit     []*State `json:"qbit"`
 	Deluge   []*State `json:"deluge"`
 	SabNZB   []*State `json:"sabnzbd"`
 	Xmission []*State `json:"transmission"`
+	Seerr    []*State `json:"overseerr"`
 	Plex     any      `json:"plexSessions"`
+	PlexMore any      `json:"morePlexSessions,omitempty"` // Plex servers after the first one.
 }
 
 // New configures the library.
@@ -164,20 +177,33 @@ func (c *Cmd) sendDashboardState(ctx context.Context, input *common.ActionInput)
 // getStates grabs data for each app.
 func (c *Cmd) getStates(ctx context.Context) *States {
 	sessions, _ := c.PlexCron.GetSessions(ctx)
-
-	return &States{
-		Deluge:   c.getDelugeStates(ctx),
+	breakdown := sendTorrentStats()
+	states := &States{
+		Deluge:   c.getDelugeStates(ctx, breakdown),
 		Lidarr:   c.getLidarrStates(ctx),
-		Qbit:     c.getQbitStates(ctx),
+		Qbit:     c.getQbitStates(ctx, breakdown),
 		NZBGet:   c.getNZBGetStates(ctx),
-		RTorrent: c.getRtorrentStates(),
+		RTorrent: c.getRtorrentStates(breakdown),
 		Radarr:   c.getRadarrStates(ctx),
 		Readarr:  c.getReadarrStates(ctx),
 		Sonarr:   c.getSonarrStates(ctx),
+		Whisparr: c.getWhisparrStates(ctx),
+		Bazarr:   c.getBazarrStates(ctx),
 		SabNZB:   c.getSabNZBStates(ctx),
-		Xmission: c.getTransmissionStates(ctx),
-		Plex:     sessions,
+		Xmission: c.getTransmissionStates(ctx, breakdown),
+		Seerr:    c.getOverseerrStates(ctx),
+	}
+
+	// The website expects a single Plex server in plexSessions, so any others are sent separately.
+	if len(sessions) > 0 {
+		states.Plex = sessions[0]
 	}
+
+	if len(sessions) > 1 {
+		states.PlexMore = sessions[1:]
+	}
+
+	return states
 }
 
 type dateSorter []*Sortable
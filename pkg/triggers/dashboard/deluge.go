@@ -10,7 +10,7 @@ import (
 	"golift.io/cnfg"
 )
 
-func (c *Cmd) getDelugeStates(ctx context.Context) []*State {
+func (c *Cmd) getDelugeStates(ctx context.Context, breakdown bool) []*State {
 	states := []*State{}
 
 	for instance, app := range c.Apps.Deluge {
@@ -20,7 +20,7 @@ func (c *Cmd) getDelugeStates(ctx context.Context) []*State {
 
 		c.Debugf("Getting Deluge State: %d:%s", instance+1, app.URL)
 
-		state, err := c.getDelugeState(ctx, instance+1, app)
+		state, err := c.getDelugeState(ctx, instance+1, app, breakdown)
 		if err != nil {
 			state.Error = err.Error()
 			c.Errorf("Getting Deluge Data from %d:%s: %v", instance+1, app.URL, err)
@@ -33,9 +33,10 @@ func (c *Cmd) getDelugeStates(ctx context.Context) []*State {
 }
 
 //nolint:funlen,cyclop
-func (c *Cmd) getDelugeState(ctx context.Context, instance int, app *apps.DelugeConfig) (*State, error) {
+func (c *Cmd) getDelugeState(ctx context.Context, instance int, app *apps.DelugeConfig, breakdown bool) (*State, error) {
 	start := time.Now()
 	xfers, err := app.GetXfersCompatContext(ctx)
+	stats := newTorrentStats(breakdown)
 	state := &State{
 		Elapsed:  cnfg.Duration{Duration: time.Since(start)},
 		Instance: instance,
@@ -91,8 +92,13 @@ func (c *Cmd) getDelugeState(ctx context.Context, instance int, app *apps.Deluge
 		if xfer.Message != "OK" {
 			state.Errors++
 		}
+
+		stats.add(xfer.TrackerHost, xfer.Label, int64(xfer.TotalSize), int64(xfer.TotalUploaded),
+			xfer.Ratio, xfer.Message != "OK")
 	}
 
+	stats.save(state)
+
 	sort.Sort(dateSorter(state.Next))
 	sort.Sort(sort.Reverse(dateSorter(state.Latest)))
 	state.Next.Shrink(showNext)
@@ -0,0 +1,46 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+)
+
+func (c *Cmd) getOverseerrStates(ctx context.Context) []*State {
+	if !c.Apps.Overseerr.Enabled() {
+		return nil
+	}
+
+	c.Debugf("Getting Overseerr State: 1:%s", c.Apps.Overseerr.URL)
+
+	state, err := c.getOverseerrState(ctx, 1, c.Apps.Overseerr)
+	if err != nil {
+		state.Error = err.Error()
+		c.Errorf("Getting Overseerr Data from 1:%s: %v", c.Apps.Overseerr.URL, err)
+	}
+
+	return []*State{state}
+}
+
+func (c *Cmd) getOverseerrState(ctx context.Context, instance int, app *apps.OverseerrConfig) (*State, error) {
+	state := &State{Instance: instance, Name: app.Name}
+	start := time.Now()
+	counts, err := app.GetCountsWithContext(ctx)
+	state.Elapsed.Duration = time.Since(start)
+
+	if err != nil {
+		return state, fmt.Errorf("getting request counts from instance %d: %w", instance, err)
+	}
+
+	state.Requests = counts.Total
+	state.Pending = counts.Pending
+	state.Approved = counts.Approved
+	state.Declined = counts.Declined
+	state.Available = counts.Available
+	state.Movies = counts.Movie
+	state.Shows = counts.TV
+
+	return state, nil
+}
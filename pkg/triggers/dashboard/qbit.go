@@ -11,7 +11,7 @@ import (
 	"golift.io/cnfg"
 )
 
-func (c *Cmd) getQbitStates(ctx context.Context) []*State {
+func (c *Cmd) getQbitStates(ctx context.Context, breakdown bool) []*State {
 	states := []*State{}
 
 	for instance, app := range c.Apps.Qbit {
@@ -21,7 +21,7 @@ func (c *Cmd) getQbitStates(ctx context.Context) []*State {
 
 		c.Debugf("Getting Qbit State: %d:%s", instance+1, app.URL)
 
-		state, err := c.getQbitState(ctx, instance+1, app)
+		state, err := c.getQbitState(ctx, instance+1, app, breakdown)
 		if err != nil {
 			state.Error = err.Error()
 			c.Errorf("Getting Qbit Data from %d:%s: %v", instance+1, app.URL, err)
@@ -33,9 +33,11 @@ func (c *Cmd) getQbitStates(ctx context.Context) []*State {
 	return states
 }
 
-func (c *Cmd) getQbitState(ctx context.Context, instance int, app *apps.QbitConfig) (*State, error) { //nolint:cyclop
+//nolint:cyclop
+func (c *Cmd) getQbitState(ctx context.Context, instance int, app *apps.QbitConfig, breakdown bool) (*State, error) {
 	start := time.Now()
 	xfers, err := app.GetXfersContext(ctx)
+	stats := newTorrentStats(breakdown)
 
 	state := &State{
 		Elapsed:  cnfg.Duration{Duration: time.Since(start)},
@@ -66,6 +68,7 @@ func (c *Cmd) getQbitState(ctx context.Context, instance int, app *apps.QbitConf
 		state.Uploaded += xfer.Uploaded
 		state.Downloaded += int64(xfer.Downloaded)
 		state.Downloads++
+		errs := state.Errors
 
 		switch strings.ToLower(strings.TrimSpace(xfer.State)) {
 		case "stalledup", "moving", "forcedup":
@@ -83,8 +86,12 @@ func (c *Cmd) getQbitState(ctx context.Context, instance int, app *apps.QbitConf
 		default:
 			state.Errors++
 		}
+
+		stats.add(xfer.Tracker, xfer.Category, xfer.Size, xfer.Uploaded, xfer.Ratio, state.Errors > errs)
 	}
 
+	stats.save(state)
+
 	sort.Sort(dateSorter(state.Next))
 	sort.Sort(sort.Reverse(dateSorter(state.Latest)))
 	state.Next.Shrink(showNext)
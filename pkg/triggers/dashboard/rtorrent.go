@@ -12,7 +12,7 @@ import (
 
 var ErrInvalidResponse = errors.New("invalid response")
 
-func (c *Cmd) getRtorrentStates() []*State {
+func (c *Cmd) getRtorrentStates(breakdown bool) []*State {
 	states := []*State{}
 
 	for instance, app := range c.Apps.Rtorrent {
@@ -22,7 +22,7 @@ func (c *Cmd) getRtorrentStates() []*State {
 
 		c.Debugf("Getting rTorrent State: %d:%s", instance+1, app.URL)
 
-		state, err := c.getRtorrentState(instance+1, app)
+		state, err := c.getRtorrentState(instance+1, app, breakdown)
 		if err != nil {
 			state.Error = err.Error()
 			c.Errorf("Getting rTorrent Data from %d:%s: %v", instance+1, app.URL, err)
@@ -34,7 +34,8 @@ func (c *Cmd) getRtorrentStates() []*State {
 	return states
 }
 
-func (c *Cmd) getRtorrentState(instance int, rTorrent *apps.RtorrentConfig) (*State, error) { //nolint:cyclop
+//nolint:cyclop
+func (c *Cmd) getRtorrentState(instance int, rTorrent *apps.RtorrentConfig, breakdown bool) (*State, error) {
 	state := &State{Instance: instance, Name: rTorrent.Name}
 	start := time.Now()
 
@@ -92,6 +93,13 @@ func (c *Cmd) getRtorrentState(instance int, rTorrent *apps.RtorrentConfig) (*St
 	state.Next.Shrink(showNext)
 	state.Latest.Shrink(showLatest)
 
+	stats := newTorrentStats(breakdown)
+	if err := rTorrentBreakdown(rTorrent, stats); err != nil {
+		return state, err
+	}
+
+	stats.save(state)
+
 	return state, nil
 }
 
@@ -0,0 +1,201 @@
+package dashboard
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"github.com/Notifiarr/notifiarr/pkg/website/clientinfo"
+	"github.com/mrobinsn/go-rtorrent/rtorrent"
+	"golang.org/x/net/publicsuffix"
+)
+
+/* This file breaks down the torrents in each torrent client by tracker and by category. */
+
+// TorrentStat is the total for the torrents in one tracker domain or one category (label).
+// An empty name is the torrents without a tracker or without a category.
+type TorrentStat struct {
+	Name     string  `json:"name"`
+	Count    int64   `json:"count"`
+	Size     int64   `json:"size"`
+	Uploaded int64   `json:"uploaded"`
+	Ratio    float64 `json:"ratio"` // average ratio.
+	Errors   int64   `json:"errors"`
+	ratios   float64
+	rated    int64
+}
+
+// torrentStats collects the tracker and category breakdowns for one torrent client instance.
+// A nil torrentStats collects nothing, so the breakdowns are only sent when the website asks for them.
+type torrentStats struct {
+	trackers   map[string]*TorrentStat
+	categories map[string]*TorrentStat
+}
+
+// sendTorrentStats returns true if the website wants the torrent breakdowns in the dashboard payload.
+func sendTorrentStats() bool {
+	ci := clientinfo.Get()
+	return ci != nil && ci.Actions.Dashboard.TorrentStats
+}
+
+func newTorrentStats(breakdown bool) *torrentStats {
+	if !breakdown {
+		return nil
+	}
+
+	return &torrentStats{
+		trackers:   make(map[string]*TorrentStat),
+		categories: make(map[string]*TorrentStat),
+	}
+}
+
+// add counts one torrent in its tracker domain and its category.
+// A negative ratio means the client does not know it, and it's left out of the average.
+func (t *torrentStats) add(tracker, category string, size, uploaded int64, ratio float64, failed bool) {
+	if t == nil {
+		return
+	}
+
+	tracker = trackerDomain(tracker)
+	if t.trackers[tracker] == nil {
+		t.trackers[tracker] = &TorrentStat{Name: tracker}
+	}
+
+	if t.categories[category] == nil {
+		t.categories[category] = &TorrentStat{Name: category}
+	}
+
+	for _, stat := range []*TorrentStat{t.trackers[tracker], t.categories[category]} {
+		stat.Count++
+		stat.Size += size
+		stat.Uploaded += uploaded
+
+		if ratio >= 0 {
+			stat.ratios += ratio
+			stat.rated++
+		}
+
+		if failed {
+			stat.Errors++
+		}
+	}
+}
+
+// save puts the breakdowns into a state, sorted by count.
+func (t *torrentStats) save(state *State) {
+	if t == nil {
+		return
+	}
+
+	state.Trackers = sortTorrentStats(t.trackers)
+	state.Categories = sortTorrentStats(t.categories)
+}
+
+func sortTorrentStats(stats map[string]*TorrentStat) []*TorrentStat {
+	list := make([]*TorrentStat, 0, len(stats))
+
+	for _, stat := range stats {
+		if stat.rated > 0 {
+			stat.Ratio = stat.ratios / float64(stat.rated)
+		}
+
+		list = append(list, stat)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Count == list[j].Count {
+			return list[i].Name < list[j].Name
+		}
+
+		return list[i].Count > list[j].Count
+	})
+
+	return list
+}
+
+// trackerDomain turns a tracker URL or host name into the tracker's domain.
+// tracker.example.co.uk:443/announce becomes example.co.uk, so each private tracker is counted once.
+func trackerDomain(tracker string) string {
+	host := strings.ToLower(strings.TrimSpace(tracker))
+	if u, err := url.Parse(host); err == nil && u.Host != "" {
+		host = u.Hostname()
+	} else if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	if host == "" || net.ParseIP(host) != nil {
+		return host
+	}
+
+	if domain, err := publicsuffix.EffectiveTLDPlusOne(host); err == nil {
+		return domain
+	}
+
+	return host
+}
+
+// rTorrentBreakdown adds every rTorrent torrent to the breakdowns.
+// This is a separate call, so an rTorrent without d.tracker_domain (older than 0.9.7) still has a dashboard state.
+func rTorrentBreakdown(rTorrent *apps.RtorrentConfig, stats *torrentStats) error {
+	if stats == nil {
+		return nil
+	}
+
+	results, err := rTorrent.Call("d.multicall2", "", string(rtorrent.ViewMain),
+		"d.tracker_domain=",
+		rtorrent.DLabel.Query(),
+		rtorrent.DSizeInBytes.Query(),
+		"d.up.total=",
+		rtorrent.DRatio.Query(),
+		"d.message=",
+	)
+	if err != nil {
+		return fmt.Errorf("%w: d.multicall2 XMLRPC call for tracker stats failed", err)
+	}
+
+	resInt, _ := results.([]interface{})
+	for _, outerResult := range resInt {
+		resOut, _ := outerResult.([]interface{})
+		for _, innerResult := range resOut {
+			data, ok := innerResult.([]interface{})
+			if !ok || len(data) != 6 { //nolint:mnd // 6 fields requested above.
+				return fmt.Errorf("%w: tracker stats returned from query are unusable", ErrInvalidResponse)
+			}
+
+			str := func(idx int) string {
+				val, _ := data[idx].(string)
+				return val
+			}
+			num := func(idx int) int64 {
+				val, _ := data[idx].(int)
+				return int64(val)
+			}
+			// The ratio from rTorrent is multiplied by 1000.
+			stats.add(str(0), str(1), num(2), num(3), float64(num(4))/1000, str(5) != "") //nolint:mnd
+		}
+	}
+
+	return nil
+}
+
+// TorrentsHandler returns the torrent client states with the tracker and category breakdowns.
+// @Description  Returns the dashboard state for every torrent client, including totals for each tracker domain and each category.
+// @Description  Use the ratio and error counts to find the trackers that need attention.
+// @Summary      Get torrent tracker and category stats
+// @Tags         Dashboard
+// @Produce      json
+// @Success      200  {object} apps.Respond.apiResponse{message=map[string][]State} "states for each torrent client"
+// @Router       /api/dashboard/torrents [get]
+// @Security     ApiKeyAuth
+func (a *Action) TorrentsHandler(req *http.Request) (int, interface{}) {
+	return http.StatusOK, map[string][]*State{
+		"qbit":         a.cmd.getQbitStates(req.Context(), true),
+		"deluge":       a.cmd.getDelugeStates(req.Context(), true),
+		"rtorrent":     a.cmd.getRtorrentStates(true),
+		"transmission": a.cmd.getTransmissionStates(req.Context(), true),
+	}
+}
@@ -0,0 +1,60 @@
+package dashboard //nolint:testpackage // the tracker breakdowns are not exported.
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrackerDomain(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		tracker string
+		want    string
+	}{
+		{tracker: "https://tracker.example.com/announce", want: "example.com"},
+		{tracker: "udp://tracker.Example.co.uk:6969/announce", want: "example.co.uk"},
+		{tracker: "tracker.example.org:443", want: "example.org"},
+		{tracker: " Example.NET ", want: "example.net"},
+		{tracker: "http://10.0.0.5:8080/announce", want: "10.0.0.5"},
+		{tracker: "[::1]:6969", want: "::1"},
+		{tracker: "localhost", want: "localhost"},
+		{tracker: "", want: ""},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, trackerDomain(test.tracker), test.tracker)
+	}
+}
+
+func TestTorrentStats(t *testing.T) {
+	t.Parallel()
+
+	var disabled *torrentStats
+
+	disabled.add("https://tracker.example.com/announce", "movies", 1, 1, 1, false)
+	disabled.save(&State{})
+	assert.Nil(t, newTorrentStats(false))
+
+	stats := newTorrentStats(true)
+	stats.add("https://a.example.com/announce", "movies", 100, 200, 2, false)
+	stats.add("https://b.example.com/announce", "tv", 100, 0, 0, true)
+	stats.add("https://other.org/announce", "tv", 50, 50, -1, false)
+
+	state := &State{}
+	stats.save(state)
+
+	require.Len(t, state.Trackers, 2)
+	assert.Equal(t, "example.com", state.Trackers[0].Name, "sub domains are counted once")
+	assert.EqualValues(t, 2, state.Trackers[0].Count)
+	assert.EqualValues(t, 200, state.Trackers[0].Size)
+	assert.EqualValues(t, 1, state.Trackers[0].Errors)
+	assert.InDelta(t, 1, state.Trackers[0].Ratio, 0.001)
+
+	require.Len(t, state.Categories, 2)
+	assert.Equal(t, "tv", state.Categories[0].Name)
+	assert.InDelta(t, 0, state.Categories[0].Ratio, 0.001, "an unknown ratio is left out of the average")
+	assert.Equal(t, "movies", state.Categories[1].Name)
+}
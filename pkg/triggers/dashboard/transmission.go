@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/Notifiarr/notifiarr/pkg/apps"
@@ -11,7 +12,7 @@ import (
 	"golift.io/cnfg"
 )
 
-func (c *Cmd) getTransmissionStates(ctx context.Context) []*State {
+func (c *Cmd) getTransmissionStates(ctx context.Context, breakdown bool) []*State {
 	states := []*State{}
 
 	for instance, app := range c.Apps.Transmission {
@@ -21,7 +22,7 @@ func (c *Cmd) getTransmissionStates(ctx context.Context) []*State {
 
 		c.Debugf("Getting Transmission State: %d:%s", instance+1, app.URL)
 
-		state, err := c.getTransmissionState(ctx, instance+1, app)
+		state, err := c.getTransmissionState(ctx, instance+1, app, breakdown)
 		if err != nil {
 			state.Error = err.Error()
 			c.Errorf("Getting Transmission Data from %d:%s: %v", instance+1, app.URL, err)
@@ -34,9 +35,15 @@ func (c *Cmd) getTransmissionStates(ctx context.Context) []*State {
 }
 
 //nolint:cyclop,funlen
-func (c *Cmd) getTransmissionState(ctx context.Context, instance int, app *apps.XmissionConfig) (*State, error) {
+func (c *Cmd) getTransmissionState(
+	ctx context.Context,
+	instance int,
+	app *apps.XmissionConfig,
+	breakdown bool,
+) (*State, error) {
 	start := time.Now()
 	xfers, err := app.TorrentGetAll(ctx)
+	stats := newTorrentStats(breakdown)
 
 	state := &State{
 		Elapsed:  cnfg.Duration{Duration: time.Since(start)},
@@ -90,8 +97,18 @@ func (c *Cmd) getTransmissionState(ctx context.Context, instance int, app *apps.
 			xmission.TorrentStatusCheck, xmission.TorrentStatusSeedWait:
 			state.Paused++
 		}
+
+		tracker := ""
+		if len(xfer.TrackerStats) > 0 {
+			tracker = xfer.TrackerStats[0].Announce
+		}
+
+		stats.add(tracker, strings.Join(xfer.Labels, ", "), int64(xfer.TotalSize.Byte()),
+			*xfer.UploadedEver, *xfer.UploadRatio, xfer.ErrorString != nil && *xfer.ErrorString != "")
 	}
 
+	stats.save(state)
+
 	sort.Sort(dateSorter(state.Next))
 	sort.Sort(sort.Reverse(dateSorter(state.Latest)))
 	state.Next.Shrink(showNext)
@@ -0,0 +1,34 @@
+package dashboard
+
+import (
+	"context"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+)
+
+func (c *Cmd) getWhisparrStates(ctx context.Context) []*State {
+	states := []*State{}
+
+	for instance, app := range c.Apps.Whisparr {
+		if !app.Enabled() {
+			continue
+		}
+
+		c.Debugf("Getting Whisparr State: %d:%s", instance+1, app.URL)
+
+		state, err := c.getWhisparrState(ctx, instance+1, app)
+		if err != nil {
+			state.Error = err.Error()
+			c.Errorf("Getting Whisparr Queue from %d:%s: %v", instance+1, app.URL, err)
+		}
+
+		states = append(states, state)
+	}
+
+	return states
+}
+
+// getWhisparrState uses the Radarr state code because Whisparr returns Radarr movies.
+func (c *Cmd) getWhisparrState(ctx context.Context, instance int, w *apps.WhisparrConfig) (*State, error) {
+	return c.getRadarrState(ctx, instance, (*apps.RadarrConfig)(w))
+}
@@ -0,0 +1,183 @@
+package downloads
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/gorilla/mux"
+)
+
+/* This file contains the API handlers that control the download clients. */
+
+// Clients is the route pattern for the download client names in the control API paths.
+const Clients = Qbit + "|" + Deluge + "|" + Xmission + "|" + Rtorrent + "|" + SabNZB + "|" + NZBGet
+
+// Priorities is the route pattern for the priorities accepted by the priority API path.
+const Priorities = PrioTop + "|" + PrioBottom + "|" + PrioUp + "|" + PrioDown + "|" +
+	PrioLow + "|" + PrioNormal + "|" + PrioHigh + "|" + PrioForce
+
+// apiItem returns the download requested in an API path.
+func apiItem(req *http.Request) *Item {
+	instance, _ := strconv.Atoi(mux.Vars(req)["instance"])
+	return &Item{Client: mux.Vars(req)["client"], Instance: instance, ID: mux.Vars(req)["id"]}
+}
+
+// apiReply turns the result of a control command into an API response.
+func apiReply(item *Item, done string, err error) (int, interface{}) {
+	switch {
+	case errors.Is(err, ErrUnknownClient), errors.Is(err, ErrNotFound):
+		return http.StatusNotFound, err
+	case errors.Is(err, ErrUnsupported):
+		return http.StatusNotImplemented, err
+	case err != nil:
+		return http.StatusServiceUnavailable, fmt.Errorf("%s %d: %w", item.Client, item.Instance, err)
+	case item.ID == "":
+		return http.StatusOK, fmt.Sprintf("%s %d: %s", item.Client, item.Instance, done)
+	default:
+		return http.StatusOK, fmt.Sprintf("%s %d: %s: %s", item.Client, item.Instance, item.ID, done)
+	}
+}
+
+// PauseHandler pauses or resumes one download, or every download in a client.
+// @Description  Pauses or resumes one download in a download client. Without an ID, every download in the client is paused or resumed.
+// @Description  The ID is a torrent hash, SABnzbd nzo_id or NZBGet NZBID.
+// @Summary      Pause or resume downloads
+// @Tags         Downloads
+// @Produce      json
+// @Param        client    path  string  true   "Download client" Enums(qbittorrent, deluge, transmission, rtorrent, sabnzbd, nzbget)
+// @Param        instance  path  int64   true   "Download client instance (1-index)."
+// @Param        action    path  string  true   "pause or resume" Enums(pause, resume)
+// @Param        id        path  string  false  "Download ID"
+// @Success      200  {object} apps.Respond.apiResponse{message=string} "action taken"
+// @Failure      404  {object} apps.Respond.apiResponse{message=string} "unknown client instance or download"
+// @Failure      503  {object} apps.Respond.apiResponse{message=string} "client error"
+// @Router       /api/downloads/{client}/{instance}/{action} [put]
+// @Router       /api/downloads/{client}/{instance}/{action}/{id} [put]
+// @Security     ApiKeyAuth
+func (a *Action) PauseHandler(req *http.Request) (int, interface{}) {
+	item := apiItem(req)
+	action := mux.Vars(req)["action"]
+	err := a.cmd.pauseItems(req.Context(), item.Client, item.Instance, item.ID, action == "pause")
+
+	return apiReply(item, action+"d", err)
+}
+
+// RemoveHandler removes a download from its client.
+// @Description  Removes a download from a download client. The downloaded data is deleted when data is true.
+// @Description  rTorrent cannot delete data.
+// @Summary      Remove a download
+// @Tags         Downloads
+// @Produce      json
+// @Param        client    path   string  true   "Download client" Enums(qbittorrent, deluge, transmission, rtorrent, sabnzbd, nzbget)
+// @Param        instance  path   int64   true   "Download client instance (1-index)."
+// @Param        id        path   string  true   "Download ID"
+// @Param        data      query  bool    false  "Also delete the downloaded data."
+// @Success      200  {object} apps.Respond.apiResponse{message=string} "action taken"
+// @Failure      404  {object} apps.Respond.apiResponse{message=string} "unknown client instance or download"
+// @Failure      501  {object} apps.Respond.apiResponse{message=string} "not supported by this client"
+// @Failure      503  {object} apps.Respond.apiResponse{message=string} "client error"
+// @Router       /api/downloads/{client}/{instance}/remove/{id} [delete]
+// @Security     ApiKeyAuth
+func (a *Action) RemoveHandler(req *http.Request) (int, interface{}) {
+	item := apiItem(req)
+	data := req.URL.Query().Get("data")
+	deleteData := data == "true" || data == "1"
+	done := "removed"
+
+	if deleteData {
+		done += " with data"
+	}
+
+	return apiReply(item, done, a.cmd.remove(req.Context(), item, deleteData))
+}
+
+// CategoryHandler changes the category of a download.
+// @Description  Changes the category of a download. Deluge calls this a label, and requires the Label plugin.
+// @Description  Transmission labels are replaced with the category.
+// @Summary      Change a download's category
+// @Tags         Downloads
+// @Produce      json
+// @Param        client    path  string  true  "Download client" Enums(qbittorrent, deluge, transmission, rtorrent, sabnzbd, nzbget)
+// @Param        instance  path  int64   true  "Download client instance (1-index)."
+// @Param        id        path  string  true  "Download ID"
+// @Param        category  path  string  true  "New category"
+// @Success      200  {object} apps.Respond.apiResponse{message=string} "action taken"
+// @Failure      404  {object} apps.Respond.apiResponse{message=string} "unknown client instance or download"
+// @Failure      503  {object} apps.Respond.apiResponse{message=string} "client error"
+// @Router       /api/downloads/{client}/{instance}/category/{id}/{category} [put]
+// @Security     ApiKeyAuth
+func (a *Action) CategoryHandler(req *http.Request) (int, interface{}) {
+	item := apiItem(req)
+	category := mux.Vars(req)["category"]
+
+	return apiReply(item, "category set to "+category, a.cmd.setCategory(req.Context(), item, category))
+}
+
+// PriorityHandler moves a download in its client's queue, or changes its priority.
+// @Description  Moves a download in its client's queue, or changes its priority.
+// @Description  top, bottom, up and down work in qBittorrent, Deluge, Transmission and NZBGet.
+// @Description  low, normal and high work in Transmission, rTorrent, SABnzbd and NZBGet. force works in SABnzbd and NZBGet.
+// @Summary      Change a download's priority
+// @Tags         Downloads
+// @Produce      json
+// @Param        client    path  string  true  "Download client" Enums(qbittorrent, deluge, transmission, rtorrent, sabnzbd, nzbget)
+// @Param        instance  path  int64   true  "Download client instance (1-index)."
+// @Param        id        path  string  true  "Download ID"
+// @Param        priority  path  string  true  "New priority" Enums(top, bottom, up, down, low, normal, high, force)
+// @Success      200  {object} apps.Respond.apiResponse{message=string} "action taken"
+// @Failure      404  {object} apps.Respond.apiResponse{message=string} "unknown client instance or download"
+// @Failure      501  {object} apps.Respond.apiResponse{message=string} "not supported by this client"
+// @Failure      503  {object} apps.Respond.apiResponse{message=string} "client error"
+// @Router       /api/downloads/{client}/{instance}/priority/{id}/{priority} [put]
+// @Security     ApiKeyAuth
+func (a *Action) PriorityHandler(req *http.Request) (int, interface{}) {
+	item := apiItem(req)
+	priority := mux.Vars(req)["priority"]
+
+	return apiReply(item, "priority set to "+priority, a.cmd.setPriority(req.Context(), item, priority))
+}
+
+// LimitsHandler sets the global speed limits in a download client.
+// @Description  Sets the global download and upload speed limits in a download client.
+// @Description  Limits are in KiB/s, and 0 removes the limit. A limit that is not provided is not changed.
+// @Description  SABnzbd and NZBGet only have a download limit.
+// @Summary      Set download client speed limits
+// @Tags         Downloads
+// @Produce      json
+// @Param        client    path   string  true   "Download client" Enums(qbittorrent, deluge, transmission, rtorrent, sabnzbd, nzbget)
+// @Param        instance  path   int64   true   "Download client instance (1-index)."
+// @Param        down      query  int64   false  "Download limit in KiB/s."
+// @Param        up        query  int64   false  "Upload limit in KiB/s."
+// @Success      200  {object} apps.Respond.apiResponse{message=string} "action taken"
+// @Failure      400  {object} apps.Respond.apiResponse{message=string} "invalid or missing limits"
+// @Failure      404  {object} apps.Respond.apiResponse{message=string} "unknown client instance"
+// @Failure      501  {object} apps.Respond.apiResponse{message=string} "not supported by this client"
+// @Failure      503  {object} apps.Respond.apiResponse{message=string} "client error"
+// @Router       /api/downloads/{client}/{instance}/limits [put]
+// @Security     ApiKeyAuth
+func (a *Action) LimitsHandler(req *http.Request) (int, interface{}) {
+	item := apiItem(req)
+	limits := make(map[string]*int64)
+
+	for _, key := range []string{"down", "up"} {
+		if !req.URL.Query().Has(key) {
+			continue
+		}
+
+		limit, err := strconv.ParseInt(req.URL.Query().Get(key), mnd.Base10, mnd.Bits64)
+		if err != nil || limit < 0 {
+			return http.StatusBadRequest, "invalid " + key + " limit: " + req.URL.Query().Get(key)
+		}
+
+		limits[key] = &limit
+	}
+
+	if len(limits) == 0 {
+		return http.StatusBadRequest, "provide a down or up limit"
+	}
+
+	return apiReply(item, "limits set", a.cmd.setLimits(req.Context(), item.Client, item.Instance, limits["down"], limits["up"]))
+}
@@ -0,0 +1,320 @@
+package downloads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	transmissionrpc "github.com/hekmon/transmissionrpc/v3"
+	"github.com/mrobinsn/go-rtorrent/rtorrent"
+)
+
+/* This file contains the procedures to control a download in its download client. */
+
+// Errors returned by this file.
+var (
+	ErrUnknownClient = errors.New("unknown download client instance")
+	ErrUnsupported   = errors.New("not supported by this download client")
+	ErrNotFound      = errors.New("download not found")
+)
+
+// pause stops a download in its download client.
+func (c *cmd) pause(ctx context.Context, item *Item) error {
+	return c.pauseItems(ctx, item.Client, item.Instance, item.ID, true)
+}
+
+// resume starts a paused download in its download client.
+func (c *cmd) resume(ctx context.Context, item *Item) error {
+	return c.pauseItems(ctx, item.Client, item.Instance, item.ID, false)
+}
+
+// pauseItems pauses, or resumes, one download. An empty id pauses or resumes every download in the client.
+func (c *cmd) pauseItems(ctx context.Context, client string, instance int, id string, pause bool) error { //nolint:cyclop
+	switch idx := instance - 1; {
+	case client == Qbit && idx >= 0 && idx < len(c.Apps.Qbit):
+		if id == "" {
+			id = "all"
+		}
+
+		return qbitPause(ctx, c.Apps.Qbit[idx], id, pause)
+	case client == Deluge && idx >= 0 && idx < len(c.Apps.Deluge):
+		return delugePause(ctx, c.Apps.Deluge[idx], id, pause)
+	case client == Xmission && idx >= 0 && idx < len(c.Apps.Transmission):
+		var hashes []string // nil controls every torrent.
+		if id != "" {
+			hashes = []string{id}
+		}
+
+		if pause {
+			return c.Apps.Transmission[idx].TorrentStopHashes(ctx, hashes) //nolint:wrapcheck
+		}
+
+		return c.Apps.Transmission[idx].TorrentStartHashes(ctx, hashes) //nolint:wrapcheck
+	case client == Rtorrent && idx >= 0 && idx < len(c.Apps.Rtorrent):
+		return rtorrentPause(c.Apps.Rtorrent[idx], id, pause)
+	case client == SabNZB && idx >= 0 && idx < len(c.Apps.SabNZB):
+		return sabnzbPause(ctx, c.Apps.SabNZB[idx], id, pause)
+	case client == NZBGet && idx >= 0 && idx < len(c.Apps.NZBGet):
+		return nzbgetPause(ctx, c.Apps.NZBGet[idx], id, pause)
+	default:
+		return fmt.Errorf("%w: %s %d", ErrUnknownClient, client, instance)
+	}
+}
+
+// remove deletes a download from its download client, and optionally deletes its data.
+func (c *cmd) remove(ctx context.Context, item *Item, deleteData bool) error { //nolint:cyclop
+	switch idx := item.Instance - 1; {
+	case item.Client == Qbit && idx >= 0 && idx < len(c.Apps.Qbit):
+		return qbitPost(ctx, c.Apps.Qbit[idx], "api/v2/torrents/delete",
+			url.Values{"hashes": {item.ID}, "deleteFiles": {fmt.Sprint(deleteData)}})
+	case item.Client == Deluge && idx >= 0 && idx < len(c.Apps.Deluge):
+		_, err := c.Apps.Deluge[idx].Get(ctx, "core.remove_torrent", []interface{}{item.ID, deleteData})
+		return err //nolint:wrapcheck
+	case item.Client == Xmission && idx >= 0 && idx < len(c.Apps.Transmission):
+		return xmissionRemove(ctx, c.Apps.Transmission[idx], item.ID, deleteData)
+	case item.Client == Rtorrent && idx >= 0 && idx < len(c.Apps.Rtorrent):
+		if deleteData { // rTorrent's API only removes the torrent; the data stays on disk.
+			return fmt.Errorf("%w: deleting data", ErrUnsupported)
+		}
+
+		_, err := c.Apps.Rtorrent[idx].Call("d.erase", item.ID)
+
+		return err //nolint:wrapcheck
+	case item.Client == SabNZB && idx >= 0 && idx < len(c.Apps.SabNZB):
+		params := url.Values{"mode": {"queue"}, "name": {"delete"}, "value": {item.ID}}
+		if deleteData {
+			params.Set("del_files", "1")
+		}
+
+		return sabnzbCall(ctx, c.Apps.SabNZB[idx], params)
+	case item.Client == NZBGet && idx >= 0 && idx < len(c.Apps.NZBGet):
+		// GroupParkDelete keeps the files that were already downloaded.
+		command := "GroupParkDelete"
+		if deleteData {
+			command = "GroupDelete"
+		}
+
+		return nzbgetEdit(ctx, c.Apps.NZBGet[idx], command, "", item.ID)
+	default:
+		return fmt.Errorf("%w: %s %d", ErrUnknownClient, item.Client, item.Instance)
+	}
+}
+
+// xmissionRemove looks up the Transmission torrent ID for a hash, then removes the torrent.
+func xmissionRemove(ctx context.Context, app *apps.XmissionConfig, hash string, deleteData bool) error {
+	torrentID, err := xmissionID(ctx, app, hash)
+	if err != nil {
+		return err
+	}
+
+	err = app.TorrentRemove(ctx, transmissionrpc.TorrentRemovePayload{
+		IDs:             []int64{torrentID},
+		DeleteLocalData: deleteData,
+	})
+	if err != nil {
+		return fmt.Errorf("removing torrent: %w", err)
+	}
+
+	return nil
+}
+
+// xmissionID returns the Transmission torrent ID for a hash. Some of its methods only accept IDs.
+func xmissionID(ctx context.Context, app *apps.XmissionConfig, hash string) (int64, error) {
+	xfers, err := app.TorrentGetAllForHashes(ctx, []string{hash})
+	if err != nil {
+		return 0, fmt.Errorf("getting torrent: %w", err)
+	} else if len(xfers) == 0 || xfers[0].ID == nil {
+		return 0, fmt.Errorf("%w: %s", ErrNotFound, hash)
+	}
+
+	return *xfers[0].ID, nil
+}
+
+// qbitPause pauses or resumes torrents in qBittorrent. hashes is a | separated list, or "all".
+func qbitPause(ctx context.Context, app *apps.QbitConfig, hashes string, pause bool) error {
+	// qBittorrent 5 renamed pause to stop and resume to start.
+	path, path5 := "api/v2/torrents/resume", "api/v2/torrents/start"
+	if pause {
+		path, path5 = "api/v2/torrents/pause", "api/v2/torrents/stop"
+	}
+
+	err := qbitPost(ctx, app, path, url.Values{"hashes": {hashes}})
+	if errors.Is(err, ErrNotFound) {
+		err = qbitPost(ctx, app, path5, url.Values{"hashes": {hashes}})
+	}
+
+	return err
+}
+
+// delugePause pauses or resumes one torrent in Deluge, or the whole session when hash is empty.
+func delugePause(ctx context.Context, app *apps.DelugeConfig, hash string, pause bool) error {
+	method, params := "core.resume_torrent", []interface{}{hash}
+
+	switch {
+	case hash == "" && pause:
+		method, params = "core.pause_session", []interface{}{}
+	case hash == "":
+		method, params = "core.resume_session", []interface{}{}
+	case pause:
+		method = "core.pause_torrent"
+	}
+
+	_, err := app.Get(ctx, method, params)
+
+	return err //nolint:wrapcheck
+}
+
+// rtorrentPause stops or starts one torrent in rTorrent, or every torrent when hash is empty.
+func rtorrentPause(app *apps.RtorrentConfig, hash string, pause bool) error {
+	method := "d.start"
+	if pause {
+		method = "d.stop"
+	}
+
+	var err error
+	if hash == "" {
+		_, err = app.Call("d.multicall2", "", string(rtorrent.ViewMain), method+"=")
+	} else {
+		_, err = app.Call(method, hash)
+	}
+
+	return err //nolint:wrapcheck
+}
+
+// sabnzbPause pauses or resumes one download in SABnzbd, or the whole queue when nzoID is empty.
+func sabnzbPause(ctx context.Context, app *apps.SabNZBConfig, nzoID string, pause bool) error {
+	mode := "resume"
+	if pause {
+		mode = "pause"
+	}
+
+	if nzoID == "" {
+		return sabnzbCall(ctx, app, url.Values{"mode": {mode}})
+	}
+
+	return sabnzbCall(ctx, app, url.Values{"mode": {"queue"}, "name": {mode}, "value": {nzoID}})
+}
+
+// nzbgetPause pauses or resumes one download in NZBGet, or all downloading when nzbID is empty.
+func nzbgetPause(ctx context.Context, app *apps.NZBGetConfig, nzbID string, pause bool) error {
+	if nzbID != "" && pause {
+		return nzbgetEdit(ctx, app, "GroupPause", "", nzbID)
+	} else if nzbID != "" {
+		return nzbgetEdit(ctx, app, "GroupResume", "", nzbID)
+	}
+
+	var (
+		success bool
+		err     error
+	)
+
+	if pause {
+		success, err = app.PauseDownloadContext(ctx)
+	} else {
+		success, err = app.ResumeDownloadContext(ctx)
+	}
+
+	return nzbgetResult("pause", success, err)
+}
+
+// nzbgetEdit runs an editqueue command against one NZBGet download.
+func nzbgetEdit(ctx context.Context, app *apps.NZBGetConfig, command, param, nzbID string) error {
+	id, err := strconv.ParseInt(nzbID, mnd.Base10, mnd.Bits64)
+	if err != nil {
+		return fmt.Errorf("%w: invalid NZBID: %s", ErrNotFound, nzbID)
+	}
+
+	success, err := app.EditQueueContext(ctx, command, param, []int64{id})
+
+	return nzbgetResult(command, success, err)
+}
+
+// nzbgetResult turns the boolean reply from an NZBGet command into an error.
+func nzbgetResult(command string, success bool, err error) error {
+	switch {
+	case err != nil:
+		return fmt.Errorf("%s: %w", command, err)
+	case !success:
+		return fmt.Errorf("%w: %s: command failed", ErrInvalidResponse, command)
+	default:
+		return nil
+	}
+}
+
+// sabnzbCall sends a command to SABnzbd. Most commands reply with a status, and failures include an error.
+func sabnzbCall(ctx context.Context, app *apps.SabNZBConfig, params url.Values) error {
+	params.Set("output", "json")
+	params.Set("apikey", app.APIKey)
+
+	var reply struct {
+		Status *bool  `json:"status"`
+		Error  string `json:"error"`
+	}
+
+	if err := app.GetURLInto(ctx, params, &reply); err != nil {
+		return fmt.Errorf("%s: %w", params.Get("mode"), err)
+	}
+
+	if reply.Error != "" || (reply.Status != nil && !*reply.Status) {
+		return fmt.Errorf("%w: %s: %s", ErrInvalidResponse, params.Get("mode"), reply.Error)
+	}
+
+	return nil
+}
+
+// qbitPost sends a command to qBittorrent. The qbit library does not have methods for these,
+// but it shares its http client (and login cookie) with the config, so that client is used here.
+func qbitPost(ctx context.Context, app *apps.QbitConfig, path string, values url.Values) error {
+	status, err := qbitPostReq(ctx, app, path, values)
+	if status == http.StatusForbidden {
+		// The login expired. Listing transfers logs in again, then try once more.
+		if _, err = app.GetXfersContext(ctx); err != nil {
+			return fmt.Errorf("logging in: %w", err)
+		}
+
+		status, err = qbitPostReq(ctx, app, path, values)
+	}
+
+	switch {
+	case err != nil:
+		return err
+	case status == http.StatusNotFound:
+		return fmt.Errorf("%w: %s: %s", ErrNotFound, path, http.StatusText(status))
+	case status != http.StatusOK:
+		return fmt.Errorf("%w: %s: %s", ErrInvalidResponse, path, http.StatusText(status))
+	default:
+		return nil
+	}
+}
+
+func qbitPostReq(ctx context.Context, app *apps.QbitConfig, path string, values url.Values) (int, error) {
+	uri := strings.TrimSuffix(app.URL, "/") + "/" + path
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, strings.NewReader(values.Encode()))
+	if err != nil {
+		return 0, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	if app.HTTPUser != "" || app.HTTPPass != "" {
+		req.SetBasicAuth(app.HTTPUser, app.HTTPPass)
+	}
+
+	resp, err := app.Config.Client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("%s failed: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,341 @@
+package downloads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/snapshot"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common/statefile"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/data"
+	"github.com/Notifiarr/notifiarr/pkg/website"
+	"golift.io/cnfg"
+)
+
+/* This file contains the procedures to pause downloads when a volume fills up, and resume them when it has room. */
+
+const TrigDiskGuard common.TriggerName = "Checking volume usage for download throttling."
+
+// How often to check volume usage.
+const diskDuration = time.Minute
+
+// ErrInvalidDiskGuard is returned when the disk guard has no paths or bad water marks.
+var ErrInvalidDiskGuard = errors.New("invalid disk guard")
+
+// Disk guard events sent to the website.
+const (
+	DiskPaused  = "paused"
+	DiskResumed = "resumed"
+)
+
+// diskGuardKey is where the guard state is saved in the data cache, so paused downloads survive reloads.
+const diskGuardKey = "downloadsDiskGuard"
+
+// DiskStateFileName is the name of the file the guard state is saved to, so paused downloads survive restarts.
+// It lives in the same folder as the config file.
+const DiskStateFileName = "diskguard.json"
+
+// DiskGuard pauses downloads when any volume with one of Paths passes High percent used.
+// The downloads are resumed when every volume drops below Low percent used.
+// Only downloads this client paused are resumed. NewOnly leaves running downloads alone,
+// and only pauses downloads added after the high-water mark was passed.
+type DiskGuard struct {
+	Paths   []string `json:"paths"   toml:"paths"    xml:"path"     yaml:"paths"`
+	High    float64  `json:"high"    toml:"high"     xml:"high"     yaml:"high"`
+	Low     float64  `json:"low"     toml:"low"      xml:"low"      yaml:"low"`
+	NewOnly bool     `json:"newOnly" toml:"new_only" xml:"new_only" yaml:"newOnly"`
+}
+
+// Volume is the usage for one disk guard path.
+type Volume struct {
+	Path    string  `json:"path"`
+	Total   uint64  `json:"total"`
+	Free    uint64  `json:"free"`
+	Used    uint64  `json:"used"`
+	Percent float64 `json:"percent"`
+	Error   string  `json:"error,omitempty"`
+}
+
+// DiskPayload is sent to the website when downloads are paused or resumed.
+type DiskPayload struct {
+	Event   string    `json:"event"`
+	High    float64   `json:"high"`
+	Low     float64   `json:"low"`
+	NewOnly bool      `json:"newOnly"`
+	Volumes []*Volume `json:"volumes"`
+	Items   []*Item   `json:"items"`
+	Errors  []string  `json:"errors,omitempty"`
+}
+
+// diskState tracks the downloads paused by the disk guard.
+type diskState struct {
+	Paused bool      `json:"paused"`
+	Since  time.Time `json:"since"`
+	// Known has the downloads that existed when the high-water mark was passed. Used with NewOnly.
+	// Recorded is true once they were saved, so a pass with no downloads is not recorded twice.
+	Recorded bool            `json:"recorded"`
+	Known    map[string]bool `json:"known"`
+	// Held has the downloads the guard paused, so only those are resumed.
+	Held map[string]*Item `json:"held"`
+}
+
+// Validate makes sure the disk guard has paths and usable water marks.
+func (d *DiskGuard) Validate() error {
+	switch {
+	case len(d.Paths) == 0:
+		return fmt.Errorf("%w: no paths", ErrInvalidDiskGuard)
+	case d.High <= 0 || d.High > 100:
+		return fmt.Errorf("%w: high must be between 0 and 100: %v", ErrInvalidDiskGuard, d.High)
+	case d.Low <= 0 || d.Low >= d.High:
+		return fmt.Errorf("%w: low must be above 0 and below high: %v", ErrInvalidDiskGuard, d.Low)
+	default:
+		return nil
+	}
+}
+
+func (c *cmd) setupDiskGuard() {
+	if c.diskGuard == nil {
+		return
+	}
+
+	if err := c.diskGuard.Validate(); err != nil {
+		c.Errorf("Disk guard disabled: %v", err)
+		c.diskGuard = nil
+
+		return
+	}
+
+	c.Printf("==> Download Disk Guard Started, paths: %s, high: %.1f%%, low: %.1f%%, new only: %v, interval: %s",
+		strings.Join(c.diskGuard.Paths, ", "), c.diskGuard.High, c.diskGuard.Low, c.diskGuard.NewOnly, diskDuration)
+
+	c.Add(&common.Action{
+		Name: TrigDiskGuard,
+		Fn:   c.checkDisks,
+		C:    make(chan *common.ActionInput, 1),
+		D:    cnfg.Duration{Duration: diskDuration},
+	})
+}
+
+// getDiskState returns the disk guard state from the data cache.
+// After a restart the state is read from the state file, so held downloads are still resumed.
+func (c *cmd) getDiskState() *diskState {
+	if item := data.Get(diskGuardKey); item != nil && item.Data != nil {
+		if state, ok := item.Data.(*diskState); ok {
+			return state
+		}
+	}
+
+	state, err := loadDiskState(c.diskFile)
+	if err != nil {
+		c.Errorf("Disk guard: loading state: %v", err)
+	} else if state.Paused {
+		c.Printf("Disk guard: resuming from saved state, %d downloads held since %s.",
+			len(state.Held), state.Since.Round(time.Second))
+	}
+
+	data.Save(diskGuardKey, state)
+
+	return state
+}
+
+// loadDiskState reads the disk guard state from a file. A new state is returned if the file does not exist.
+func loadDiskState(path string) (*diskState, error) {
+	state := &diskState{}
+	if err := statefile.Load(path, state); err != nil {
+		return &diskState{Known: make(map[string]bool), Held: make(map[string]*Item)}, err //nolint:wrapcheck
+	}
+
+	if state.Known == nil {
+		state.Known = make(map[string]bool)
+	}
+
+	if state.Held == nil {
+		state.Held = make(map[string]*Item)
+	}
+
+	return state, nil
+}
+
+// checkDisks runs on an interval, and pauses or resumes downloads based on volume usage.
+// The state is saved to a file while downloads are paused, and once more when they are resumed.
+func (c *cmd) checkDisks(ctx context.Context, input *common.ActionInput) {
+	volumes, full, errs := c.diskUsage(ctx)
+	state := c.getDiskState()
+	wasPaused := state.Paused
+
+	defer func() {
+		if !wasPaused && !state.Paused {
+			return
+		}
+
+		if err := statefile.Save(c.diskFile, state); err != nil {
+			c.Errorf("[%s requested] Disk guard: saving state: %v", input.Type, err)
+		}
+	}()
+
+	switch {
+	case !state.Paused && full:
+		state.Paused, state.Since = true, time.Now()
+		c.Printf("[%s requested] Disk guard: volume usage passed %.1f%%, pausing downloads.", input.Type, c.diskGuard.High)
+
+		items, errs := c.holdDownloads(ctx, state)
+		c.sendDiskEvent(input, DiskPaused, volumes, items, errs)
+	case state.Paused && len(errs) == 0 && !c.aboveLow(volumes):
+		items, errs := c.releaseDownloads(ctx, state)
+		state.Paused = false
+		c.Printf("[%s requested] Disk guard: volume usage dropped below %.1f%%, resumed %d downloads paused for %s.",
+			input.Type, c.diskGuard.Low, len(items), time.Since(state.Since).Round(time.Second))
+		c.sendDiskEvent(input, DiskResumed, volumes, items, errs)
+	case state.Paused:
+		// Downloads added while paused are paused too.
+		items, errs := c.holdDownloads(ctx, state)
+		for _, err := range errs {
+			c.Errorf("[%s requested] Disk guard: %s", input.Type, err)
+		}
+
+		if len(items) > 0 {
+			c.Printf("[%s requested] Disk guard: paused %d new downloads.", input.Type, len(items))
+		}
+	}
+}
+
+// diskUsage returns the usage of every disk guard path, and true if any of them passed the high-water mark.
+func (c *cmd) diskUsage(ctx context.Context) ([]*Volume, bool, []string) {
+	var (
+		volumes = []*Volume{}
+		errs    = []string{}
+		full    bool
+	)
+
+	for _, path := range c.diskGuard.Paths {
+		usage, err := snapshot.GetPathUsage(ctx, path)
+		if err != nil {
+			c.Errorf("Disk guard: %v", err)
+			errs = append(errs, err.Error())
+			volumes = append(volumes, &Volume{Path: path, Error: err.Error()})
+
+			continue
+		}
+
+		volume := &Volume{
+			Path:    path,
+			Total:   usage.Total,
+			Free:    usage.Free,
+			Used:    usage.Used,
+			Percent: usedPercent(usage.Used, usage.Free),
+		}
+		full = full || volume.Percent >= c.diskGuard.High
+		volumes = append(volumes, volume)
+	}
+
+	return volumes, full, errs
+}
+
+// usedPercent returns the percent of a volume that is used, like df does.
+// Blocks reserved for root are not counted, so this is used/(used+free), not used/total.
+func usedPercent(used, free uint64) float64 {
+	if used+free == 0 {
+		return 0
+	}
+
+	return float64(used) / float64(used+free) * 100 //nolint:mnd
+}
+
+// aboveLow returns true if any volume is still above the low-water mark.
+func (c *cmd) aboveLow(volumes []*Volume) bool {
+	for _, volume := range volumes {
+		if volume.Percent >= c.diskGuard.Low {
+			return true
+		}
+	}
+
+	return false
+}
+
+// holdDownloads pauses every running download the guard has not paused yet.
+// With NewOnly, the downloads that existed when the guard first paused are left running.
+func (c *cmd) holdDownloads(ctx context.Context, state *diskState) ([]*Item, []string) {
+	list := c.getItems(ctx)
+	first := !state.Recorded
+	fetched := len(list.errors) == 0
+	items := []*Item{}
+
+	for _, item := range list.items {
+		// Finished torrents do not use more space, and usenet history is not downloading.
+		if item.History || item.Progress >= 100 || item.paused() {
+			continue
+		}
+
+		key := diskKey(item)
+		if first && c.diskGuard.NewOnly {
+			state.Known[key] = true
+			continue
+		} else if state.Known[key] || state.Held[key] != nil {
+			continue
+		}
+
+		if err := c.pause(ctx, item); err != nil {
+			list.errors = append(list.errors, fmt.Sprintf("pausing %s %d: %s: %v", item.Client, item.Instance, item.Title, err))
+			continue
+		}
+
+		state.Held[key] = item
+		items = append(items, item)
+	}
+
+	// A client that failed may be hiding running downloads, so they are recorded again next time.
+	if fetched {
+		state.Recorded = true
+	}
+
+	return items, list.errors
+}
+
+// releaseDownloads resumes every download the guard paused.
+func (c *cmd) releaseDownloads(ctx context.Context, state *diskState) ([]*Item, []string) {
+	items, errs := []*Item{}, []string{}
+
+	for key, item := range state.Held {
+		if err := c.resume(ctx, item); err != nil {
+			errs = append(errs, fmt.Sprintf("resuming %s %d: %s: %v", item.Client, item.Instance, item.Title, err))
+		} else {
+			items = append(items, item)
+		}
+
+		delete(state.Held, key)
+	}
+
+	state.Known, state.Recorded = make(map[string]bool), false
+
+	return items, errs
+}
+
+func diskKey(item *Item) string {
+	return fmt.Sprint(item.Client, item.Instance, strings.ToLower(item.ID))
+}
+
+// sendDiskEvent notifies the website when downloads are paused or resumed.
+func (c *cmd) sendDiskEvent(input *common.ActionInput, event string, volumes []*Volume, items []*Item, errs []string) {
+	for _, err := range errs {
+		c.Errorf("[%s requested] Disk guard: %s", input.Type, err)
+	}
+
+	c.SendData(&website.Request{
+		Route:      website.DiskRoute,
+		Event:      input.Type,
+		LogPayload: true,
+		LogMsg:     fmt.Sprintf("Disk Guard: %s %d downloads", event, len(items)),
+		Payload: &DiskPayload{
+			Event:   event,
+			High:    c.diskGuard.High,
+			Low:     c.diskGuard.Low,
+			NewOnly: c.diskGuard.NewOnly,
+			Volumes: volumes,
+			Items:   items,
+			Errors:  errs,
+		},
+	})
+}
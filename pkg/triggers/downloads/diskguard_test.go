@@ -0,0 +1,112 @@
+package downloads //nolint:testpackage // the disk guard state is not exported.
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common/statefile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskGuardValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		guard DiskGuard
+		valid bool
+	}{
+		{name: "good", guard: DiskGuard{Paths: []string{"/downloads"}, High: 95, Low: 90}, valid: true},
+		{name: "full", guard: DiskGuard{Paths: []string{"/downloads"}, High: 100, Low: 99.5}, valid: true},
+		{name: "no paths", guard: DiskGuard{High: 95, Low: 90}, valid: false},
+		{name: "no high", guard: DiskGuard{Paths: []string{"/downloads"}, Low: 90}, valid: false},
+		{name: "high too high", guard: DiskGuard{Paths: []string{"/downloads"}, High: 101, Low: 90}, valid: false},
+		{name: "no low", guard: DiskGuard{Paths: []string{"/downloads"}, High: 95}, valid: false},
+		{name: "low above high", guard: DiskGuard{Paths: []string{"/downloads"}, High: 90, Low: 95}, valid: false},
+		{name: "low is high", guard: DiskGuard{Paths: []string{"/downloads"}, High: 90, Low: 90}, valid: false},
+	}
+
+	for _, test := range tests {
+		err := test.guard.Validate()
+		if test.valid {
+			require.NoError(t, err, test.name)
+		} else {
+			require.ErrorIs(t, err, ErrInvalidDiskGuard, test.name)
+		}
+	}
+}
+
+func TestUsedPercent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		used uint64
+		free uint64
+		want float64
+	}{
+		{used: 0, free: 0, want: 0},
+		{used: 0, free: 100, want: 0},
+		{used: 50, free: 50, want: 50},
+		{used: 95, free: 0, want: 100}, // reserved blocks are not free space.
+		{used: 900, free: 100, want: 90},
+	}
+
+	for _, test := range tests {
+		assert.InDelta(t, test.want, usedPercent(test.used, test.free), 0.001, "%d used, %d free", test.used, test.free)
+	}
+}
+
+func TestAboveLow(t *testing.T) {
+	t.Parallel()
+
+	cmd := &cmd{diskGuard: &DiskGuard{High: 95, Low: 90}}
+
+	tests := []struct {
+		name    string
+		volumes []*Volume
+		want    bool
+	}{
+		{name: "none", volumes: nil, want: false},
+		{name: "all below", volumes: []*Volume{{Percent: 10}, {Percent: 89.9}}, want: false},
+		{name: "one at low", volumes: []*Volume{{Percent: 10}, {Percent: 90}}, want: true},
+		{name: "one above", volumes: []*Volume{{Percent: 96}}, want: true},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, cmd.aboveLow(test.volumes), test.name)
+	}
+}
+
+func TestLoadDiskState(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), DiskStateFileName)
+
+	state, err := loadDiskState(path)
+	require.NoError(t, err, "a missing file is a new state")
+	assert.False(t, state.Paused)
+	assert.NotNil(t, state.Known)
+	assert.NotNil(t, state.Held)
+
+	since := time.Now().Add(-time.Hour).Round(time.Second)
+	item := &Item{Client: Qbit, Instance: 1, ID: "abc", Title: "Movie"}
+	require.NoError(t, statefile.Save(path, &diskState{
+		Paused: true, Since: since, Held: map[string]*Item{diskKey(item): item},
+	}))
+
+	state, err = loadDiskState(path)
+	require.NoError(t, err)
+	assert.True(t, state.Paused, "the guard must still be paused after a restart")
+	assert.True(t, since.Equal(state.Since))
+	assert.Equal(t, map[string]*Item{diskKey(item): item}, state.Held, "held downloads must be resumed after a restart")
+	assert.NotNil(t, state.Known, "missing maps are made")
+
+	require.NoError(t, os.WriteFile(path, []byte("not json"), 0o600))
+	state, err = loadDiskState(path)
+	require.Error(t, err)
+	assert.False(t, state.Paused)
+	assert.NotNil(t, state.Held)
+}
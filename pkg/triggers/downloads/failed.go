@@ -0,0 +1,415 @@
+package downloads
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/sabnzbd"
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common/statefile"
+	"github.com/Notifiarr/notifiarr/pkg/website"
+	"golift.io/cnfg"
+	"golift.io/nzbget"
+	"golift.io/starr"
+)
+
+/* This file contains the procedures to report failed usenet downloads, grouped by category and indexer. */
+
+const TrigFailedDownloads common.TriggerName = "Sending failed usenet downloads to website."
+
+// FailedSentFileName is the name of the file that remembers the newest failed download sent from each client.
+// It lives in the same folder as the config file.
+const FailedSentFileName = "failedsent.json"
+
+const (
+	// How often to send the failed downloads report.
+	failedDuration = time.Hour
+	// This is the max number of failed downloads sent to the website. The groups include every failure.
+	failedPayloadMax = 100
+	// This is how many grab events are read from each starr app to find the indexer for a download.
+	grabHistoryMax = 1000
+)
+
+// These are the reasons a usenet download fails.
+const (
+	ReasonArticles = "missing articles"
+	ReasonPassword = "password protected"
+	ReasonUnpack   = "unpack failed"
+	ReasonRepair   = "repair failed"
+	ReasonOther    = "other"
+)
+
+// unknownIndexer is used when neither a starr app nor the nzb URL identify the indexer.
+const unknownIndexer = "unknown"
+
+// FailedConfig turns on the hourly failed usenet downloads report.
+// Only failures newer than the last report are sent.
+type FailedConfig struct {
+	Enabled bool `json:"enabled" toml:"enabled" xml:"enabled" yaml:"enabled"`
+}
+
+// Failed is a failed download from SABnzbd or NZBGet history.
+type Failed struct {
+	Client   string    `json:"client"`
+	Instance int       `json:"instance"`
+	Name     string    `json:"name"`
+	ID       string    `json:"id"`
+	Title    string    `json:"title"`
+	Category string    `json:"category"`
+	Indexer  string    `json:"indexer"`
+	Reason   string    `json:"reason"`
+	Message  string    `json:"message"`
+	Size     int64     `json:"size"`
+	Date     time.Time `json:"date"`
+}
+
+// FailedGroup counts the failed downloads for one category and indexer.
+type FailedGroup struct {
+	Category string         `json:"category"`
+	Indexer  string         `json:"indexer"`
+	Count    int            `json:"count"`
+	Reasons  map[string]int `json:"reasons"`
+}
+
+// FailedReport is the failed download history from every usenet client.
+// Groups are sorted with the most failures first, and Failed is sorted newest first.
+type FailedReport struct {
+	Total  int            `json:"total"`
+	Groups []*FailedGroup `json:"groups"`
+	Failed []*Failed      `json:"failed"`
+	Errors []string       `json:"errors,omitempty"`
+}
+
+func (c *cmd) setupFailed() {
+	if c.failed == nil || !c.failed.Enabled {
+		return
+	}
+
+	clients := 0
+
+	for _, app := range c.Apps.SabNZB {
+		if app.Enabled() {
+			clients++
+		}
+	}
+
+	for _, app := range c.Apps.NZBGet {
+		if app.Enabled() {
+			clients++
+		}
+	}
+
+	if clients == 0 {
+		return
+	}
+
+	if err := statefile.Load(c.sentFile, &c.failedSent); err != nil {
+		c.Errorf("Loading failed downloads sent state: %v", err)
+	}
+
+	c.Add(&common.Action{
+		Name: TrigFailedDownloads,
+		Fn:   c.sendFailed,
+		C:    make(chan *common.ActionInput, 1),
+		D:    cnfg.Duration{Duration: failedDuration},
+	})
+}
+
+// SendFailed sends the failed usenet downloads report to the website.
+func (a *Action) SendFailed(event website.EventType) {
+	a.cmd.Exec(&common.ActionInput{Type: event}, TrigFailedDownloads)
+}
+
+// FailedHandler returns the failed usenet downloads report.
+// @Description  Returns the failed downloads in SABnzbd and NZBGet history, with the reason each one failed.
+// @Description  Failures are grouped by category and indexer. The indexer comes from the starr app that grabbed the download.
+// @Summary      Retrieve failed usenet downloads.
+// @Tags         Downloads
+// @Produce      json
+// @Success      200  {object} apps.Respond.apiResponse{message=FailedReport} "failed downloads"
+// @Failure      404  {object} string "bad token or api key"
+// @Router       /api/downloads/failed [get]
+// @Security     ApiKeyAuth
+func (a *Action) FailedHandler(req *http.Request) (int, interface{}) {
+	return http.StatusOK, a.cmd.failedReport(req.Context(), nil)
+}
+
+// sendFailed sends the failures that are newer than the last report.
+// The newest failure from each client is saved after the website accepts the report.
+func (c *cmd) sendFailed(ctx context.Context, input *common.ActionInput) {
+	report := c.failedReport(ctx, c.failedSent)
+	for _, err := range report.Errors {
+		c.Errorf("[%s requested] Failed downloads report: %s", input.Type, err)
+	}
+
+	if report.Total == 0 {
+		c.Debugf("[%s requested] No new failed usenet downloads found.", input.Type)
+		return
+	}
+
+	sent := newestFailed(report.Failed, c.failedSent)
+
+	if len(report.Failed) > failedPayloadMax {
+		report.Failed = report.Failed[:failedPayloadMax]
+	}
+
+	_, err := c.GetData(&website.Request{
+		Route:      website.FailedRoute,
+		Event:      input.Type,
+		LogPayload: true,
+		ErrorsOnly: !c.DebugEnabled(),
+		LogMsg:     fmt.Sprintf("Failed Usenet Downloads: %d, groups: %d", report.Total, len(report.Groups)),
+		Payload:    report,
+	})
+	if err != nil {
+		c.Errorf("[%s requested] Sending failed downloads report: %v", input.Type, err)
+		return
+	}
+
+	c.failedSent = sent
+	if err := statefile.Save(c.sentFile, c.failedSent); err != nil {
+		c.Errorf("[%s requested] Saving failed downloads sent state: %v", input.Type, err)
+	}
+}
+
+// failedKey identifies a usenet client in the sent state.
+func failedKey(failed *Failed) string {
+	return failed.Client + strconv.Itoa(failed.Instance)
+}
+
+// newestFailed returns a copy of sent with the newest failure date from each client.
+func newestFailed(failed []*Failed, sent map[string]time.Time) map[string]time.Time {
+	newest := make(map[string]time.Time, len(sent))
+	for key, date := range sent {
+		newest[key] = date
+	}
+
+	for _, item := range failed {
+		if key := failedKey(item); item.Date.After(newest[key]) {
+			newest[key] = item.Date
+		}
+	}
+
+	return newest
+}
+
+// failedReport collects the failed downloads from every usenet client, and groups them.
+// Failures that are not newer than the date in since for their client are skipped. A nil since skips nothing.
+func (c *cmd) failedReport(ctx context.Context, since map[string]time.Time) *FailedReport {
+	report := &FailedReport{Groups: []*FailedGroup{}, Failed: []*Failed{}, Errors: []string{}}
+
+	for idx, app := range c.Apps.SabNZB {
+		if app.Enabled() {
+			failed, err := sabnzbFailed(ctx, idx+1, app)
+			if err != nil {
+				report.Errors = append(report.Errors, wrap(SabNZB, err).Error())
+			}
+
+			report.Failed = append(report.Failed, failed...)
+		}
+	}
+
+	for idx, app := range c.Apps.NZBGet {
+		if app.Enabled() {
+			failed, err := nzbgetFailed(ctx, idx+1, app)
+			if err != nil {
+				report.Errors = append(report.Errors, wrap(NZBGet, err).Error())
+			}
+
+			report.Failed = append(report.Failed, failed...)
+		}
+	}
+
+	report.Failed = newFailed(report.Failed, since)
+	if len(report.Failed) == 0 {
+		return report
+	}
+
+	indexers, errs := c.grabIndexers(ctx)
+	report.Errors = append(report.Errors, errs...)
+	report.Total = len(report.Failed)
+	groups := make(map[string]*FailedGroup)
+
+	for _, failed := range report.Failed {
+		// The starr app knows the indexer better than the nzb URL does.
+		if indexer := indexers[strings.ToLower(failed.ID)]; indexer != "" {
+			failed.Indexer = indexer
+		}
+
+		key := failed.Category + "\x00" + failed.Indexer
+		if groups[key] == nil {
+			groups[key] = &FailedGroup{Category: failed.Category, Indexer: failed.Indexer, Reasons: make(map[string]int)}
+			report.Groups = append(report.Groups, groups[key])
+		}
+
+		groups[key].Count++
+		groups[key].Reasons[failed.Reason]++
+	}
+
+	sort.Slice(report.Groups, func(i, j int) bool {
+		if report.Groups[i].Count != report.Groups[j].Count {
+			return report.Groups[i].Count > report.Groups[j].Count
+		}
+
+		return report.Groups[i].Category+report.Groups[i].Indexer < report.Groups[j].Category+report.Groups[j].Indexer
+	})
+	sort.SliceStable(report.Failed, func(i, j int) bool {
+		return report.Failed[i].Date.After(report.Failed[j].Date)
+	})
+
+	return report
+}
+
+// newFailed returns the failures that are newer than the date in since for their client.
+func newFailed(failed []*Failed, since map[string]time.Time) []*Failed {
+	if len(since) == 0 {
+		return failed
+	}
+
+	list := make([]*Failed, 0, len(failed))
+
+	for _, item := range failed {
+		if item.Date.After(since[failedKey(item)]) {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
+
+func sabnzbFailed(ctx context.Context, instance int, app *apps.SabNZBConfig) ([]*Failed, error) {
+	params := url.Values{}
+	params.Add("output", "json")
+	params.Add("mode", "history")
+	params.Add("failed_only", "1")
+	params.Add("apikey", app.APIKey)
+
+	var hist struct {
+		History *sabnzbd.History `json:"history"`
+	}
+
+	if err := app.GetURLInto(ctx, params, &hist); err != nil {
+		return nil, fmt.Errorf("getting failed history from instance %d: %w", instance, err)
+	} else if hist.History == nil {
+		return nil, nil
+	}
+
+	failed := make([]*Failed, 0, len(hist.History.Slots))
+	for _, xfer := range hist.History.Slots {
+		failed = append(failed, &Failed{
+			Client:   SabNZB,
+			Instance: instance,
+			Name:     app.Name,
+			ID:       xfer.NzoID,
+			Title:    xfer.Name,
+			Category: xfer.Category,
+			Indexer:  urlHost(xfer.URL),
+			Reason:   sabnzbReason(xfer.FailMessage),
+			Message:  xfer.FailMessage,
+			Size:     xfer.Bytes,
+			Date:     time.Unix(xfer.Completed, 0),
+		})
+	}
+
+	return failed, nil
+}
+
+// sabnzbReason picks a failure reason from the message SABnzbd gives a failed download.
+func sabnzbReason(message string) string {
+	switch msg := strings.ToLower(message); {
+	case strings.Contains(msg, "password"), strings.Contains(msg, "encrypt"):
+		return ReasonPassword
+	case strings.Contains(msg, "not-complete"), strings.Contains(msg, "cannot be completed"),
+		strings.Contains(msg, "not on your server"), strings.Contains(msg, "missing"), strings.Contains(msg, "article"):
+		return ReasonArticles
+	case strings.Contains(msg, "unpack"):
+		return ReasonUnpack
+	case strings.Contains(msg, "repair"), strings.Contains(msg, "par2"), strings.Contains(msg, "verif"):
+		return ReasonRepair
+	default:
+		return ReasonOther
+	}
+}
+
+func nzbgetFailed(ctx context.Context, instance int, app *apps.NZBGetConfig) ([]*Failed, error) {
+	hist, err := app.HistoryContext(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("getting history from instance %d: %w", instance, err)
+	}
+
+	failed := []*Failed{}
+
+	for _, xfer := range hist {
+		// Manual and duplicate deletes are not failures.
+		if !strings.HasPrefix(xfer.Status, "FAILURE") &&
+			xfer.DeleteStatus != nzbget.DeleteHEALTH && xfer.DeleteStatus != nzbget.DeleteBAD {
+			continue
+		}
+
+		failed = append(failed, &Failed{
+			Client:   NZBGet,
+			Instance: instance,
+			Name:     app.Name,
+			ID:       strconv.FormatInt(xfer.NZBID, mnd.Base10),
+			Title:    xfer.Name,
+			Category: xfer.Category,
+			Indexer:  urlHost(xfer.URL),
+			Reason:   nzbgetReason(xfer),
+			Message:  xfer.Status,
+			Size:     xfer.FileSizeMB * mnd.Megabyte,
+			Date:     xfer.HistoryTime.Time,
+		})
+	}
+
+	return failed, nil
+}
+
+// nzbgetReason picks a failure reason from the statuses NZBGet gives a failed download.
+func nzbgetReason(xfer *nzbget.History) string {
+	switch {
+	case xfer.UnpackStatus == nzbget.UnpackPASSWORD:
+		return ReasonPassword
+	case xfer.DeleteStatus == nzbget.DeleteHEALTH, strings.HasSuffix(xfer.Status, "/HEALTH"):
+		return ReasonArticles
+	case xfer.UnpackStatus == nzbget.UnpackFAILURE, xfer.UnpackStatus == nzbget.UnpackSPACE,
+		strings.HasSuffix(xfer.Status, "/UNPACK"):
+		return ReasonUnpack
+	case xfer.ParStatus == nzbget.ParFAILURE, strings.HasSuffix(xfer.Status, "/PAR"):
+		return ReasonRepair
+	default:
+		return ReasonOther
+	}
+}
+
+// urlHost returns the host name from an nzb URL. Downloads sent by starr apps usually do not have one.
+func urlHost(nzbURL string) string {
+	if parsed, err := url.Parse(nzbURL); err == nil && parsed.Hostname() != "" {
+		return parsed.Hostname()
+	}
+
+	return unknownIndexer
+}
+
+// grabIndexers returns the indexer for every download ID recently grabbed by a starr app.
+// Download IDs are lower-cased.
+func (c *cmd) grabIndexers(ctx context.Context) (map[string]string, []string) {
+	indexers := make(map[string]string)
+	params := &starr.PageReq{PageSize: grabHistoryMax, SortKey: "date", SortDir: starr.SortDescend}
+	records, errs := c.getHistory(ctx, params, true)
+
+	for _, record := range records {
+		if record.DownloadID != "" && record.Indexer != "" {
+			indexers[strings.ToLower(record.DownloadID)] = record.Indexer
+		}
+	}
+
+	return indexers, errs
+}
@@ -0,0 +1,89 @@
+package downloads //nolint:testpackage // failure reasons are picked by unexported functions.
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golift.io/nzbget"
+)
+
+func TestSabnzbReason(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{message: "Unpacking failed, archive requires a password", want: ReasonPassword},
+		{message: "Aborted, encryption detected", want: ReasonPassword},
+		{message: "Download might fail, only 81.2% of required 100.0% available", want: ReasonOther},
+		{message: "Aborted, cannot be completed - https://sabnzbd.org/not-complete", want: ReasonArticles},
+		{message: "Too many missing articles", want: ReasonArticles},
+		{message: "Unpacking failed, write error or disk is full?", want: ReasonUnpack},
+		{message: "Repair failed, not enough repair blocks (12 short)", want: ReasonRepair},
+		{message: "PAR2 verification failed", want: ReasonRepair},
+		{message: "", want: ReasonOther},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, sabnzbReason(test.message), test.message)
+	}
+}
+
+func TestNzbgetReason(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		xfer *nzbget.History
+		want string
+	}{
+		{name: "password", xfer: &nzbget.History{Status: "FAILURE/UNPACK", UnpackStatus: nzbget.UnpackPASSWORD},
+			want: ReasonPassword},
+		{name: "health delete", xfer: &nzbget.History{Status: "DELETED/HEALTH", DeleteStatus: nzbget.DeleteHEALTH},
+			want: ReasonArticles},
+		{name: "health status", xfer: &nzbget.History{Status: "FAILURE/HEALTH"}, want: ReasonArticles},
+		{name: "unpack", xfer: &nzbget.History{Status: "FAILURE/UNPACK", UnpackStatus: nzbget.UnpackFAILURE},
+			want: ReasonUnpack},
+		{name: "disk space", xfer: &nzbget.History{UnpackStatus: nzbget.UnpackSPACE}, want: ReasonUnpack},
+		{name: "par", xfer: &nzbget.History{Status: "FAILURE/PAR", ParStatus: nzbget.ParFAILURE}, want: ReasonRepair},
+		{name: "bad", xfer: &nzbget.History{Status: "DELETED/BAD", DeleteStatus: nzbget.DeleteBAD}, want: ReasonOther},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, nzbgetReason(test.xfer), test.name)
+	}
+}
+
+func TestNewFailed(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	failed := []*Failed{
+		{Client: SabNZB, Instance: 1, ID: "old", Date: now.Add(-2 * time.Hour)},
+		{Client: SabNZB, Instance: 1, ID: "sent", Date: now.Add(-time.Hour)},
+		{Client: SabNZB, Instance: 1, ID: "new", Date: now},
+		{Client: SabNZB, Instance: 2, ID: "other instance", Date: now.Add(-2 * time.Hour)},
+		{Client: NZBGet, Instance: 1, ID: "other client", Date: now.Add(-2 * time.Hour)},
+	}
+
+	assert.Len(t, newFailed(failed, nil), len(failed), "nothing was sent yet")
+
+	sent := map[string]time.Time{failedKey(failed[1]): failed[1].Date}
+	ids := []string{}
+
+	for _, item := range newFailed(failed, sent) {
+		ids = append(ids, item.ID)
+	}
+
+	assert.Equal(t, []string{"new", "other instance", "other client"}, ids)
+
+	newest := newestFailed(failed, sent)
+	assert.Equal(t, map[string]time.Time{
+		failedKey(failed[0]): now,
+		failedKey(failed[3]): failed[3].Date,
+		failedKey(failed[4]): failed[4].Date,
+	}, newest)
+	assert.Equal(t, failed[1].Date, sent[failedKey(failed[1])], "the sent state is copied, not changed")
+}
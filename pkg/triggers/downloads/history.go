@@ -0,0 +1,145 @@
+package downloads
+
+import (
+	"context"
+	"fmt"
+
+	"golift.io/starr"
+	"golift.io/starr/lidarr"
+	"golift.io/starr/radarr"
+	"golift.io/starr/readarr"
+	"golift.io/starr/sonarr"
+)
+
+/* This file contains the procedures to fetch the history from each starr app. */
+
+// historyRecord is the part of a history record, from any starr app, that this package uses.
+type historyRecord struct {
+	App         starr.App
+	Instance    int
+	DownloadID  string
+	EventType   string
+	SourceTitle string
+	Indexer     string
+	DroppedPath string
+}
+
+// getHistory returns the history records from every enabled starr app. The request is sent to every app.
+// Set grabbed to only return grab events; the apps number their event filters differently.
+//
+//nolint:cyclop,funlen
+func (c *cmd) getHistory(ctx context.Context, params *starr.PageReq, grabbed bool) ([]*historyRecord, []string) {
+	var (
+		records = []*historyRecord{}
+		errs    = []string{}
+	)
+
+	fail := func(app starr.App, instance int, err error) {
+		errs = append(errs, fmt.Sprintf("%s: getting history from instance %d: %v", app.Lower(), instance, err))
+	}
+	req := func(filter starr.Filtering) *starr.PageReq {
+		req := *params
+		if grabbed {
+			req.Filter = filter
+		}
+
+		return &req
+	}
+
+	for idx, app := range c.Apps.Lidarr {
+		if !app.Enabled() {
+			continue
+		}
+
+		history, err := app.GetHistoryPageContext(ctx, req(lidarr.FilterGrabbed))
+		if err != nil {
+			fail(starr.Lidarr, idx+1, err)
+			continue
+		}
+
+		for _, record := range history.Records {
+			records = append(records, &historyRecord{
+				App: starr.Lidarr, Instance: idx + 1, DownloadID: record.DownloadID, EventType: record.EventType,
+				SourceTitle: record.SourceTitle, Indexer: record.Data.Indexer, DroppedPath: record.Data.DroppedPath,
+			})
+		}
+	}
+
+	for idx, app := range c.Apps.Radarr {
+		if !app.Enabled() {
+			continue
+		}
+
+		history, err := app.GetHistoryPageContext(ctx, req(radarr.FilterGrabbed))
+		if err != nil {
+			fail(starr.Radarr, idx+1, err)
+			continue
+		}
+
+		for _, record := range history.Records {
+			records = append(records, &historyRecord{
+				App: starr.Radarr, Instance: idx + 1, DownloadID: record.DownloadID, EventType: record.EventType,
+				SourceTitle: record.SourceTitle, Indexer: record.Data.Indexer, DroppedPath: record.Data.DroppedPath,
+			})
+		}
+	}
+
+	for idx, app := range c.Apps.Readarr {
+		if !app.Enabled() {
+			continue
+		}
+
+		history, err := app.GetHistoryPageContext(ctx, req(readarr.FilterGrabbed))
+		if err != nil {
+			fail(starr.Readarr, idx+1, err)
+			continue
+		}
+
+		for _, record := range history.Records {
+			records = append(records, &historyRecord{
+				App: starr.Readarr, Instance: idx + 1, DownloadID: record.DownloadID, EventType: record.EventType,
+				SourceTitle: record.SourceTitle, Indexer: record.Data.Indexer, DroppedPath: record.Data.DroppedPath,
+			})
+		}
+	}
+
+	for idx, app := range c.Apps.Sonarr {
+		if !app.Enabled() {
+			continue
+		}
+
+		history, err := app.GetHistoryPageContext(ctx, req(sonarr.FilterGrabbed))
+		if err != nil {
+			fail(starr.Sonarr, idx+1, err)
+			continue
+		}
+
+		for _, record := range history.Records {
+			records = append(records, &historyRecord{
+				App: starr.Sonarr, Instance: idx + 1, DownloadID: record.DownloadID, EventType: record.EventType,
+				SourceTitle: record.SourceTitle, Indexer: record.Data.Indexer, DroppedPath: record.Data.DroppedPath,
+			})
+		}
+	}
+
+	for idx, app := range c.Apps.Whisparr {
+		if !app.Enabled() {
+			continue
+		}
+
+		history, err := app.GetHistoryPageContext(ctx, req(radarr.FilterGrabbed))
+		if err != nil {
+			fail(starr.Whisparr, idx+1, err)
+			continue
+		}
+
+		for _, record := range history.Records {
+			records = append(records, &historyRecord{
+				App: starr.Whisparr, Instance: idx + 1, DownloadID: record.DownloadID, EventType: record.EventType,
+				SourceTitle: record.SourceTitle, Indexer: record.Data.Indexer, DroppedPath: record.Data.DroppedPath,
+			})
+		}
+	}
+
+	return records, errs
+}
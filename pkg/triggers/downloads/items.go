@@ -0,0 +1,123 @@
+package downloads
+
+import (
+	"context"
+	"time"
+
+	"golift.io/starr"
+)
+
+// Item is a download (torrent or nzb) in a download client.
+// The fields are the same for every client, so they can be compared side by side.
+type Item struct {
+	Client   string    `json:"client"`
+	Instance int       `json:"instance"`
+	Name     string    `json:"name"`
+	ID       string    `json:"id"` // hash, nzo_id or NZBID.
+	Title    string    `json:"title"`
+	State    string    `json:"state"`
+	Category string    `json:"category"`
+	SavePath string    `json:"savePath"`
+	Size     int64     `json:"size"`
+	Progress float64   `json:"progress"` // percent, 0-100.
+	Ratio    float64   `json:"ratio"`
+	Seeders  int64     `json:"seeders"`
+	Seeding  int64     `json:"seeding"` // seconds spent seeding.
+	Tracker  string    `json:"tracker,omitempty"`
+	Added    time.Time `json:"added"`
+	// History is true for a usenet download that already left the client's queue.
+	History bool `json:"history,omitempty"`
+}
+
+// clientList is the combined output from every enabled download client.
+type clientList struct {
+	items  []*Item
+	errors []string
+	// clients counts the enabled clients for each protocol.
+	clients map[starr.Protocol]int
+	// failed is true for a protocol if any of its clients returned an error.
+	failed map[starr.Protocol]bool
+}
+
+func newClientList() *clientList {
+	return &clientList{
+		clients: make(map[starr.Protocol]int),
+		failed:  make(map[starr.Protocol]bool),
+	}
+}
+
+func (l *clientList) add(protocol starr.Protocol, items []*Item, err error) {
+	l.clients[protocol]++
+
+	if err != nil {
+		l.failed[protocol] = true
+		l.errors = append(l.errors, err.Error())
+
+		return
+	}
+
+	l.items = append(l.items, items...)
+}
+
+// complete returns true if a protocol has clients and all of them returned their downloads.
+func (l *clientList) complete(protocol starr.Protocol) bool {
+	return l.clients[protocol] > 0 && !l.failed[protocol]
+}
+
+// getItems returns the downloads in every enabled download client.
+func (c *cmd) getItems(ctx context.Context) *clientList {
+	list := newClientList()
+
+	c.addTorrents(ctx, list)
+	c.addUsenet(ctx, list)
+
+	return list
+}
+
+// addTorrents adds the transfers in every enabled torrent client to a list.
+func (c *cmd) addTorrents(ctx context.Context, list *clientList) {
+	for idx, app := range c.Apps.Qbit {
+		if app.Enabled() {
+			items, err := qbitItems(ctx, idx+1, app)
+			list.add(starr.ProtocolTorrent, items, wrap(Qbit, err))
+		}
+	}
+
+	for idx, app := range c.Apps.Deluge {
+		if app.Enabled() {
+			items, err := delugeItems(ctx, idx+1, app)
+			list.add(starr.ProtocolTorrent, items, wrap(Deluge, err))
+		}
+	}
+
+	for idx, app := range c.Apps.Transmission {
+		if app.Enabled() {
+			items, err := xmissionItems(ctx, idx+1, app)
+			list.add(starr.ProtocolTorrent, items, wrap(Xmission, err))
+		}
+	}
+
+	for idx, app := range c.Apps.Rtorrent {
+		if app.Enabled() {
+			items, err := rtorrentItems(idx+1, app)
+			list.add(starr.ProtocolTorrent, items, wrap(Rtorrent, err))
+		}
+	}
+}
+
+// addUsenet adds the queue and history from every enabled usenet client to a list.
+func (c *cmd) addUsenet(ctx context.Context, list *clientList) {
+	for idx, app := range c.Apps.SabNZB {
+		if app.Enabled() {
+			items, err := sabnzbItems(ctx, idx+1, app)
+			list.add(starr.ProtocolUsenet, items, wrap(SabNZB, err))
+		}
+	}
+
+	for idx, app := range c.Apps.NZBGet {
+		if app.Enabled() {
+			items, err := nzbgetItems(ctx, idx+1, app)
+			list.add(starr.ProtocolUsenet, items, wrap(NZBGet, err))
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package downloads
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	transmissionrpc "github.com/hekmon/transmissionrpc/v3"
+)
+
+/* This file contains the procedures to change the settings of a download, or of a download client. */
+
+// These are the priorities accepted by setPriority. The queue moves only work in clients with a queue,
+// and the priority levels only work in clients that prioritize downloads.
+const (
+	PrioTop    = "top"
+	PrioBottom = "bottom"
+	PrioUp     = "up"
+	PrioDown   = "down"
+	PrioLow    = "low"
+	PrioNormal = "normal"
+	PrioHigh   = "high"
+	PrioForce  = "force"
+)
+
+// setCategory changes the category (or label) of a download.
+func (c *cmd) setCategory(ctx context.Context, item *Item, category string) error {
+	switch idx := item.Instance - 1; {
+	case item.Client == Qbit && idx >= 0 && idx < len(c.Apps.Qbit):
+		return qbitPost(ctx, c.Apps.Qbit[idx], "api/v2/torrents/setCategory",
+			url.Values{"hashes": {item.ID}, "category": {category}})
+	case item.Client == Deluge && idx >= 0 && idx < len(c.Apps.Deluge):
+		// This requires the Label plugin.
+		_, err := c.Apps.Deluge[idx].Get(ctx, "label.set_torrent", []interface{}{item.ID, category})
+		return err //nolint:wrapcheck
+	case item.Client == Xmission && idx >= 0 && idx < len(c.Apps.Transmission):
+		torrentID, err := xmissionID(ctx, c.Apps.Transmission[idx], item.ID)
+		if err != nil {
+			return err
+		}
+
+		return c.Apps.Transmission[idx].TorrentSet(ctx, transmissionrpc.TorrentSetPayload{ //nolint:wrapcheck
+			IDs:    []int64{torrentID},
+			Labels: []string{category},
+		})
+	case item.Client == Rtorrent && idx >= 0 && idx < len(c.Apps.Rtorrent):
+		_, err := c.Apps.Rtorrent[idx].Call("d.custom1.set", item.ID, category)
+		return err //nolint:wrapcheck
+	case item.Client == SabNZB && idx >= 0 && idx < len(c.Apps.SabNZB):
+		return sabnzbCall(ctx, c.Apps.SabNZB[idx],
+			url.Values{"mode": {"change_cat"}, "value": {item.ID}, "value2": {category}})
+	case item.Client == NZBGet && idx >= 0 && idx < len(c.Apps.NZBGet):
+		return nzbgetEdit(ctx, c.Apps.NZBGet[idx], "GroupSetCategory", category, item.ID)
+	default:
+		return fmt.Errorf("%w: %s %d", ErrUnknownClient, item.Client, item.Instance)
+	}
+}
+
+// setPriority moves a download in its client's queue, or changes its priority level.
+func (c *cmd) setPriority(ctx context.Context, item *Item, priority string) error { //nolint:cyclop
+	switch idx := item.Instance - 1; {
+	case item.Client == Qbit && idx >= 0 && idx < len(c.Apps.Qbit):
+		path, ok := map[string]string{
+			PrioTop: "topPrio", PrioBottom: "bottomPrio", PrioUp: "increasePrio", PrioDown: "decreasePrio",
+		}[priority]
+		if !ok {
+			break
+		}
+
+		return qbitPost(ctx, c.Apps.Qbit[idx], "api/v2/torrents/"+path, url.Values{"hashes": {item.ID}})
+	case item.Client == Deluge && idx >= 0 && idx < len(c.Apps.Deluge):
+		method, ok := map[string]string{
+			PrioTop: "core.queue_top", PrioBottom: "core.queue_bottom", PrioUp: "core.queue_up", PrioDown: "core.queue_down",
+		}[priority]
+		if !ok {
+			break
+		}
+
+		_, err := c.Apps.Deluge[idx].Get(ctx, method, []interface{}{[]string{item.ID}})
+
+		return err //nolint:wrapcheck
+	case item.Client == Xmission && idx >= 0 && idx < len(c.Apps.Transmission):
+		return xmissionPriority(ctx, c.Apps.Transmission[idx], item.ID, priority)
+	case item.Client == Rtorrent && idx >= 0 && idx < len(c.Apps.Rtorrent):
+		// rTorrent priorities: 0 off, 1 low, 2 normal, 3 high.
+		level, ok := map[string]int{PrioLow: 1, PrioNormal: 2, PrioHigh: 3}[priority] //nolint:mnd
+		if !ok {
+			break
+		}
+
+		_, err := c.Apps.Rtorrent[idx].Call("d.priority.set", item.ID, level)
+
+		return err //nolint:wrapcheck
+	case item.Client == SabNZB && idx >= 0 && idx < len(c.Apps.SabNZB):
+		level, ok := map[string]int{PrioLow: -1, PrioNormal: 0, PrioHigh: 1, PrioForce: 2}[priority] //nolint:mnd
+		if !ok {
+			break
+		}
+
+		return sabnzbCall(ctx, c.Apps.SabNZB[idx], url.Values{
+			"mode": {"queue"}, "name": {"priority"}, "value": {item.ID}, "value2": {strconv.Itoa(level)},
+		})
+	case item.Client == NZBGet && idx >= 0 && idx < len(c.Apps.NZBGet):
+		return nzbgetPriority(ctx, c.Apps.NZBGet[idx], item.ID, priority)
+	default:
+		return fmt.Errorf("%w: %s %d", ErrUnknownClient, item.Client, item.Instance)
+	}
+
+	return fmt.Errorf("%w: priority %s", ErrUnsupported, priority)
+}
+
+// xmissionPriority moves a torrent in the Transmission queue, or sets its bandwidth priority.
+func xmissionPriority(ctx context.Context, app *apps.XmissionConfig, hash, priority string) error {
+	torrentID, err := xmissionID(ctx, app, hash)
+	if err != nil {
+		return err
+	}
+
+	ids := []int64{torrentID}
+
+	switch priority {
+	case PrioTop:
+		err = app.QueueMoveTop(ctx, ids)
+	case PrioBottom:
+		err = app.QueueMoveBottom(ctx, ids)
+	case PrioUp:
+		err = app.QueueMoveUp(ctx, ids)
+	case PrioDown:
+		err = app.QueueMoveDown(ctx, ids)
+	case PrioLow, PrioNormal, PrioHigh:
+		level := map[string]int64{PrioLow: -1, PrioNormal: 0, PrioHigh: 1}[priority]
+		err = app.TorrentSet(ctx, transmissionrpc.TorrentSetPayload{IDs: ids, BandwidthPriority: &level})
+	default:
+		return fmt.Errorf("%w: priority %s", ErrUnsupported, priority)
+	}
+
+	return err //nolint:wrapcheck
+}
+
+// nzbgetPriority moves a download in the NZBGet queue, or sets its priority.
+func nzbgetPriority(ctx context.Context, app *apps.NZBGetConfig, nzbID, priority string) error {
+	switch priority {
+	case PrioTop:
+		return nzbgetEdit(ctx, app, "GroupMoveTop", "", nzbID)
+	case PrioBottom:
+		return nzbgetEdit(ctx, app, "GroupMoveBottom", "", nzbID)
+	case PrioUp:
+		return nzbgetEdit(ctx, app, "GroupMoveOffset", "-1", nzbID)
+	case PrioDown:
+		return nzbgetEdit(ctx, app, "GroupMoveOffset", "1", nzbID)
+	}
+
+	// NZBGet priorities: -50 low, 0 normal, 50 high, 900 force.
+	level, ok := map[string]int{PrioLow: -50, PrioNormal: 0, PrioHigh: 50, PrioForce: 900}[priority] //nolint:mnd
+	if !ok {
+		return fmt.Errorf("%w: priority %s", ErrUnsupported, priority)
+	}
+
+	return nzbgetEdit(ctx, app, "GroupSetPriority", strconv.Itoa(level), nzbID)
+}
+
+// setLimits changes the global speed limits in a download client. Limits are in KiB/s, and 0 is unlimited.
+// A nil limit is not changed. The usenet clients do not have an upload limit.
+func (c *cmd) setLimits(ctx context.Context, client string, instance int, down, up *int64) error { //nolint:cyclop,funlen
+	switch idx := instance - 1; {
+	case client == Qbit && idx >= 0 && idx < len(c.Apps.Qbit):
+		if down != nil {
+			err := qbitPost(ctx, c.Apps.Qbit[idx], "api/v2/transfer/setDownloadLimit",
+				url.Values{"limit": {strconv.FormatInt(*down*mnd.Kilobyte, mnd.Base10)}})
+			if err != nil {
+				return err
+			}
+		}
+
+		if up != nil {
+			return qbitPost(ctx, c.Apps.Qbit[idx], "api/v2/transfer/setUploadLimit",
+				url.Values{"limit": {strconv.FormatInt(*up*mnd.Kilobyte, mnd.Base10)}})
+		}
+
+		return nil
+	case client == Deluge && idx >= 0 && idx < len(c.Apps.Deluge):
+		// Deluge uses -1 for unlimited.
+		limits := map[string]float64{}
+		for key, limit := range map[string]*int64{"max_download_speed": down, "max_upload_speed": up} {
+			if limit != nil && *limit > 0 {
+				limits[key] = float64(*limit)
+			} else if limit != nil {
+				limits[key] = -1
+			}
+		}
+
+		_, err := c.Apps.Deluge[idx].Get(ctx, "core.set_config", []interface{}{limits})
+
+		return err //nolint:wrapcheck
+	case client == Xmission && idx >= 0 && idx < len(c.Apps.Transmission):
+		args := transmissionrpc.SessionArguments{}
+		if down != nil {
+			enabled := *down > 0
+			args.SpeedLimitDown, args.SpeedLimitDownEnabled = down, &enabled
+		}
+
+		if up != nil {
+			enabled := *up > 0
+			args.SpeedLimitUp, args.SpeedLimitUpEnabled = up, &enabled
+		}
+
+		return c.Apps.Transmission[idx].SessionArgumentsSet(ctx, args) //nolint:wrapcheck
+	case client == Rtorrent && idx >= 0 && idx < len(c.Apps.Rtorrent):
+		for method, limit := range map[string]*int64{
+			"throttle.global_down.max_rate.set": down, "throttle.global_up.max_rate.set": up,
+		} {
+			if limit == nil {
+				continue
+			}
+
+			if _, err := c.Apps.Rtorrent[idx].Call(method, "", *limit*mnd.Kilobyte); err != nil {
+				return fmt.Errorf("%s: %w", method, err)
+			}
+		}
+
+		return nil
+	case client == SabNZB && idx >= 0 && idx < len(c.Apps.SabNZB):
+		if up != nil {
+			return fmt.Errorf("%w: upload limit", ErrUnsupported)
+		} else if down == nil {
+			return nil
+		}
+
+		return sabnzbCall(ctx, c.Apps.SabNZB[idx], url.Values{
+			"mode": {"config"}, "name": {"speedlimit"}, "value": {strconv.FormatInt(*down, mnd.Base10) + "K"},
+		})
+	case client == NZBGet && idx >= 0 && idx < len(c.Apps.NZBGet):
+		if up != nil {
+			return fmt.Errorf("%w: upload limit", ErrUnsupported)
+		} else if down == nil {
+			return nil
+		}
+
+		success, err := c.Apps.NZBGet[idx].RateContext(ctx, *down)
+
+		return nzbgetResult("rate", success, err)
+	default:
+		return fmt.Errorf("%w: %s %d", ErrUnknownClient, client, instance)
+	}
+}
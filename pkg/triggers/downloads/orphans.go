@@ -0,0 +1,507 @@
+package downloads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/data"
+	"github.com/Notifiarr/notifiarr/pkg/website"
+	"golift.io/cnfg"
+	"golift.io/starr"
+)
+
+/* This file contains the procedures to find files in the download folders that nothing is tracking. */
+
+const TrigOrphans common.TriggerName = "Scanning download folders for orphaned files."
+
+const (
+	// How often to scan the download folders.
+	orphanDuration = 6 * time.Hour
+	// Files and folders changed more recently than this are still being written, and are not orphans yet.
+	orphanMinAge = time.Hour
+	// orphanGrace is the grace period used when one is not configured.
+	orphanGrace = 24 * time.Hour
+	// orphansKey is where the time each orphan was first seen is saved in the data cache.
+	orphansKey = "downloadsOrphans"
+	// orphanMaxDepth is how deep the scan goes into save path and category folders.
+	orphanMaxDepth = 8
+)
+
+// These are the actions an orphan scan can take.
+const (
+	OrphanQuarantine = "quarantine"
+	OrphanDelete     = "delete"
+)
+
+// ErrInvalidOrphanScan is returned when the orphan scan has no paths or a bad action.
+var ErrInvalidOrphanScan = errors.New("invalid orphan scan")
+
+// OrphanScan finds the files and folders in Paths that no download client is tracking, and no starr
+// queue or history item references. Action may be empty to only report them, quarantine or delete.
+// Orphans are quarantined or deleted after they have been orphans for the Grace period.
+// The delete action only runs when AllowDelete is also true.
+type OrphanScan struct {
+	Paths       []string      `json:"paths"       toml:"paths"        xml:"path"         yaml:"paths"`
+	Action      string        `json:"action"      toml:"action"       xml:"action"       yaml:"action"`
+	AllowDelete bool          `json:"allowDelete" toml:"allow_delete" xml:"allow_delete" yaml:"allowDelete"`
+	Quarantine  string        `json:"quarantine"  toml:"quarantine"   xml:"quarantine"   yaml:"quarantine"`
+	Grace       cnfg.Duration `json:"grace"       toml:"grace"        xml:"grace"        yaml:"grace"`
+}
+
+// Orphan is a file or folder in a download folder that nothing is tracking.
+// FirstSeen is when the scan first found it, and is not set in the API response.
+type Orphan struct {
+	Path      string    `json:"path"`
+	Dir       bool      `json:"dir"`
+	Size      int64     `json:"size"`
+	Files     int       `json:"files"`
+	Modified  time.Time `json:"modified"`
+	FirstSeen time.Time `json:"firstSeen"`
+	Action    string    `json:"action,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// OrphanReport is the orphaned files in every download folder, largest first.
+// Orphans are only quarantined or deleted when Errors is empty.
+type OrphanReport struct {
+	Total   int       `json:"total"`
+	Size    int64     `json:"size"`
+	Action  string    `json:"action,omitempty"`
+	Orphans []*Orphan `json:"orphans"`
+	Errors  []string  `json:"errors,omitempty"`
+}
+
+// Validate makes sure the orphan scan has paths and a usable action.
+func (o *OrphanScan) Validate() error {
+	if len(o.Paths) == 0 {
+		return fmt.Errorf("%w: no paths", ErrInvalidOrphanScan)
+	}
+
+	switch o.Action {
+	case "":
+	case OrphanDelete:
+		if !o.AllowDelete {
+			return fmt.Errorf("%w: delete action requires allow_delete", ErrInvalidOrphanScan)
+		}
+	case OrphanQuarantine:
+		if o.Quarantine == "" {
+			return fmt.Errorf("%w: quarantine action requires a quarantine path", ErrInvalidOrphanScan)
+		}
+	default:
+		return fmt.Errorf("%w: unknown action: %s", ErrInvalidOrphanScan, o.Action)
+	}
+
+	if o.Grace.Duration <= 0 {
+		o.Grace.Duration = orphanGrace
+	}
+
+	return nil
+}
+
+func (c *cmd) setupOrphans() {
+	if c.orphans == nil {
+		return
+	}
+
+	if err := c.orphans.Validate(); err != nil {
+		c.Errorf("Orphan scan disabled: %v", err)
+		c.orphans = nil
+
+		return
+	}
+
+	c.Printf("==> Orphaned Download Scan Started, paths: %s, action: %s, grace: %s, interval: %s",
+		strings.Join(c.orphans.Paths, ", "), c.orphans.Action, c.orphans.Grace, orphanDuration)
+
+	c.Add(&common.Action{
+		Name: TrigOrphans,
+		Fn:   c.sendOrphans,
+		C:    make(chan *common.ActionInput, 1),
+		D:    cnfg.Duration{Duration: orphanDuration},
+	})
+}
+
+// SendOrphans scans the download folders, acts on the orphans, and sends them to the website.
+func (a *Action) SendOrphans(event website.EventType) {
+	a.cmd.Exec(&common.ActionInput{Type: event}, TrigOrphans)
+}
+
+// OrphansHandler returns the orphaned files in the download folders.
+// @Description  Returns the files and folders in the configured download folders that no download client is tracking,
+// @Description  and no starr queue or history item references. Nothing is quarantined or deleted by this request.
+// @Summary      Retrieve orphaned download files.
+// @Tags         Downloads
+// @Produce      json
+// @Success      200  {object} apps.Respond.apiResponse{message=OrphanReport} "orphaned files"
+// @Failure      404  {object} string "orphan scan is not configured"
+// @Router       /api/downloads/orphans [get]
+// @Security     ApiKeyAuth
+func (a *Action) OrphansHandler(req *http.Request) (int, interface{}) {
+	if a.cmd.orphans == nil {
+		return http.StatusNotFound, "orphan scan is not configured"
+	}
+
+	return http.StatusOK, a.cmd.orphanReport(req.Context(), nil)
+}
+
+func (c *cmd) sendOrphans(ctx context.Context, input *common.ActionInput) {
+	report := c.orphanReport(ctx, getOrphansSeen())
+	for _, err := range report.Errors {
+		c.Errorf("[%s requested] Orphaned downloads: %s", input.Type, err)
+	}
+
+	c.actOrphans(input, report)
+
+	if report.Total == 0 {
+		c.Debugf("[%s requested] No orphaned downloads found.", input.Type)
+
+		if c.noOrphans {
+			return
+		}
+
+		c.noOrphans = true
+	} else {
+		c.noOrphans = false
+	}
+
+	c.SendData(&website.Request{
+		Route:      website.OrphanRoute,
+		Event:      input.Type,
+		LogPayload: true,
+		ErrorsOnly: !c.DebugEnabled(),
+		LogMsg:     fmt.Sprintf("Orphaned Downloads: %d, size: %d", report.Total, report.Size),
+		Payload:    report,
+	})
+}
+
+// getOrphansSeen returns the time each orphan was first seen, saved in the data cache so it survives reloads.
+func getOrphansSeen() map[string]time.Time {
+	if item := data.Get(orphansKey); item != nil && item.Data != nil {
+		if seen, ok := item.Data.(map[string]time.Time); ok {
+			return seen
+		}
+	}
+
+	seen := make(map[string]time.Time)
+	data.Save(orphansKey, seen)
+
+	return seen
+}
+
+// orphanReport scans every download folder for orphans. When seen is not nil, it's updated
+// with the orphans found, and the files that are no longer orphans are removed from it.
+func (c *cmd) orphanReport(ctx context.Context, seen map[string]time.Time) *OrphanReport {
+	report := &OrphanReport{Action: c.orphans.Action, Orphans: []*Orphan{}, Errors: []string{}}
+	track, errs := c.trackedDownloads(ctx)
+	report.Errors = append(report.Errors, errs...)
+	found := make(map[string]bool)
+
+	for _, dir := range c.orphans.Paths {
+		orphans, err := c.scanOrphans(dir, "", track, 0)
+		if err != nil {
+			report.Errors = append(report.Errors, err.Error())
+		}
+
+		for _, orphan := range orphans {
+			found[orphan.Path] = true
+
+			if seen != nil {
+				if seen[orphan.Path].IsZero() {
+					seen[orphan.Path] = time.Now()
+				}
+
+				orphan.FirstSeen = seen[orphan.Path]
+			}
+
+			report.Size += orphan.Size
+			report.Orphans = append(report.Orphans, orphan)
+		}
+	}
+
+	for path := range seen {
+		if !found[path] {
+			delete(seen, path)
+		}
+	}
+
+	report.Total = len(report.Orphans)
+	sort.Slice(report.Orphans, func(i, j int) bool {
+		return report.Orphans[i].Size > report.Orphans[j].Size
+	})
+
+	return report
+}
+
+// scanOrphans returns the files and folders in one download folder that are not tracked.
+// rel is the path below the configured download folder, and is empty for the download folder.
+// Save path and category folders are never orphans; the scan looks inside them instead.
+// Hidden files, empty folders, and anything changed in the last hour are skipped.
+func (c *cmd) scanOrphans(dir, rel string, track *trackList, depth int) ([]*Orphan, error) {
+	entries, err := os.ReadDir(filepath.Join(dir, rel))
+	if err != nil {
+		return nil, fmt.Errorf("reading download folder: %w", err)
+	}
+
+	orphans := []*Orphan{}
+
+	for _, entry := range entries {
+		entryRel := filepath.Join(rel, entry.Name())
+		path := filepath.Join(dir, entryRel)
+
+		if strings.HasPrefix(entry.Name(), ".") || track.tracked(entryRel, entry) ||
+			filepath.Clean(path) == filepath.Clean(c.orphans.Quarantine) {
+			continue
+		}
+
+		if entry.IsDir() && track.folder(entryRel) {
+			if depth >= orphanMaxDepth {
+				continue
+			}
+
+			inner, err := c.scanOrphans(dir, entryRel, track, depth+1)
+			if err != nil {
+				return nil, err
+			}
+
+			orphans = append(orphans, inner...)
+
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil || time.Since(info.ModTime()) < orphanMinAge {
+			continue
+		}
+
+		orphan := &Orphan{Path: path, Dir: entry.IsDir(), Modified: info.ModTime().Round(time.Second)}
+		if orphan.Size, orphan.Files = pathSize(path); orphan.Files > 0 {
+			orphans = append(orphans, orphan)
+		}
+	}
+
+	return orphans, nil
+}
+
+// trackList has every download a client or starr app knows about. Paths are lower-cased, use slashes,
+// and are matched on their last elements, because a download client in a container may see a
+// different path than this app does.
+type trackList struct {
+	// names are download titles and file names.
+	names map[string]bool
+	// content are the full paths of downloads.
+	content []string
+	// folders are client save paths and category names. These are never orphans.
+	folders    []string
+	categories map[string]bool
+}
+
+func newTrackList() *trackList {
+	return &trackList{names: make(map[string]bool), categories: make(map[string]bool)}
+}
+
+// cleanPath lower-cases a path, and makes it start with a slash and use slashes.
+func cleanPath(path string) string {
+	path = strings.Trim(strings.ToLower(strings.ReplaceAll(strings.TrimSpace(path), "\\", "/")), "/")
+	if path == "" {
+		return ""
+	}
+
+	return "/" + path
+}
+
+func (t *trackList) addName(name string) {
+	if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+		t.names[name] = true
+	}
+}
+
+// addItem adds a client download. Its content is in a folder named after it inside its save path.
+func (t *trackList) addItem(item *Item) {
+	t.addName(item.Title)
+
+	if category := strings.ToLower(strings.TrimSpace(item.Category)); category != "" {
+		t.categories[category] = true
+	}
+
+	if savePath := cleanPath(item.SavePath); savePath != "" {
+		t.folders = append(t.folders, savePath)
+		t.content = append(t.content, savePath+cleanPath(item.Title))
+	}
+}
+
+// addContent adds the full path of a downloaded file, like a starr app's dropped path.
+func (t *trackList) addContent(path string) {
+	if path = cleanPath(path); path != "" {
+		t.content = append(t.content, path)
+		t.addName(path[strings.LastIndex(path, "/")+1:])
+	}
+}
+
+// tracked returns true if a folder entry is a tracked download. rel is the entry's path below the download folder.
+// Files are also checked without their extension, because a starr app titles a download without one.
+func (t *trackList) tracked(rel string, entry fs.DirEntry) bool {
+	name := strings.ToLower(entry.Name())
+	if t.names[name] || (!entry.IsDir() && t.names[strings.TrimSuffix(name, filepath.Ext(name))]) {
+		return true
+	}
+
+	rel = cleanPath(rel)
+	for _, path := range t.content {
+		if strings.HasSuffix(path, rel) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// folder returns true if a folder is a save path, a category, or holds a tracked download.
+// Folders are searched for orphans instead of being orphans.
+func (t *trackList) folder(rel string) bool {
+	if t.categories[strings.ToLower(filepath.Base(rel))] {
+		return true
+	}
+
+	rel = cleanPath(rel) + "/"
+	for _, path := range t.folders {
+		if strings.Contains(path+"/", rel) {
+			return true
+		}
+	}
+
+	for _, path := range t.content {
+		if strings.Contains(path, rel) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pathSize returns the size of a file, or the size of every file in a folder, and how many files it has.
+func pathSize(path string) (int64, int) {
+	var (
+		size  int64
+		files int
+	)
+
+	_ = filepath.WalkDir(path, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return nil //nolint:nilerr // Count what can be read.
+		}
+
+		if info, err := entry.Info(); err == nil {
+			size += info.Size()
+			files++
+		}
+
+		return nil
+	})
+
+	return size, files
+}
+
+// actOrphans quarantines or deletes the orphans that outlived the grace period.
+// Nothing is done if any download client, starr app or download folder returned an error,
+// because a download the scan could not see may look like an orphan.
+func (c *cmd) actOrphans(input *common.ActionInput, report *OrphanReport) {
+	if c.orphans.Action == "" || len(report.Errors) > 0 {
+		return
+	}
+
+	for _, orphan := range report.Orphans {
+		if time.Since(orphan.FirstSeen) < c.orphans.Grace.Duration {
+			continue
+		}
+
+		var err error
+
+		switch c.orphans.Action {
+		case OrphanQuarantine:
+			orphan.Action = "quarantined"
+			err = quarantine(orphan.Path, c.orphans.Quarantine)
+		case OrphanDelete:
+			if !c.orphans.AllowDelete {
+				continue
+			}
+
+			orphan.Action = "deleted"
+			err = os.RemoveAll(orphan.Path)
+		}
+
+		if err != nil {
+			orphan.Error = err.Error()
+			c.Errorf("[%s requested] Orphaned downloads: %s %s: %v", input.Type, c.orphans.Action, orphan.Path, err)
+		} else {
+			c.Printf("[%s requested] Orphaned downloads: %s %s (%d files, %d bytes, orphaned since %s)", input.Type,
+				orphan.Action, orphan.Path, orphan.Files, orphan.Size, orphan.FirstSeen.Format(time.RFC3339))
+		}
+	}
+}
+
+// quarantine moves a file or folder into the quarantine folder. The quarantine folder must be on the same
+// file system. A time stamp is added to the name if the quarantine folder already has one with that name.
+func quarantine(path, dir string) error {
+	if err := os.MkdirAll(dir, mnd.Mode0750); err != nil {
+		return fmt.Errorf("creating quarantine folder: %w", err)
+	}
+
+	dest := filepath.Join(dir, filepath.Base(path))
+	if _, err := os.Stat(dest); err == nil {
+		dest += "." + time.Now().Format("20060102150405")
+	}
+
+	if err := os.Rename(path, dest); err != nil {
+		return fmt.Errorf("moving to quarantine: %w", err)
+	}
+
+	return nil
+}
+
+// trackedDownloads returns every download a client or starr app knows about.
+func (c *cmd) trackedDownloads(ctx context.Context) (*trackList, []string) {
+	track := newTrackList()
+
+	list := c.getItems(ctx)
+	for _, item := range list.items {
+		track.addItem(item)
+	}
+
+	records, _, errs := c.getRecords(ctx)
+	for _, record := range records {
+		track.addName(record.Title)
+	}
+
+	errs = append(list.errors, errs...)
+	errs = append(errs, c.historyNames(ctx, track)...)
+
+	// Without a download client, every download looks like an orphan.
+	if len(list.clients) == 0 {
+		errs = append(errs, "no download clients are enabled")
+	}
+
+	return track, errs
+}
+
+// historyNames adds the release titles and download paths from recent starr history to the tracked downloads.
+func (c *cmd) historyNames(ctx context.Context, track *trackList) []string {
+	params := &starr.PageReq{PageSize: grabHistoryMax, SortKey: "date", SortDir: starr.SortDescend}
+	records, errs := c.getHistory(ctx, params, false)
+
+	for _, record := range records {
+		track.addName(record.SourceTitle)
+		track.addContent(record.DroppedPath)
+	}
+
+	return errs
+}
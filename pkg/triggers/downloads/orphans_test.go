@@ -0,0 +1,202 @@
+package downloads //nolint:testpackage // the orphan matcher is not exported.
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/logs"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golift.io/cnfg"
+)
+
+func TestOrphanScanValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		scan  OrphanScan
+		valid bool
+	}{
+		{name: "report", scan: OrphanScan{Paths: []string{"/downloads"}}, valid: true},
+		{name: "quarantine", scan: OrphanScan{Paths: []string{"/d"}, Action: OrphanQuarantine, Quarantine: "/q"}, valid: true},
+		{name: "delete", scan: OrphanScan{Paths: []string{"/d"}, Action: OrphanDelete, AllowDelete: true}, valid: true},
+		{name: "delete not allowed", scan: OrphanScan{Paths: []string{"/d"}, Action: OrphanDelete}, valid: false},
+		{name: "no quarantine", scan: OrphanScan{Paths: []string{"/d"}, Action: OrphanQuarantine}, valid: false},
+		{name: "no paths", scan: OrphanScan{}, valid: false},
+		{name: "bad action", scan: OrphanScan{Paths: []string{"/d"}, Action: "nope"}, valid: false},
+	}
+
+	for _, test := range tests {
+		err := test.scan.Validate()
+		if !test.valid {
+			require.ErrorIs(t, err, ErrInvalidOrphanScan, test.name)
+			continue
+		}
+
+		require.NoError(t, err, test.name)
+		assert.Positive(t, test.scan.Grace.Duration, test.name)
+	}
+}
+
+func TestCleanPath(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"":                    "",
+		"/":                   "",
+		"/Data/TV/":           "/data/tv",
+		`C:\Downloads\Movies`: "/c:/downloads/movies",
+		" relative/path ":     "/relative/path",
+	}
+
+	for path, want := range tests {
+		assert.Equal(t, want, cleanPath(path), path)
+	}
+}
+
+// orphanTree makes a download folder, and returns it with the orphans a scan must find.
+func orphanTree(t *testing.T) (string, []string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	old := time.Now().Add(-2 * time.Hour)
+	files := []string{
+		"tv/Show.S01/episode.mkv",         // tracked by title, inside a category folder.
+		"tv/Old.Show/episode.mkv",         // orphan inside a save path.
+		"movies/Gone.Movie.mkv",           // orphan inside a category folder with nothing in its save path.
+		"Tracked.File.mkv",                // tracked by title without the extension.
+		"Loose.Orphan.mkv",                // orphan.
+		".hidden",                         // hidden.
+		"complete/Usenet.Movie/movie.mkv", // tracked usenet history.
+		"complete/Other.Usenet/movie.mkv", // orphan next to a usenet save path.
+		"imported/Release/file.mkv",       // tracked starr dropped path.
+		"imported/Release/file.nfo",       // orphan next to a dropped path.
+		"quarantine/Old.Orphan.mkv",       // the quarantine folder is skipped.
+	}
+
+	for _, file := range files {
+		path := filepath.Join(dir, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+		require.NoError(t, os.WriteFile(path, []byte("data"), 0o600))
+	}
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Fresh.mkv"), []byte("data"), 0o600), "too new to be an orphan")
+	require.NoError(t, filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.Name() == "Fresh.mkv" {
+			return err
+		}
+
+		return os.Chtimes(path, old, old)
+	}))
+
+	return dir, []string{
+		"Loose.Orphan.mkv",
+		"complete/Other.Usenet",
+		"imported/Release/file.nfo",
+		"movies/Gone.Movie.mkv",
+		"tv/Old.Show",
+	}
+}
+
+func testTrackList() *trackList {
+	track := newTrackList()
+	// Download clients in containers see different paths than this app.
+	track.addItem(&Item{Title: "Show.S01", Category: "tv", SavePath: "/data/torrents/tv"})
+	track.addItem(&Item{Title: "Queued.Movie", Category: "Movies"})
+	track.addItem(&Item{Title: "Tracked.File", SavePath: "/data/torrents"})
+	track.addItem(&Item{Title: "Usenet.Movie", SavePath: `D:\usenet\complete\Usenet.Movie`, History: true})
+	track.addName("Some Release Title")
+	track.addContent("/downloads/imported/Release/file.mkv")
+
+	return track
+}
+
+func TestScanOrphans(t *testing.T) {
+	t.Parallel()
+
+	dir, want := orphanTree(t)
+	cmd := &cmd{orphans: &OrphanScan{Paths: []string{dir}, Quarantine: filepath.Join(dir, "quarantine")}}
+
+	orphans, err := cmd.scanOrphans(dir, "", testTrackList(), 0)
+	require.NoError(t, err)
+
+	found := []string{}
+
+	for _, orphan := range orphans {
+		rel, err := filepath.Rel(dir, orphan.Path)
+		require.NoError(t, err)
+
+		found = append(found, filepath.ToSlash(rel))
+		assert.Positive(t, orphan.Files, rel)
+	}
+
+	sort.Strings(found)
+	assert.Equal(t, want, found)
+
+	_, err = cmd.scanOrphans(filepath.Join(dir, "missing"), "", testTrackList(), 0)
+	require.Error(t, err)
+}
+
+func TestTrackListFolder(t *testing.T) {
+	t.Parallel()
+
+	track := testTrackList()
+	tests := []struct {
+		rel  string
+		want bool
+	}{
+		{rel: "tv", want: true},
+		{rel: "torrents/tv", want: true},
+		{rel: "movies", want: true},
+		{rel: "complete", want: true},
+		{rel: "complete/Usenet.Movie", want: true},
+		{rel: "imported/Release", want: true},
+		{rel: "tv/Show.S01", want: false},
+		{rel: "tvshows", want: false},
+		{rel: "books", want: false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, track.folder(test.rel), test.rel)
+	}
+}
+
+func TestActOrphans(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "orphan")
+	require.NoError(t, os.WriteFile(path, []byte("data"), 0o600))
+
+	input := &common.ActionInput{Type: "test"}
+	report := &OrphanReport{Orphans: []*Orphan{{Path: path, FirstSeen: time.Now().Add(-48 * time.Hour)}}}
+	cmd := &cmd{
+		Config:  &common.Config{Logger: logs.New()},
+		orphans: &OrphanScan{Action: OrphanDelete, Grace: cnfg.Duration{Duration: 24 * time.Hour}},
+	}
+
+	cmd.actOrphans(input, report)
+	assert.FileExists(t, path, "delete must be allowed")
+
+	report.Errors = []string{"a client failed"}
+	cmd.orphans.AllowDelete = true
+	cmd.actOrphans(input, report)
+	assert.FileExists(t, path, "nothing is deleted when a client failed")
+
+	report.Errors = nil
+	report.Orphans[0].FirstSeen = time.Now()
+	cmd.actOrphans(input, report)
+	assert.FileExists(t, path, "nothing is deleted during the grace period")
+
+	report.Orphans[0].FirstSeen = time.Now().Add(-48 * time.Hour)
+	cmd.orphans.Action, cmd.orphans.Quarantine = OrphanQuarantine, filepath.Join(dir, "quarantine")
+	cmd.actOrphans(input, report)
+	assert.NoFileExists(t, path)
+	assert.FileExists(t, filepath.Join(dir, "quarantine", "orphan"))
+	assert.Equal(t, "quarantined", report.Orphans[0].Action)
+}
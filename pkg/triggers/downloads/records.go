@@ -0,0 +1,143 @@
+package downloads
+
+import (
+	"context"
+	"fmt"
+
+	"golift.io/starr"
+)
+
+/* This file contains the procedures to fetch the queue from each starr app. */
+
+// getRecords returns the queue records from every enabled starr app, and how many apps were queried.
+// Records without a download ID (delayed items) are not returned.
+//
+//nolint:cyclop,funlen
+func (c *cmd) getRecords(ctx context.Context) ([]*Record, int, []string) {
+	var (
+		records = []*Record{}
+		errs    = []string{}
+		apps    int
+	)
+
+	add := func(record *Record) {
+		if record.DownloadID != "" {
+			records = append(records, record)
+		}
+	}
+	fail := func(app starr.App, instance int, err error) {
+		errs = append(errs, fmt.Sprintf("%s: getting queue from instance %d: %v", app.Lower(), instance, err))
+	}
+
+	for idx, app := range c.Apps.Lidarr {
+		if !app.Enabled() {
+			continue
+		}
+
+		apps++
+
+		queue, err := app.GetQueueContext(ctx, queueItemsMax, 1)
+		if err != nil {
+			fail(starr.Lidarr, idx+1, err)
+			continue
+		}
+
+		for _, item := range queue.Records {
+			add(&Record{
+				App: starr.Lidarr.Lower(), Instance: idx + 1, Name: app.Name, QueueID: item.ID,
+				DownloadID: item.DownloadID, Title: item.Title, Status: item.Status,
+				Client: item.DownloadClient, Protocol: string(item.Protocol),
+			})
+		}
+	}
+
+	for idx, app := range c.Apps.Radarr {
+		if !app.Enabled() {
+			continue
+		}
+
+		apps++
+
+		queue, err := app.GetQueueContext(ctx, queueItemsMax, 1)
+		if err != nil {
+			fail(starr.Radarr, idx+1, err)
+			continue
+		}
+
+		for _, item := range queue.Records {
+			add(&Record{
+				App: starr.Radarr.Lower(), Instance: idx + 1, Name: app.Name, QueueID: item.ID,
+				DownloadID: item.DownloadID, Title: item.Title, Status: item.Status, State: item.TrackedDownloadState,
+				Client: item.DownloadClient, Protocol: string(item.Protocol),
+			})
+		}
+	}
+
+	for idx, app := range c.Apps.Readarr {
+		if !app.Enabled() {
+			continue
+		}
+
+		apps++
+
+		queue, err := app.GetQueueContext(ctx, queueItemsMax, 1)
+		if err != nil {
+			fail(starr.Readarr, idx+1, err)
+			continue
+		}
+
+		for _, item := range queue.Records {
+			add(&Record{
+				App: starr.Readarr.Lower(), Instance: idx + 1, Name: app.Name, QueueID: item.ID,
+				DownloadID: item.DownloadID, Title: item.Title, Status: item.Status, State: item.TrackedDownloadState,
+				Client: item.DownloadClient, Protocol: string(item.Protocol),
+			})
+		}
+	}
+
+	for idx, app := range c.Apps.Sonarr {
+		if !app.Enabled() {
+			continue
+		}
+
+		apps++
+
+		queue, err := app.GetQueueContext(ctx, queueItemsMax, 1)
+		if err != nil {
+			fail(starr.Sonarr, idx+1, err)
+			continue
+		}
+
+		for _, item := range queue.Records {
+			add(&Record{
+				App: starr.Sonarr.Lower(), Instance: idx + 1, Name: app.Name, QueueID: item.ID,
+				DownloadID: item.DownloadID, Title: item.Title, Status: item.Status, State: item.TrackedDownloadState,
+				Client: item.DownloadClient, Protocol: string(item.Protocol),
+			})
+		}
+	}
+
+	for idx, app := range c.Apps.Whisparr {
+		if !app.Enabled() {
+			continue
+		}
+
+		apps++
+
+		queue, err := app.GetQueueContext(ctx, queueItemsMax, 1)
+		if err != nil {
+			fail(starr.Whisparr, idx+1, err)
+			continue
+		}
+
+		for _, item := range queue.Records {
+			add(&Record{
+				App: starr.Whisparr.Lower(), Instance: idx + 1, Name: app.Name, QueueID: item.ID,
+				DownloadID: item.DownloadID, Title: item.Title, Status: item.Status, State: item.TrackedDownloadState,
+				Client: item.DownloadClient, Protocol: string(item.Protocol),
+			})
+		}
+	}
+
+	return records, apps, errs
+}
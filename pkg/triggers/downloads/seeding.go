@@ -0,0 +1,358 @@
+package downloads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
+	"golift.io/cnfg"
+	"golift.io/starr"
+)
+
+/* This file contains the procedures to pause or remove torrents that reached their seeding goals. */
+
+// SeedAuditFileName is the name of the file every seeding action is written to.
+// It lives in the same folder as the config file. Each line is one json encoded SeedAudit.
+const SeedAuditFileName = "seedaudit.jsonl"
+
+const (
+	// The audit log is rotated when it's bigger than this.
+	auditMaxSize = 10 * mnd.Megabyte
+	// How many rotated audit logs are kept.
+	auditBackups = 2
+)
+
+const TrigSeedRules common.TriggerName = "Checking finished torrents against seeding rules."
+
+// How often to check torrents against the seeding rules.
+const seedDuration = 10 * time.Minute
+
+// Errors returned by this file.
+var (
+	// ErrInvalidSeedRule is returned when a seed rule has a bad client, action or no goals.
+	ErrInvalidSeedRule  = errors.New("invalid seed rule")
+	ErrStarrUnavailable = errors.New("a starr app queue is unavailable")
+	ErrStillQueued      = errors.New("still in a starr app queue")
+	ErrNoImport         = errors.New("no starr app imported this download")
+	ErrNoHistory        = errors.New("a starr app history is unavailable")
+)
+
+// These are the actions a seed rule may take.
+const (
+	SeedPause  = "pause"
+	SeedRemove = "remove"
+)
+
+// Seeding results written to the audit log.
+const (
+	resultPaused      = "paused"
+	resultRemoved     = "removed"
+	resultDryRun      = "dry run"
+	resultNotImported = "not imported"
+	resultError       = "error"
+)
+
+// SeedRule pauses or removes finished torrents once they reach their seeding goals.
+// A torrent matches when it is in Client, has Category, and its tracker contains Tracker.
+// Empty values match everything.
+// Goals are met when the torrent reached MinRatio and MinSeedTime, or when it has seeded for MaxSeedTime.
+// DeleteData removes the torrent's files too, but only after a starr app imported the download.
+type SeedRule struct {
+	Name        string        `json:"name"        toml:"name"          xml:"name"          yaml:"name"`
+	Client      string        `json:"client"      toml:"client"        xml:"client"        yaml:"client"`
+	Category    string        `json:"category"    toml:"category"      xml:"category"      yaml:"category"`
+	Tracker     string        `json:"tracker"     toml:"tracker"       xml:"tracker"       yaml:"tracker"`
+	MinRatio    float64       `json:"minRatio"    toml:"min_ratio"     xml:"min_ratio"     yaml:"minRatio"`
+	MinSeedTime cnfg.Duration `json:"minSeedTime" toml:"min_seed_time" xml:"min_seed_time" yaml:"minSeedTime"`
+	MaxSeedTime cnfg.Duration `json:"maxSeedTime" toml:"max_seed_time" xml:"max_seed_time" yaml:"maxSeedTime"`
+	Action      string        `json:"action"      toml:"action"        xml:"action"        yaml:"action"`
+	DeleteData  bool          `json:"deleteData"  toml:"delete_data"   xml:"delete_data"   yaml:"deleteData"`
+	DryRun      bool          `json:"dryRun"      toml:"dry_run"       xml:"dry_run"       yaml:"dryRun"`
+}
+
+// SeedAudit is one seeding action written to the audit log.
+type SeedAudit struct {
+	Time       time.Time `json:"time"`
+	Rule       string    `json:"rule"`
+	Client     string    `json:"client"`
+	Instance   int       `json:"instance"`
+	Name       string    `json:"name"`
+	Hash       string    `json:"hash"`
+	Title      string    `json:"title"`
+	Category   string    `json:"category"`
+	Tracker    string    `json:"tracker"`
+	Ratio      float64   `json:"ratio"`
+	Seeding    string    `json:"seeding"`
+	Reason     string    `json:"reason"`
+	Action     string    `json:"action"`
+	DeleteData bool      `json:"deleteData"`
+	Result     string    `json:"result"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Validate checks a seed rule for errors, and sets defaults.
+func (r *SeedRule) Validate() error {
+	switch r.Client = strings.ToLower(r.Client); r.Client {
+	case "", Qbit, Deluge, Xmission, Rtorrent:
+	default:
+		return fmt.Errorf("%w: rule '%s' has unknown client: %s", ErrInvalidSeedRule, r.Name, r.Client)
+	}
+
+	switch r.Action = strings.ToLower(r.Action); r.Action {
+	case SeedPause, SeedRemove:
+	default:
+		return fmt.Errorf("%w: rule '%s' has unknown action: %s", ErrInvalidSeedRule, r.Name, r.Action)
+	}
+
+	if r.MinRatio <= 0 && r.MinSeedTime.Duration <= 0 && r.MaxSeedTime.Duration <= 0 {
+		return fmt.Errorf("%w: rule '%s' requires a min ratio, min seed time or max seed time", ErrInvalidSeedRule, r.Name)
+	}
+
+	if r.DeleteData && r.Action != SeedRemove {
+		return fmt.Errorf("%w: rule '%s' can only delete data when the action is remove", ErrInvalidSeedRule, r.Name)
+	}
+
+	if r.DeleteData && r.Client == Rtorrent {
+		return fmt.Errorf("%w: rule '%s': rTorrent cannot delete data", ErrInvalidSeedRule, r.Name)
+	}
+
+	if r.Name == "" {
+		r.Name = strings.Join(strings.Fields(r.Client+" "+r.Category+" "+r.Tracker+" "+r.Action), " ")
+	}
+
+	return nil
+}
+
+// matches returns true if the rule applies to a torrent. It does not check the goals.
+func (r *SeedRule) matches(item *Item) bool {
+	return (r.Client == "" || r.Client == item.Client) &&
+		(r.Category == "" || strings.EqualFold(r.Category, item.Category)) &&
+		(r.Tracker == "" || strings.Contains(strings.ToLower(item.Tracker), strings.ToLower(r.Tracker)))
+}
+
+// satisfied returns the reason a torrent reached the rule's goals, or an empty string if it has not.
+func (r *SeedRule) satisfied(item *Item) string {
+	seeding := time.Duration(item.Seeding) * time.Second
+
+	if (r.MinRatio > 0 || r.MinSeedTime.Duration > 0) && item.Ratio >= r.MinRatio && seeding >= r.MinSeedTime.Duration {
+		return fmt.Sprintf("ratio %.2f >= %.2f and seeding %s >= %s",
+			item.Ratio, r.MinRatio, seeding.Round(time.Minute), r.MinSeedTime)
+	}
+
+	if r.MaxSeedTime.Duration > 0 && seeding >= r.MaxSeedTime.Duration {
+		return fmt.Sprintf("seeding %s >= max %s", seeding.Round(time.Minute), r.MaxSeedTime)
+	}
+
+	return ""
+}
+
+func (c *cmd) setupSeeding() {
+	if len(c.seedRules) == 0 {
+		return
+	}
+
+	for _, rule := range c.seedRules {
+		if err := rule.Validate(); err != nil {
+			c.Errorf("Seeding rules disabled: %v", err)
+			c.seedRules = nil
+
+			return
+		}
+	}
+
+	c.Printf("==> Torrent Seeding Rules Started, rules: %d, interval: %s, audit log: %s",
+		len(c.seedRules), seedDuration, c.audit.Path)
+
+	c.Add(&common.Action{
+		Name: TrigSeedRules,
+		Fn:   c.checkSeeding,
+		C:    make(chan *common.ActionInput, 1),
+		D:    cnfg.Duration{Duration: seedDuration},
+	})
+}
+
+// checkSeeding runs on an interval and checks every finished torrent against the seeding rules.
+func (c *cmd) checkSeeding(ctx context.Context, input *common.ActionInput) {
+	list := newClientList()
+	c.addTorrents(ctx, list)
+
+	for _, err := range list.errors {
+		c.Errorf("[%s requested] Checking seeding rules: %s", input.Type, err)
+	}
+
+	var (
+		seen    = make(map[string]string)
+		entries = []*SeedAudit{}
+		queued  map[string]bool // fetched once, the first time a rule wants to delete data.
+		fetched bool
+	)
+
+	for _, item := range list.items {
+		rule, reason := c.findSeedRule(item)
+		if rule == nil || (rule.Action == SeedPause && item.paused()) {
+			continue
+		}
+
+		if rule.DeleteData && !fetched {
+			queued, fetched = c.queuedIDs(ctx), true
+		}
+
+		entry := c.seed(ctx, rule, item, queued)
+		entry.Reason = reason
+		key := fmt.Sprint(item.Client, item.Instance, strings.ToLower(item.ID))
+		seen[key] = entry.Result
+
+		// Repeated dry runs, errors and items waiting on an import are only logged once per torrent.
+		if c.seeded[key] == entry.Result {
+			continue
+		}
+
+		entries = append(entries, entry)
+		c.Printf("[%s requested] Seeding Rules: %s (%d) '%s' matched rule '%s' (%s): %s %s",
+			input.Type, item.Client, item.Instance, item.Title, rule.Name, reason, entry.Result, entry.Error)
+	}
+
+	c.seeded = seen
+
+	if err := c.writeAudit(entries); err != nil {
+		c.Errorf("[%s requested] Writing seeding audit log: %v", input.Type, err)
+	}
+}
+
+// seed takes a rule's action on a torrent, and returns the audit entry for it.
+func (c *cmd) seed(ctx context.Context, rule *SeedRule, item *Item, queued map[string]bool) *SeedAudit {
+	entry := &SeedAudit{
+		Time:       time.Now(),
+		Rule:       rule.Name,
+		Client:     item.Client,
+		Instance:   item.Instance,
+		Name:       item.Name,
+		Hash:       item.ID,
+		Title:      item.Title,
+		Category:   item.Category,
+		Tracker:    item.Tracker,
+		Ratio:      item.Ratio,
+		Seeding:    (time.Duration(item.Seeding) * time.Second).String(),
+		Action:     rule.Action,
+		DeleteData: rule.DeleteData,
+	}
+
+	var err error
+
+	if rule.DeleteData {
+		if err = c.imported(ctx, item.ID, queued); err != nil {
+			entry.Result = resultNotImported
+			entry.Error = err.Error()
+
+			return entry
+		}
+	}
+
+	switch {
+	case rule.DryRun:
+		entry.Result = resultDryRun
+	case rule.Action == SeedPause:
+		entry.Result = resultPaused
+		err = c.pause(ctx, item)
+	default:
+		entry.Result = resultRemoved
+		err = c.remove(ctx, item, rule.DeleteData)
+	}
+
+	if err != nil {
+		entry.Result = resultError
+		entry.Error = err.Error()
+	}
+
+	return entry
+}
+
+// findSeedRule returns the first rule that applies to a finished torrent, if the torrent reached its goals.
+// The first rule that matches the torrent is the only rule checked, so put specific rules first.
+func (c *cmd) findSeedRule(item *Item) (*SeedRule, string) {
+	if item.Progress < 100 { //nolint:mnd
+		return nil, ""
+	}
+
+	for _, rule := range c.seedRules {
+		if !rule.matches(item) {
+			continue
+		}
+
+		if reason := rule.satisfied(item); reason != "" {
+			return rule, reason
+		}
+
+		return nil, ""
+	}
+
+	return nil, ""
+}
+
+// paused returns true if the download client already paused or stopped the download.
+func (i *Item) paused() bool {
+	state := strings.ToLower(i.State)
+	return strings.Contains(state, "paused") || strings.Contains(state, "stopped")
+}
+
+// queuedIDs returns the (lower-cased) download IDs in every starr app queue.
+// Returns nil if any app failed, and then imported() refuses to delete data.
+func (c *cmd) queuedIDs(ctx context.Context) map[string]bool {
+	records, _, errs := c.getRecords(ctx)
+	if len(errs) > 0 {
+		c.Errorf("Checking seeding rules: %s", strings.Join(errs, "; "))
+		return nil
+	}
+
+	queued := make(map[string]bool)
+	for _, record := range records {
+		queued[strings.ToLower(record.DownloadID)] = true
+	}
+
+	return queued
+}
+
+// imported returns nil if a starr app imported a download, and no starr app still has it in the queue.
+func (c *cmd) imported(ctx context.Context, hash string, queued map[string]bool) error {
+	if queued == nil {
+		return ErrStarrUnavailable
+	} else if queued[strings.ToLower(hash)] {
+		return ErrStillQueued
+	}
+
+	params := &starr.PageReq{PageSize: 50, Values: url.Values{"downloadId": {strings.ToUpper(hash)}}} //nolint:mnd
+
+	records, errs := c.getHistory(ctx, params, false)
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %s", ErrNoHistory, strings.Join(errs, "; "))
+	}
+
+	return hasImport(records, hash)
+}
+
+// hasImport returns nil if any history record is an import of the download.
+// The download ID is checked, because an app that ignores the downloadId filter returns every download.
+// Every app names its import event differently: downloadFolderImported, trackFileImported, etc.
+func hasImport(records []*historyRecord, hash string) error {
+	for _, record := range records {
+		if strings.EqualFold(record.DownloadID, hash) && strings.Contains(strings.ToLower(record.EventType), "imported") {
+			return nil
+		}
+	}
+
+	return ErrNoImport
+}
+
+// writeAudit appends seeding actions to the audit log.
+func (c *cmd) writeAudit(entries []*SeedAudit) error {
+	if _, err := c.audit.Append(entries...); err != nil {
+		return fmt.Errorf("audit log: %w", err)
+	}
+
+	return nil
+}
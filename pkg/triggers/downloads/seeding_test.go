@@ -0,0 +1,130 @@
+package downloads //nolint:testpackage // seeding rules are checked by unexported methods.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golift.io/cnfg"
+)
+
+func TestSeedRuleSatisfied(t *testing.T) {
+	t.Parallel()
+
+	hours := func(count int) cnfg.Duration { return cnfg.Duration{Duration: time.Duration(count) * time.Hour} }
+	seeding := func(count int64) int64 { return count * int64(time.Hour/time.Second) }
+
+	tests := []struct {
+		name string
+		rule SeedRule
+		item Item
+		want bool
+	}{
+		{name: "ratio met", rule: SeedRule{MinRatio: 1}, item: Item{Ratio: 1.5}, want: true},
+		{name: "ratio not met", rule: SeedRule{MinRatio: 2}, item: Item{Ratio: 1.5}, want: false},
+		{name: "ratio and time met", rule: SeedRule{MinRatio: 1, MinSeedTime: hours(24)},
+			item: Item{Ratio: 1, Seeding: seeding(24)}, want: true},
+		{name: "ratio met, time not met", rule: SeedRule{MinRatio: 1, MinSeedTime: hours(24)},
+			item: Item{Ratio: 3, Seeding: seeding(2)}, want: false},
+		{name: "time met, ratio not met", rule: SeedRule{MinRatio: 1, MinSeedTime: hours(24)},
+			item: Item{Ratio: 0.5, Seeding: seeding(48)}, want: false},
+		{name: "min time only", rule: SeedRule{MinSeedTime: hours(24)}, item: Item{Seeding: seeding(25)}, want: true},
+		{name: "max time", rule: SeedRule{MinRatio: 5, MaxSeedTime: hours(72)},
+			item: Item{Ratio: 0.1, Seeding: seeding(72)}, want: true},
+		{name: "max time not met", rule: SeedRule{MaxSeedTime: hours(72)}, item: Item{Seeding: seeding(71)}, want: false},
+		{name: "no goals", rule: SeedRule{}, item: Item{Ratio: 10, Seeding: seeding(1000)}, want: false},
+	}
+
+	for _, test := range tests {
+		reason := test.rule.satisfied(&test.item)
+		assert.Equal(t, test.want, reason != "", "%s: %s", test.name, reason)
+	}
+}
+
+func TestSeedRuleMatches(t *testing.T) {
+	t.Parallel()
+
+	item := &Item{Client: Qbit, Category: "Movies", Tracker: "https://tracker.Example.com/announce"}
+
+	tests := []struct {
+		rule SeedRule
+		want bool
+	}{
+		{rule: SeedRule{}, want: true},
+		{rule: SeedRule{Client: Qbit, Category: "movies", Tracker: "example.com"}, want: true},
+		{rule: SeedRule{Client: Deluge}, want: false},
+		{rule: SeedRule{Category: "tv"}, want: false},
+		{rule: SeedRule{Tracker: "other.org"}, want: false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, test.rule.matches(item), "%+v", test.rule)
+	}
+}
+
+func TestSeedRuleValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		rule  SeedRule
+		valid bool
+	}{
+		{name: "pause", rule: SeedRule{Action: "Pause", MinRatio: 1}, valid: true},
+		{name: "delete", rule: SeedRule{Client: "QBittorrent", Action: SeedRemove, MinRatio: 1, DeleteData: true},
+			valid: true},
+		{name: "no goals", rule: SeedRule{Action: SeedPause}, valid: false},
+		{name: "bad action", rule: SeedRule{Action: "nope", MinRatio: 1}, valid: false},
+		{name: "bad client", rule: SeedRule{Client: "nope", Action: SeedPause, MinRatio: 1}, valid: false},
+		{name: "pause delete", rule: SeedRule{Action: SeedPause, MinRatio: 1, DeleteData: true}, valid: false},
+		{name: "rtorrent delete", rule: SeedRule{Client: Rtorrent, Action: SeedRemove, MinRatio: 1, DeleteData: true},
+			valid: false},
+	}
+
+	for _, test := range tests {
+		err := test.rule.Validate()
+		if test.valid {
+			require.NoError(t, err, test.name)
+			assert.NotEmpty(t, test.rule.Name, test.name)
+		} else {
+			require.ErrorIs(t, err, ErrInvalidSeedRule, test.name)
+		}
+	}
+}
+
+func TestHasImport(t *testing.T) {
+	t.Parallel()
+
+	const hash = "ABCDEF0123"
+
+	tests := []struct {
+		name    string
+		records []*historyRecord
+		want    error
+	}{
+		{name: "imported", records: []*historyRecord{
+			{DownloadID: "abcdef0123", EventType: "grabbed"},
+			{DownloadID: "abcdef0123", EventType: "downloadFolderImported"},
+		}, want: nil},
+		{name: "other download imported", records: []*historyRecord{
+			{DownloadID: "abcdef0123", EventType: "grabbed"},
+			{DownloadID: "9999999999", EventType: "downloadFolderImported"},
+		}, want: ErrNoImport},
+		{name: "no download id", records: []*historyRecord{{EventType: "trackFileImported"}}, want: ErrNoImport},
+		{name: "no history", records: nil, want: ErrNoImport},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, hasImport(test.records, hash), test.name)
+	}
+}
+
+func TestImported(t *testing.T) {
+	t.Parallel()
+
+	cmd := &cmd{}
+	require.ErrorIs(t, cmd.imported(context.Background(), "abc", nil), ErrStarrUnavailable)
+	require.ErrorIs(t, cmd.imported(context.Background(), "ABC", map[string]bool{"abc": true}), ErrStillQueued)
+}
@@ -0,0 +1,77 @@
+// Package downloads talks to every configured download client,
+// and joins their downloads with the starr app queues.
+package downloads
+
+import (
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common/jsonl"
+)
+
+// Action contains the exported methods for this package.
+type Action struct {
+	cmd *cmd
+}
+
+type cmd struct {
+	*common.Config
+	// seedRules pause or remove finished torrents, and every action is written to the audit log.
+	seedRules []*SeedRule
+	audit     *jsonl.File[SeedAudit]
+	seeded    map[string]string
+	// failed turns on the failed downloads report. failedSent is the newest failure sent from each client.
+	failed     *FailedConfig
+	sentFile   string
+	failedSent map[string]time.Time
+	// diskGuard pauses downloads when a volume fills up. Its state is saved to diskFile.
+	diskGuard *DiskGuard
+	diskFile  string
+	// orphans finds files in the download folders that nothing is tracking.
+	// We set noOrphans to true after we send 1 "no orphans" report.
+	orphans   *OrphanScan
+	noOrphans bool
+}
+
+// These are the download clients this package knows about. Used as Item.Client.
+const (
+	Qbit     = "qbittorrent"
+	Deluge   = "deluge"
+	Xmission = "transmission"
+	Rtorrent = "rtorrent"
+	SabNZB   = "sabnzbd"
+	NZBGet   = "nzbget"
+)
+
+// New configures the library.
+func New(
+	config *common.Config,
+	seedRules []*SeedRule,
+	auditFile string,
+	failed *FailedConfig,
+	sentFile string,
+	diskGuard *DiskGuard,
+	diskFile string,
+	orphans *OrphanScan,
+) *Action {
+	return &Action{cmd: &cmd{
+		Config:     config,
+		seedRules:  seedRules,
+		audit:      &jsonl.File[SeedAudit]{Path: auditFile, MaxSize: auditMaxSize, Backups: auditBackups},
+		seeded:     make(map[string]string),
+		failed:     failed,
+		sentFile:   sentFile,
+		failedSent: make(map[string]time.Time),
+		diskGuard:  diskGuard,
+		diskFile:   diskFile,
+		orphans:    orphans,
+	}}
+}
+
+// Create initializes the library.
+func (a *Action) Create() {
+	a.cmd.setupSeeding()
+	a.cmd.setupFailed()
+	a.cmd.setupDiskGuard()
+	a.cmd.setupOrphans()
+}
@@ -0,0 +1,224 @@
+package downloads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"github.com/mrobinsn/go-rtorrent/rtorrent"
+)
+
+var ErrInvalidResponse = errors.New("invalid response")
+
+/* This file contains the procedures to list the transfers in each torrent client. */
+
+func qbitItems(ctx context.Context, instance int, app *apps.QbitConfig) ([]*Item, error) {
+	xfers, err := app.GetXfersContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting transfers from instance %d: %w", instance, err)
+	}
+
+	items := make([]*Item, len(xfers))
+	for idx, xfer := range xfers {
+		items[idx] = &Item{
+			Client:   Qbit,
+			Instance: instance,
+			Name:     app.Name,
+			ID:       xfer.Hash,
+			Title:    xfer.Name,
+			State:    xfer.State,
+			Category: xfer.Category,
+			SavePath: xfer.SavePath,
+			Size:     xfer.Size,
+			Progress: xfer.Progress * 100, //nolint:mnd
+			Ratio:    xfer.Ratio,
+			Seeders:  int64(xfer.NumComplete),
+			Seeding:  xfer.SeedingTime,
+			Tracker:  xfer.Tracker,
+			Added:    time.Unix(int64(xfer.AddedOn), 0),
+		}
+	}
+
+	return items, nil
+}
+
+func delugeItems(ctx context.Context, instance int, app *apps.DelugeConfig) ([]*Item, error) {
+	xfers, err := app.GetXfersCompatContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting transfers from instance %d: %w", instance, err)
+	}
+
+	items := make([]*Item, 0, len(xfers))
+	for hash, xfer := range xfers {
+		path := xfer.DownloadLocation
+		if path == "" {
+			path = xfer.SavePath
+		}
+
+		items = append(items, &Item{
+			Client:   Deluge,
+			Instance: instance,
+			Name:     app.Name,
+			ID:       hash,
+			Title:    xfer.Name,
+			State:    xfer.State,
+			Category: xfer.Label,
+			SavePath: path,
+			Size:     int64(xfer.TotalSize),
+			Progress: xfer.Progress,
+			Ratio:    xfer.Ratio,
+			Seeders:  int64(xfer.TotalSeeds),
+			Seeding:  int64(xfer.SeedingTime),
+			Tracker:  xfer.TrackerHost,
+			Added:    time.Unix(int64(xfer.TimeAdded), 0),
+		})
+	}
+
+	return items, nil
+}
+
+func xmissionItems(ctx context.Context, instance int, app *apps.XmissionConfig) ([]*Item, error) {
+	xfers, err := app.TorrentGetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting transfers from instance %d: %w", instance, err)
+	}
+
+	items := make([]*Item, len(xfers))
+	for idx, xfer := range xfers {
+		item := &Item{
+			Client:   Xmission,
+			Instance: instance,
+			Name:     app.Name,
+			ID:       deref(xfer.HashString),
+			Title:    deref(xfer.Name),
+			Category: strings.Join(xfer.Labels, ", "),
+			SavePath: deref(xfer.DownloadDir),
+			Progress: deref(xfer.PercentDone) * 100, //nolint:mnd
+			Ratio:    deref(xfer.UploadRatio),
+			Added:    deref(xfer.AddedDate),
+		}
+
+		if xfer.Status != nil {
+			item.State = xfer.Status.String()
+		}
+
+		if xfer.TimeSeeding != nil {
+			item.Seeding = int64(xfer.TimeSeeding.Seconds())
+		}
+
+		if xfer.TotalSize != nil {
+			item.Size = int64(xfer.TotalSize.Byte())
+		}
+
+		// The seeder count is the highest count reported by any tracker.
+		for _, tracker := range xfer.TrackerStats {
+			if item.Tracker == "" {
+				item.Tracker = tracker.Host
+			}
+
+			item.Seeders = max(item.Seeders, tracker.SeederCount)
+		}
+
+		items[idx] = item
+	}
+
+	return items, nil
+}
+
+func rtorrentItems(instance int, app *apps.RtorrentConfig) ([]*Item, error) {
+	results, err := app.Call("d.multicall2", "", string(rtorrent.ViewMain),
+		rtorrent.DHash.Query(),
+		rtorrent.DName.Query(),
+		rtorrent.DLabel.Query(),
+		rtorrent.DDirectory.Query(),
+		rtorrent.DSizeInBytes.Query(),
+		rtorrent.DCompletedBytes.Query(),
+		rtorrent.DRatio.Query(),
+		rtorrent.DIsActive.Query(),
+		"d.message=",
+		"d.peers_complete=",
+		rtorrent.DStartedTime.Query(),
+		rtorrent.DFinishedTime.Query(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: d.multicall2 XMLRPC call failed: instance %d", err, instance)
+	}
+
+	items := []*Item{}
+
+	resInt, _ := results.([]interface{})
+	for _, outerResult := range resInt {
+		resOut, _ := outerResult.([]interface{})
+		for _, innerResult := range resOut {
+			data, ok := innerResult.([]interface{})
+			if !ok || len(data) != 12 { //nolint:mnd // 12 fields requested above.
+				return nil, fmt.Errorf("%w: data returned from query is unusable: instance %d", ErrInvalidResponse, instance)
+			}
+
+			items = append(items, rtorrentItem(instance, app.Name, data))
+		}
+	}
+
+	return items, nil
+}
+
+// rtorrentItem turns one row of d.multicall2 output into an Item.
+// The ratio from rTorrent is multiplied by 1000, and seeding time is counted from when the download finished.
+func rtorrentItem(instance int, name string, data []interface{}) *Item {
+	str := func(idx int) string {
+		val, _ := data[idx].(string)
+		return val
+	}
+	num := func(idx int) int64 {
+		val, _ := data[idx].(int)
+		return int64(val)
+	}
+
+	item := &Item{
+		Client:   Rtorrent,
+		Instance: instance,
+		Name:     name,
+		ID:       str(0),
+		Title:    str(1),
+		Category: str(2),
+		SavePath: str(3),
+		Size:     num(4),
+		Ratio:    float64(num(6)) / 1000, //nolint:mnd
+		Seeders:  num(9),
+		Added:    time.Unix(num(10), 0),
+	}
+
+	if finished := num(11); finished > 0 {
+		item.Seeding = time.Now().Unix() - finished
+	}
+
+	if item.Size > 0 {
+		item.Progress = float64(num(5)) / float64(item.Size) * 100 //nolint:mnd
+	}
+
+	switch {
+	case str(8) != "":
+		item.State = "error: " + str(8)
+	case num(7) == 0:
+		item.State = "stopped"
+	case num(5) >= item.Size:
+		item.State = "seeding"
+	default:
+		item.State = "downloading"
+	}
+
+	return item
+}
+
+// deref returns the value a pointer points to, or the zero value for a nil pointer.
+func deref[T any](ptr *T) T {
+	if ptr == nil {
+		var zero T
+		return zero
+	}
+
+	return *ptr
+}
@@ -0,0 +1,116 @@
+package downloads
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+)
+
+/* This file contains the procedures to list the queue and history in each usenet client. */
+
+func sabnzbItems(ctx context.Context, instance int, app *apps.SabNZBConfig) ([]*Item, error) {
+	queue, err := app.GetQueue(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting queue from instance %d: %w", instance, err)
+	}
+
+	hist, err := app.GetHistory(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting history from instance %d: %w", instance, err)
+	}
+
+	items := make([]*Item, 0, len(queue.Slots)+len(hist.Slots))
+	for _, xfer := range queue.Slots {
+		items = append(items, &Item{
+			Client:   SabNZB,
+			Instance: instance,
+			Name:     app.Name,
+			ID:       xfer.NzoID,
+			Title:    xfer.Filename,
+			State:    xfer.Status,
+			Category: xfer.Cat,
+			Size:     xfer.Size.Bytes,
+			Progress: float64(xfer.Percentage),
+		})
+	}
+
+	for _, xfer := range hist.Slots {
+		items = append(items, &Item{
+			Client:   SabNZB,
+			Instance: instance,
+			Name:     app.Name,
+			ID:       xfer.NzoID,
+			Title:    xfer.Name,
+			State:    xfer.Status,
+			Category: xfer.Category,
+			SavePath: xfer.Storage,
+			Size:     xfer.Bytes,
+			Progress: 100, //nolint:mnd
+			Added:    time.Unix(xfer.Completed, 0),
+			History:  true,
+		})
+	}
+
+	return items, nil
+}
+
+func nzbgetItems(ctx context.Context, instance int, app *apps.NZBGetConfig) ([]*Item, error) {
+	queue, err := app.ListGroupsContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting file groups (queue) from instance %d: %w", instance, err)
+	}
+
+	hist, err := app.HistoryContext(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("getting history from instance %d: %w", instance, err)
+	}
+
+	items := make([]*Item, 0, len(queue)+len(hist))
+	for _, xfer := range queue {
+		item := &Item{
+			Client:   NZBGet,
+			Instance: instance,
+			Name:     app.Name,
+			ID:       strconv.FormatInt(xfer.NZBID, 10),
+			Title:    xfer.NZBName,
+			State:    string(xfer.Status),
+			Category: xfer.Category,
+			SavePath: xfer.DestDir,
+			Size:     xfer.FileSizeMB * mnd.Megabyte,
+		}
+
+		if xfer.FileSizeMB > 0 {
+			item.Progress = float64(xfer.FileSizeMB-xfer.RemainingSizeMB) / float64(xfer.FileSizeMB) * 100 //nolint:mnd
+		}
+
+		items = append(items, item)
+	}
+
+	for _, xfer := range hist {
+		path := xfer.FinalDir
+		if path == "" {
+			path = xfer.DestDir
+		}
+
+		items = append(items, &Item{
+			Client:   NZBGet,
+			Instance: instance,
+			Name:     app.Name,
+			ID:       strconv.FormatInt(xfer.NZBID, 10),
+			Title:    xfer.Name,
+			State:    xfer.Status,
+			Category: xfer.Category,
+			SavePath: path,
+			Size:     xfer.FileSizeMB * mnd.Megabyte,
+			Progress: 100, //nolint:mnd
+			Added:    xfer.HistoryTime.Time,
+			History:  true,
+		})
+	}
+
+	return items, nil
+}
@@ -0,0 +1,167 @@
+package downloads
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"golift.io/starr"
+)
+
+/* This file contains the procedures to join the starr app queues with the download clients. */
+
+// queueItemsMax is the max number of queue records requested from each starr app.
+const queueItemsMax = 1000
+
+// Record is a queue record from a starr app.
+type Record struct {
+	App        string `json:"app"`
+	Instance   int    `json:"instance"`
+	Name       string `json:"name"`
+	QueueID    int64  `json:"queueId"`
+	DownloadID string `json:"downloadId"`
+	Title      string `json:"title"`
+	Status     string `json:"status"`
+	State      string `json:"state,omitempty"`
+	Client     string `json:"client"` // Name of the download client in the starr app.
+	Protocol   string `json:"protocol"`
+}
+
+// Download is a download client item joined with the starr queue records that belong to it.
+// Orphan is set when no starr app knows about the client item.
+// Missing is set when the starr records point to a client item that no longer exists.
+type Download struct {
+	ID      string    `json:"id"`
+	Item    *Item     `json:"item,omitempty"`
+	Records []*Record `json:"records,omitempty"`
+	Orphan  bool      `json:"orphan,omitempty"`
+	Missing bool      `json:"missing,omitempty"`
+}
+
+// View is the joined view of every download client and starr app queue.
+type View struct {
+	Downloads []*Download `json:"downloads"`
+	Orphans   int         `json:"orphans"`
+	Missing   int         `json:"missing"`
+	Errors    []string    `json:"errors,omitempty"`
+}
+
+// View joins every starr queue record with the matching item in a download client.
+// Orphans are only flagged when every app and client involved returned data.
+func (a *Action) View(ctx context.Context) *View {
+	return a.cmd.view(ctx)
+}
+
+// Handler returns the downloads from every client joined with the starr app queues.
+// @Description  Joins every starr app queue record with the matching item in a download client.
+// @Description  Matches by download ID: torrent hash, SABnzbd nzo_id or NZBGet NZBID.
+// @Description  Client items that no starr app knows about are flagged orphan.
+// @Description  Starr records whose client item has vanished are flagged missing.
+// @Summary      Retrieve the unified download view.
+// @Tags         Downloads
+// @Produce      json
+// @Param        flagged  query   bool  false  "Only return orphan and missing downloads."
+// @Success      200  {object} apps.Respond.apiResponse{message=View} "joined downloads"
+// @Failure      404  {object} string "bad token or api key"
+// @Router       /api/downloads [get]
+// @Security     ApiKeyAuth
+func (a *Action) Handler(req *http.Request) (int, interface{}) {
+	view := a.cmd.view(req.Context())
+
+	if flagged := req.URL.Query().Get("flagged"); flagged == "true" || flagged == "1" {
+		downloads := []*Download{}
+
+		for _, download := range view.Downloads {
+			if download.Orphan || download.Missing {
+				downloads = append(downloads, download)
+			}
+		}
+
+		view.Downloads = downloads
+	}
+
+	return http.StatusOK, view
+}
+
+func (c *cmd) view(ctx context.Context) *View {
+	clients := c.getItems(ctx)
+	records, apps, errs := c.getRecords(ctx)
+	view := &View{Downloads: []*Download{}, Errors: append(clients.errors, errs...)}
+
+	for _, err := range view.Errors {
+		c.Errorf("Building download view: %s", err)
+	}
+
+	// Download IDs are compared lower-cased because the starr apps upper-case torrent hashes.
+	byID := make(map[string][]*Record)
+	for _, record := range records {
+		id := strings.ToLower(record.DownloadID)
+		byID[id] = append(byID[id], record)
+	}
+
+	found := make(map[string]bool)
+	// A client item is only an orphan if every starr app returned its queue.
+	orphans := apps > 0 && len(errs) == 0
+
+	for _, item := range clients.items {
+		id := strings.ToLower(item.ID)
+		matched := byID[id]
+		found[id] = found[id] || len(matched) > 0
+
+		if len(matched) == 0 && item.History {
+			continue // Usenet history is only interesting when a starr app is still waiting on it.
+		}
+
+		view.Downloads = append(view.Downloads, &Download{
+			ID:      item.ID,
+			Item:    item,
+			Records: matched,
+			Orphan:  len(matched) == 0 && orphans,
+		})
+	}
+
+	for id, matched := range byID {
+		if found[id] {
+			continue
+		}
+
+		view.Downloads = append(view.Downloads, &Download{
+			ID:      matched[0].DownloadID,
+			Records: matched,
+			Missing: clients.complete(starr.Protocol(matched[0].Protocol)),
+		})
+	}
+
+	for _, download := range view.Downloads {
+		if download.Orphan {
+			view.Orphans++
+		} else if download.Missing {
+			view.Missing++
+		}
+	}
+
+	sort.Slice(view.Downloads, func(i, j int) bool {
+		return strings.ToLower(view.Downloads[i].title()) < strings.ToLower(view.Downloads[j].title())
+	})
+
+	return view
+}
+
+func (d *Download) title() string {
+	if d.Item != nil {
+		return d.Item.Title
+	}
+
+	return d.Records[0].Title
+}
+
+// wrap adds the client name to an error.
+func wrap(client string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("%s: %w", client, err)
+}
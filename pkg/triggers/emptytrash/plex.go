@@ -3,7 +3,9 @@ package emptytrash
 import (
 	"context"
 	"fmt"
+	"strconv"
 
+	"github.com/Notifiarr/notifiarr/pkg/apps"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
 	"github.com/Notifiarr/notifiarr/pkg/website"
 )
@@ -19,6 +21,13 @@ type cmd struct {
 	*common.Config
 }
 
+// plexApp allows each Plex server to have its own trigger.
+type plexApp struct {
+	app *apps.PlexConfig
+	cmd *cmd
+	idx int
+}
+
 // New configures the library.
 func New(config *common.Config) *Action {
 	return &Action{cmd: &cmd{Config: config}}
@@ -30,30 +39,37 @@ func (a *Action) Create() {
 }
 
 func (c *cmd) create() {
-	c.Add(&common.Action{
-		Name: TrigPlexEmptyTrash,
-		Fn:   c.emptyPlexTrash,
-		C:    make(chan *common.ActionInput, 1),
-	})
-}
+	for idx, app := range c.Apps.Plex {
+		if !app.Enabled() {
+			continue
+		}
 
-// Send radarr collection gaps to the website.
-func (a *Action) Send(input *common.ActionInput) {
-	a.cmd.Exec(input, TrigPlexEmptyTrash)
+		c.Add(&common.Action{
+			Name: TrigPlexEmptyTrash.WithInstance(idx + 1),
+			Fn:   (&plexApp{app: app, cmd: c, idx: idx}).emptyPlexTrash,
+			C:    make(chan *common.ActionInput, 1),
+		})
+	}
 }
 
-// Plex empties the trash for a Library in Plex.
-func (a *Action) Plex(event website.EventType, libraryKeys []string) {
-	a.cmd.Exec(&common.ActionInput{Type: event, Args: libraryKeys}, TrigPlexEmptyTrash)
+// Plex empties the trash for a Library in a Plex server. Instance is 1-indexed.
+func (a *Action) Plex(event website.EventType, instance int, libraryKeys []string) error {
+	input := &common.ActionInput{Type: event, Args: libraryKeys}
+	if !a.cmd.Exec(input, TrigPlexEmptyTrash.WithInstance(instance)) {
+		return fmt.Errorf("%w: Plex instance: %d", common.ErrInvalidApp, instance)
+	}
+
+	return nil
 }
 
-func (c *cmd) emptyPlexTrash(ctx context.Context, input *common.ActionInput) {
+func (p *plexApp) emptyPlexTrash(ctx context.Context, input *common.ActionInput) {
 	status := make(map[string]string)
 	errors := 0
 
 	for _, key := range input.Args {
-		if _, err := c.Apps.Plex.EmptyTrashWithContext(ctx, key); err != nil {
-			c.ErrorfNoShare("[%s requested] Emptying Plex trash for library '%s' failed: %v", input.Type, key, err)
+		if _, err := p.app.EmptyTrashWithContext(ctx, key); err != nil {
+			p.cmd.ErrorfNoShare("[%s requested] Emptying Plex %d trash for library '%s' failed: %v",
+				input.Type, p.idx+1, key, err)
 
 			status[key] = err.Error()
 			errors++
@@ -63,15 +79,16 @@ func (c *cmd) emptyPlexTrash(ctx context.Context, input *common.ActionInput) {
 	}
 
 	if len(status) > 0 {
-		c.SendData(&website.Request{
+		p.cmd.SendData(&website.Request{
 			Route:      website.PlexRoute,
 			Event:      input.Type,
-			Params:     []string{"emptylibrary=true"},
+			Params:     []string{"emptylibrary=true", "instance=" + strconv.Itoa(p.idx+1)},
 			Payload:    status,
-			LogMsg:     fmt.Sprintf("Emptied %d Plex library trashes with %d errors.", len(status), errors),
+			LogMsg:     fmt.Sprintf("Emptied %d Plex %d library trashes with %d errors.", len(status), p.idx+1, errors),
 			LogPayload: true,
 		})
 	} else {
-		c.Printf("[%s requested] Emptied %d Plex library trashes with %d errors.", input.Type, len(status), errors)
+		p.cmd.Printf("[%s requested] Emptied %d Plex %d library trashes with %d errors.",
+			input.Type, len(status), p.idx+1, errors)
 	}
 }
@@ -152,8 +152,14 @@ func (a *Actions) runTrigger(input *common.ActionInput, trigger, content string)
 		return a.notification(content)
 	case "emptyplextrash":
 		return a.emptyplextrash(input, content)
+	case "plexscan":
+		return a.plexscan(input)
 	case "mdblist":
 		return a.mdblist(input)
+	case "faileddownloads":
+		return a.faileddownloads(input)
+	case "orphans":
+		return a.orphans(input)
 	case "uploadlog":
 		return a.uploadlog(input, content)
 	default:
@@ -376,7 +382,7 @@ func (a *Actions) gaps(input *common.ActionInput) (int, string) {
 // @Summary      Start app-specific corruption check
 // @Tags         Triggers
 // @Produce      json
-// @Param        app  path   string  true  "app type to check" Enum(lidarr, prowlarr, radarr, readarr, sonarr)
+// @Param        app  path   string  true  "app type to check" Enum(lidarr, prowlarr, radarr, readarr, sonarr, whisparr)
 // @Success      200  {object} apps.Respond.apiResponse{message=string} "success"
 // @Failure      400  {object} apps.Respond.apiResponse{message=string} "missing app"
 // @Failure      404  {object} string "bad token or api key"
@@ -397,7 +403,7 @@ func (a *Actions) corrupt(input *common.ActionInput, content string) (int, strin
 // @Summary      Start app-specific backup check
 // @Tags         Triggers
 // @Produce      json
-// @Param        app  path   string  true  "app type to check" Enum(lidarr, prowlarr, radarr, readarr, sonarr)
+// @Param        app  path   string  true  "app type to check" Enum(lidarr, prowlarr, radarr, readarr, sonarr, whisparr)
 // @Success      200  {object} apps.Respond.apiResponse{message=string} "success"
 // @Failure      400  {object} apps.Respond.apiResponse{message=string} "missing app"
 // @Failure      404  {object} string "bad token or api key"
@@ -456,11 +462,13 @@ func (a *Actions) notification(content string) (int, string) {
 }
 
 // @Description  Empties one or more Plex library trash cans.
+// @Description  Prefix the library keys with an instance and a colon to pick a Plex server, ie. 2:1,5,6. The default is 1.
 // @Summary      Empty Plex Trashes
 // @Tags         Triggers,Plex
 // @Produce      json
 // @Param        libraryKeys  path   []string  true  "List of library keys, comma separated."
 // @Success      200  {object} apps.Respond.apiResponse{message=string} "started"
+// @Failure      400  {object} apps.Respond.apiResponse{message=string} "bad instance"
 // @Failure      501  {object} apps.Respond.apiResponse{message=string} "plex not enabled"
 // @Failure      404  {object} string "bad token or api key"
 // @Router       /api/trigger/emptyplextrash/{libraryKeys} [get]
@@ -470,9 +478,18 @@ func (a *Actions) emptyplextrash(input *common.ActionInput, content string) (int
 		return http.StatusNotImplemented, "Plex is not enabled."
 	}
 
-	a.EmptyTrash.Plex(input.Type, strings.Split(content, ","))
+	instance := 1
 
-	return http.StatusOK, "Emptying Plex Trash for library " + content
+	if prefix, keys, found := strings.Cut(content, ":"); found {
+		instance, _ = strconv.Atoi(prefix)
+		content = keys
+	}
+
+	if err := a.EmptyTrash.Plex(input.Type, instance, strings.Split(content, ",")); err != nil {
+		return http.StatusBadRequest, "Emptying Plex Trash: " + err.Error()
+	}
+
+	return http.StatusOK, fmt.Sprintf("Emptying Plex %d Trash for library %s", instance, content)
 }
 
 // @Description  Sends Radarr and Sonarr Libraries for MDBList Syncing.
@@ -488,6 +505,58 @@ func (a *Actions) mdblist(input *common.ActionInput) (int, string) {
 	return http.StatusOK, "MDBList library update started."
 }
 
+// @Description  Sends the failed SABnzbd and NZBGet downloads, grouped by category and indexer, to the website.
+// @Summary      Send failed usenet downloads
+// @Tags         Triggers
+// @Produce      json
+// @Success      200  {object} apps.Respond.apiResponse{message=string} "success"
+// @Failure      404  {object} string "bad token or api key"
+// @Router       /api/trigger/faileddownloads [get]
+// @Security     ApiKeyAuth
+func (a *Actions) faileddownloads(input *common.ActionInput) (int, string) {
+	a.Downloads.SendFailed(input.Type)
+	return http.StatusOK, "Failed downloads report started."
+}
+
+// @Description  Scans the download folders for files nothing is tracking, and sends them to the website.
+// @Description  Orphans that outlived the grace period are quarantined or deleted if an action is configured.
+// @Summary      Scan for orphaned downloads
+// @Tags         Triggers
+// @Produce      json
+// @Success      200  {object} apps.Respond.apiResponse{message=string} "success"
+// @Failure      404  {object} string "bad token or api key"
+// @Router       /api/trigger/orphans [get]
+// @Security     ApiKeyAuth
+func (a *Actions) orphans(input *common.ActionInput) (int, string) {
+	a.Downloads.SendOrphans(input.Type)
+	return http.StatusOK, "Orphaned downloads scan started."
+}
+
+// @Description  Asks every enabled Plex server to scan the library section that contains each path.
+// @Description  Radarr and Sonarr paths are fine; they are translated with each Plex server's path maps.
+// @Description  Use this after a starr import to refresh only the folder that changed.
+// @Summary      Partial Plex library scan
+// @Tags         Triggers,Plex
+// @Produce      json
+// @Param        args formData []string true "provide paths as multiple 'args' parameters in POST body" collectionFormat(multi) example(args=/movies/Movie (2024))
+// @Accept       application/x-www-form-urlencoded
+// @Success      200  {object} apps.Respond.apiResponse{message=string} "success"
+// @Failure      400  {object} apps.Respond.apiResponse{message=string} "no paths provided"
+// @Failure      404  {object} string "bad token or api key"
+// @Router       /api/trigger/plexscan [post]
+// @Security     ApiKeyAuth
+//
+//nolint:lll
+func (a *Actions) plexscan(input *common.ActionInput) (int, string) {
+	if len(input.Args) == 0 {
+		return http.StatusBadRequest, "No paths provided."
+	}
+
+	a.PlexCron.ScanPaths(input.Type, input.Args...)
+
+	return http.StatusOK, fmt.Sprintf("Plex scan started for %d paths.", len(input.Args))
+}
+
 // @Description  Uploads a log file to Notifiarr.com.
 // @Summary      Upload log file to Notifiarr.com
 // @Tags         Triggers
@@ -0,0 +1,86 @@
+package jellyfincron
+
+import (
+	"context"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/jellyfin"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/plexcron"
+	"github.com/Notifiarr/notifiarr/pkg/website"
+)
+
+// sentExpire is how long a sent session is remembered after it ends.
+const sentExpire = 10 * time.Minute
+
+// checkForFinishedItems runs every minute to send a report when a user gets to the end of a movie or tv show.
+// Like Plex, Jellyfin does not send a reliable notice when an item is "finished", so we watch the play position.
+func (c *cmd) checkForFinishedItems(ctx context.Context, _ *common.ActionInput) {
+	sessions, err := c.getSessions(ctx, time.Second)
+	if err != nil {
+		c.Errorf("[JELLYFIN] Getting Sessions from %s: %v", c.Jellyfin.URL, err)
+		return
+	}
+
+	c.pruneSent(sessions.Sessions, time.Now())
+
+	if len(sessions.Sessions) == 0 {
+		c.Debugf("[JELLYFIN] No Sessions Collected from %s", c.Jellyfin.URL)
+		return
+	}
+
+	for _, session := range sessions.Sessions {
+		pct := session.Percent()
+
+		// Make sure we didn't already send this session.
+		if _, ok := c.sent[session.ID+session.ItemID()]; ok {
+			c.Debugf("[JELLYFIN] %s {%s} %s => %s: %s %.1f%% (sent)",
+				c.Jellyfin.URL, session.ID, session.UserName, session.Type(), session.Title(), pct)
+			continue
+		}
+
+		msg, done := plexcron.SessionDone(float64(session.Duration()), pct, session.Playing(), session.Type())
+		if !done {
+			c.Debugf("[JELLYFIN] %s {%s} %s => %s: %s %.1f%% (%s)",
+				c.Jellyfin.URL, session.ID, session.UserName, session.Type(), session.Title(), pct, msg)
+			continue
+		}
+
+		c.Printf("[JELLYFIN] %s {%s} %s => %s: %s %.1f%% (sending)",
+			c.Jellyfin.URL, session.ID, session.UserName, session.Type(), session.Title(), pct)
+		c.sendSessionDone(ctx, session)
+	}
+}
+
+// sendSessionDone sends a finished session to the website.
+func (c *cmd) sendSessionDone(ctx context.Context, session *jellyfin.Session) {
+	c.SendData(&website.Request{
+		Route: website.JellyRoute,
+		Event: website.EventType(session.Type()),
+		Payload: &website.JellyfinPayload{
+			Snap:     c.plex.GetMetaSnap(ctx),
+			Jellyfin: &jellyfin.Sessions{Name: c.Jellyfin.Server.Name(), Sessions: []*jellyfin.Session{session}},
+		},
+		LogMsg:     "Jellyfin Completed Sessions",
+		LogPayload: true,
+		ErrorsOnly: !c.DebugEnabled(),
+	})
+
+	c.sent[session.ID+session.ItemID()] = time.Now()
+}
+
+// pruneSent forgets the sent sessions that ended more than sentExpire ago, so the sent map does not grow forever.
+// The short wait keeps a session that briefly drops out of the list from being sent twice.
+func (c *cmd) pruneSent(sessions []*jellyfin.Session, now time.Time) {
+	for _, session := range sessions {
+		if _, ok := c.sent[session.ID+session.ItemID()]; ok {
+			c.sent[session.ID+session.ItemID()] = now
+		}
+	}
+
+	for key, seen := range c.sent {
+		if now.Sub(seen) > sentExpire {
+			delete(c.sent, key)
+		}
+	}
+}
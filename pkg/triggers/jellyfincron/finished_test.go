@@ -0,0 +1,35 @@
+package jellyfincron //nolint:testpackage // the sent map is not exported.
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/jellyfin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPruneSent(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	cmd := &cmd{sent: map[string]time.Time{
+		"playing" + "item1": now.Add(-time.Hour),
+		"ended" + "item2":   now.Add(-time.Hour),
+		"recent" + "item3":  now.Add(-time.Minute),
+	}}
+	sessions := []*jellyfin.Session{
+		{ID: "playing", NowPlayingItem: &jellyfin.Item{ID: "item1"}},
+		{ID: "unsent", NowPlayingItem: &jellyfin.Item{ID: "item4"}},
+	}
+
+	cmd.pruneSent(sessions, now)
+
+	assert.Contains(t, cmd.sent, "playingitem1", "a session that is still playing must be remembered")
+	assert.Equal(t, now, cmd.sent["playingitem1"], "the last seen time must be updated")
+	assert.NotContains(t, cmd.sent, "endeditem2", "a session that ended long ago must be forgotten")
+	assert.Contains(t, cmd.sent, "recentitem3", "a session that just ended must be remembered a while")
+	assert.NotContains(t, cmd.sent, "unsentitem4", "sessions are only added after they are sent")
+
+	cmd.pruneSent(nil, now.Add(sentExpire+time.Minute))
+	assert.Empty(t, cmd.sent, "every session is forgotten after they all end")
+}
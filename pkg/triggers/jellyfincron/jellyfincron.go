@@ -0,0 +1,170 @@
+// Package jellyfincron watches Jellyfin (or Emby) sessions for finished items, and relays webhooks.
+// Finished items are decided using the same website settings and logic as Plex.
+package jellyfincron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/jellyfin"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/data"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/plexcron"
+	"github.com/Notifiarr/notifiarr/pkg/website"
+	"github.com/Notifiarr/notifiarr/pkg/website/clientinfo"
+	"golift.io/cnfg"
+)
+
+const randomMilliseconds = 400
+
+// Action contains the exported methods for this package.
+type Action struct {
+	cmd *cmd
+}
+
+type cmd struct {
+	*common.Config
+	Jellyfin *apps.JellyfinConfig
+	plex     *plexcron.Action     // used for system snapshots.
+	sent     map[string]time.Time // Tracks Finished sessions already sent, and when they were last seen.
+	sync.Mutex
+}
+
+const TrigJellyfinSessions common.TriggerName = "Gathering and sending Jellyfin Sessions."
+
+// New configures the library.
+func New(config *common.Config, jelly *apps.JellyfinConfig, plex *plexcron.Action) *Action {
+	return &Action{
+		cmd: &cmd{
+			Config:   config,
+			Jellyfin: jelly,
+			plex:     plex,
+			sent:     make(map[string]time.Time),
+		},
+	}
+}
+
+// Send sends jellyfin sessions in a go routine through a channel.
+func (a *Action) Send(event website.EventType) {
+	a.cmd.Exec(&common.ActionInput{Type: event}, TrigJellyfinSessions)
+}
+
+// Create initializes the library.
+func (a *Action) Create() {
+	a.cmd.create()
+}
+
+func (c *cmd) create() {
+	info := clientinfo.Get()
+	if !c.Jellyfin.Enabled() || info == nil {
+		return
+	}
+
+	c.Add(&common.Action{
+		Name: TrigJellyfinSessions,
+		Fn:   c.sendSessions,
+		C:    make(chan *common.ActionInput, 1),
+	})
+
+	// The website has no jellyfin settings, so this uses the Plex percentages.
+	if cfg := info.Actions.Plex; cfg.MoviesPC != 0 || cfg.SeriesPC != 0 {
+		c.Printf("==> Jellyfin Sessions Tracker Started, URL: %s, interval:1m timeout:%s movies:%d%% series:%d%%",
+			c.Jellyfin.URL, c.Jellyfin.Timeout, cfg.MoviesPC, cfg.SeriesPC)
+
+		c.Add(&common.Action{
+			Name: "Checking Jellyfin for completed sessions.",
+			Hide: true, // do not log this one.
+			Fn:   c.checkForFinishedItems,
+			D: cnfg.Duration{Duration: time.Minute +
+				time.Duration(c.Config.Rand().Intn(randomMilliseconds))*time.Millisecond},
+		})
+	}
+}
+
+// sendSessions is fired by a trigger to send the current sessions to the website.
+func (c *cmd) sendSessions(ctx context.Context, input *common.ActionInput) {
+	sessions, err := c.getSessions(ctx, time.Minute)
+	if err != nil {
+		c.Errorf("Getting Jellyfin sessions: %v", err)
+	}
+
+	c.SendData(&website.Request{
+		Route:      website.JellyRoute,
+		Event:      input.Type,
+		Payload:    &website.JellyfinPayload{Snap: c.plex.GetMetaSnap(ctx), Jellyfin: sessions},
+		LogMsg:     "Jellyfin Sessions",
+		LogPayload: true,
+	})
+}
+
+// SendWebhook is called in a go routine after a jellyfin playback webhook is received.
+func (a *Action) SendWebhook(hook *jellyfin.IncomingWebhook) {
+	go a.cmd.sendWebhook(hook)
+}
+
+func (c *cmd) sendWebhook(hook *jellyfin.IncomingWebhook) {
+	sessions := &jellyfin.Sessions{Name: c.Jellyfin.Server.Name()}
+	ci := clientinfo.Get()
+	ctx := context.Background()
+
+	// If NoActivity=false, then grab sessions, but wait 'Delay' to make sure they're updated.
+	if ci != nil && !ci.Actions.Plex.NoActivity {
+		time.Sleep(ci.Actions.Plex.Delay.Duration)
+		ctx, cancel := context.WithTimeout(ctx, c.Jellyfin.Timeout.Duration)
+
+		var err error
+		if sessions, err = c.getSessions(ctx, time.Second); err != nil {
+			c.Errorf("Getting Jellyfin sessions: %v", err)
+		}
+
+		cancel()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second) //nolint:mnd // wait max 5 seconds for system info.
+	defer cancel()
+
+	c.SendData(&website.Request{
+		Route:      website.JellyRoute,
+		Event:      website.EventHook,
+		Payload:    &website.JellyfinPayload{Snap: c.plex.GetMetaSnap(ctx), Load: hook, Jellyfin: sessions},
+		LogMsg:     "Jellyfin Webhook (and sessions)",
+		LogPayload: true,
+	})
+}
+
+// GetSessions returns the jellyfin sessions up to 1 minute old.
+func (a *Action) GetSessions(ctx context.Context) (*jellyfin.Sessions, error) {
+	return a.cmd.getSessions(ctx, time.Minute)
+}
+
+// getSessions returns cached sessions if they are new enough, otherwise fetches fresh sessions.
+// The Lock ensures only one request to Jellyfin happens at once.
+func (c *cmd) getSessions(ctx context.Context, allowedAge time.Duration) (*jellyfin.Sessions, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	item := data.Get("jellyfinCurrentSessions")
+	if item != nil && time.Now().Add(-allowedAge).Before(item.Time) && item.Data != nil {
+		return item.Data.(*jellyfin.Sessions), nil //nolint:forcetypeassert
+	}
+
+	start := time.Now()
+	sessions, err := c.Jellyfin.GetSessionsWithContext(ctx)
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		return &jellyfin.Sessions{Name: c.Jellyfin.Server.Name()},
+			fmt.Errorf("jellyfin sessions cancelled after %s: %w", time.Since(start), err)
+	case err != nil:
+		return &jellyfin.Sessions{Name: c.Jellyfin.Server.Name()}, fmt.Errorf("jellyfin sessions: %w", err)
+	}
+
+	sessions.Name = c.Jellyfin.Server.Name()
+	data.Save("jellyfinCurrentSessions", sessions)
+
+	return sessions, nil
+}
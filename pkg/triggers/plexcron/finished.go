@@ -17,12 +17,20 @@ import (
 // This usually means the user has finished watching the item and we can send a "done" notice.
 // Plex does not send a webhook or identify in any other way when an item is "finished".
 func (c *cmd) checkForFinishedItems(ctx context.Context, _ *common.ActionInput) {
-	sessions, err := c.getSessions(ctx, time.Second)
+	for idx, server := range c.Plex {
+		if server.Enabled() {
+			c.checkServerForFinishedItems(ctx, idx)
+		}
+	}
+}
+
+func (c *cmd) checkServerForFinishedItems(ctx context.Context, idx int) {
+	sessions, err := c.getSessions(ctx, idx, time.Second)
 	if err != nil {
-		c.Errorf("[PLEX] Getting Sessions from %s: %v", c.Plex.URL, err)
+		c.Errorf("[PLEX] Getting Sessions from %s: %v", c.Plex[idx].URL, err)
 		return
 	} else if len(sessions.Sessions) == 0 {
-		c.Debugf("[PLEX] No Sessions Collected from %s", c.Plex.URL)
+		c.Debugf("[PLEX] No Sessions Collected from %s", c.Plex[idx].URL)
 		return
 	}
 
@@ -34,7 +42,7 @@ func (c *cmd) checkForFinishedItems(ctx context.Context, _ *common.ActionInput)
 
 		// Make sure we didn't already send this session.
 		if _, ok := c.sent[session.Session.ID+session.SessionKey]; !ok {
-			msg = c.checkSessionDone(ctx, session, pct)
+			msg = c.checkSessionDone(ctx, idx, session, sessions.Name, pct)
 		}
 
 		//nolint:lll
@@ -42,45 +50,59 @@ func (c *cmd) checkForFinishedItems(ctx context.Context, _ *common.ActionInput)
 		// [DEBUG] 2021/04/03 06:00:39 [PLEX] https://plex.domain.com {dsm195u1jurq7w1ejlh6pmr9/33} username => movie: Come True (playing) 81.3%
 		if strings.HasPrefix(msg, statusSending) || strings.HasPrefix(msg, statusError) {
 			c.Printf("[PLEX] %s {%s/%s} %s => %s: %s (%s) %.1f%% (%s)",
-				c.Plex.URL, session.Session.ID, session.SessionKey, session.User.Title,
+				c.Plex[idx].URL, session.Session.ID, session.SessionKey, session.User.Title,
 				session.Type, session.Title, session.Player.State, pct, msg)
 		} else {
 			c.Debugf("[PLEX] %s {%s/%s} %s => %s: %s (%s) %.1f%% (%s)",
-				c.Plex.URL, session.Session.ID, session.SessionKey, session.User.Title,
+				c.Plex[idx].URL, session.Session.ID, session.SessionKey, session.User.Title,
 				session.Type, session.Title, session.Player.State, pct, msg)
 		}
 	}
 }
 
 // checkSessionDone checks a session's data to see if it is considered finished.
-func (c *cmd) checkSessionDone(ctx context.Context, session *plex.Session, pct float64) string {
+func (c *cmd) checkSessionDone(ctx context.Context, idx int, session *plex.Session, name string, pct float64) string {
+	if msg, done := SessionDone(session.Duration, pct, session.Player.State == playing, session.Type); !done {
+		return msg
+	}
+
+	return c.sendSessionDone(ctx, idx, session, name)
+}
+
+// SessionDone uses the website's movie and series percentages to decide if a playing item is finished.
+// Returns a status message, and true if the item should be sent as finished. The message is empty when true.
+// This is exported so other media servers (Jellyfin/Emby) can track finished items the same way Plex does.
+func SessionDone(duration, pct float64, isPlaying bool, itemType string) (string, bool) {
 	ci := clientinfo.Get()
+	if ci == nil {
+		return statusIgnoring, false
+	}
 
 	switch cfg := ci.Actions.Plex; {
-	case session.Duration == 0:
-		return statusIgnoring
-	case session.Player.State != playing:
-		return statusPaused
-	case cfg.MoviesPC > 0 && website.EventType(session.Type) == website.EventMovie:
+	case duration == 0:
+		return statusIgnoring, false
+	case !isPlaying:
+		return statusPaused, false
+	case cfg.MoviesPC > 0 && website.EventType(itemType) == website.EventMovie:
 		if pct < float64(cfg.MoviesPC) {
-			return statusWatching
+			return statusWatching, false
 		}
 
-		return c.sendSessionDone(ctx, session)
-	case cfg.SeriesPC > 0 && website.EventType(session.Type) == website.EventEpisode:
+		return "", true
+	case cfg.SeriesPC > 0 && website.EventType(itemType) == website.EventEpisode:
 		if pct < float64(cfg.SeriesPC) {
-			return statusWatching
+			return statusWatching, false
 		}
 
-		return c.sendSessionDone(ctx, session)
+		return "", true
 	default:
-		return statusIgnoring
+		return statusIgnoring, false
 	}
 }
 
 // sendSessionDone is the last method to run that sends a finished session to the website.
-func (c *cmd) sendSessionDone(ctx context.Context, session *plex.Session) string {
-	if err := c.checkPlexAgent(ctx, session); err != nil {
+func (c *cmd) sendSessionDone(ctx context.Context, idx int, session *plex.Session, name string) string {
+	if err := c.checkPlexAgent(ctx, idx, session); err != nil {
 		return statusError + ": " + err.Error()
 	}
 
@@ -89,7 +111,7 @@ func (c *cmd) sendSessionDone(ctx context.Context, session *plex.Session) string
 		Event: website.EventType(session.Type),
 		Payload: &website.Payload{
 			Snap: c.getMetaSnap(ctx),
-			Plex: &plex.Sessions{Name: c.Plex.Server.Name(), Sessions: []*plex.Session{session}},
+			Plex: &plex.Sessions{Name: name, Sessions: []*plex.Session{session}},
 		},
 		LogMsg:     "Plex Completed Sessions",
 		LogPayload: true,
@@ -103,12 +125,12 @@ func (c *cmd) sendSessionDone(ctx context.Context, session *plex.Session) string
 
 // checkPlexAgent checks the plex agent and makes another request to find the section key.
 // This is because Plex servers using the Plex Agent do not provide the show Title in the session.
-func (c *cmd) checkPlexAgent(ctx context.Context, session *plex.Session) error {
+func (c *cmd) checkPlexAgent(ctx context.Context, idx int, session *plex.Session) error {
 	if !strings.Contains(session.GUID, "plex://") || session.Key == "" {
 		return nil
 	}
 
-	sections, err := c.Plex.GetPlexSectionKeyWithContext(ctx, session.Key)
+	sections, err := c.Plex[idx].GetPlexSectionKeyWithContext(ctx, session.Key)
 	if err != nil {
 		return fmt.Errorf("getting plex key %s: %w", session.Key, err)
 	}
@@ -0,0 +1,552 @@
+package plexcron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/plex"
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common/jsonl"
+	"github.com/gorilla/mux"
+	"golift.io/cnfg"
+)
+
+/* This file contains the procedures to save completed Plex sessions to a local watch history file. */
+
+// HistoryFileName is the name of the file Plex watch history is saved to.
+// It lives in the same folder as the config file. Each line is one json encoded HistoryItem.
+const HistoryFileName = "plexhistory.jsonl"
+
+const TrigPlexHistory common.TriggerName = "Collecting Plex sessions for watch history."
+
+const (
+	// How many history items are returned when no limit is provided.
+	defaultHistoryLimit = 100
+	// The history file is rotated when it's bigger than this many megabytes, if max size is not set.
+	defaultHistorySize = 10
+	// How many rotated history files are kept.
+	historyBackups = 1
+	// Date format accepted by the history API, besides RFC3339.
+	historyDate = "2006-01-02"
+)
+
+// ErrBadDate is returned when the history API gets a bad start or end date.
+var ErrBadDate = errors.New("dates must look like " + historyDate + " or " + time.RFC3339)
+
+// HistoryConfig turns on the local Plex watch history.
+// Items older than MaxAge are pruned, and the file is rotated when it's larger than MaxSize megabytes.
+// Player IP addresses are only saved when SaveIPs is true.
+type HistoryConfig struct {
+	Enabled bool          `json:"enabled" toml:"enabled"  xml:"enabled"  yaml:"enabled"`
+	MaxAge  cnfg.Duration `json:"maxAge"  toml:"max_age"  xml:"max_age"  yaml:"maxAge"`
+	MaxSize int64         `json:"maxSize" toml:"max_size" xml:"max_size" yaml:"maxSize"`
+	SaveIPs bool          `json:"saveIps" toml:"save_ips" xml:"save_ips" yaml:"saveIps"`
+}
+
+// HistoryItem is a completed Plex session saved to the local watch history.
+type HistoryItem struct {
+	Instance         int       `json:"instance"`
+	Server           string    `json:"server"`
+	Started          time.Time `json:"started"`
+	Stopped          time.Time `json:"stopped"`
+	User             string    `json:"user"`
+	UserID           string    `json:"userId"`
+	Type             string    `json:"type"`
+	Title            string    `json:"title"`
+	ParentTitle      string    `json:"parentTitle,omitempty"`
+	GrandparentTitle string    `json:"grandparentTitle,omitempty"`
+	Year             int       `json:"year,omitempty"`
+	Season           int64     `json:"season,omitempty"`
+	Episode          int64     `json:"episode,omitempty"`
+	Library          string    `json:"library"`
+	RatingKey        string    `json:"ratingKey"`
+	GUID             string    `json:"guid"`
+	GUIDs            []string  `json:"guids,omitempty"`
+	Duration         float64   `json:"duration"`   // milliseconds, like Plex.
+	ViewOffset       float64   `json:"viewOffset"` // milliseconds, like Plex.
+	Percent          float64   `json:"percent"`
+	Player           string    `json:"player"`
+	Product          string    `json:"product"`
+	Platform         string    `json:"platform"`
+	Address          string    `json:"address,omitempty"`
+	Local            bool      `json:"local"`
+	VideoDecision    string    `json:"videoDecision"`
+	AudioDecision    string    `json:"audioDecision"`
+}
+
+// HistoryUser is the watch history summary for one user.
+type HistoryUser struct {
+	User     string    `json:"user"`
+	UserID   string    `json:"userId"`
+	Plays    int       `json:"plays"`
+	Watched  float64   `json:"watched"` // milliseconds.
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+// historyFilter is used to query the watch history.
+type historyFilter struct {
+	instance int
+	user     string
+	item     string
+	start    time.Time
+	end      time.Time
+	limit    int
+}
+
+// history saves and reads the watch history file.
+// The items in the file are kept in memory (oldest first), so queries do not read the file.
+type history struct {
+	*HistoryConfig
+	file    *jsonl.File[HistoryItem]
+	items   []*HistoryItem
+	loaded  bool
+	started map[string]time.Time // Tracks when we first saw each session.
+	sync.Mutex
+}
+
+func newHistory(config *HistoryConfig, file string) *history {
+	if config == nil || !config.Enabled || file == "" {
+		return &history{HistoryConfig: &HistoryConfig{}, file: &jsonl.File[HistoryItem]{}}
+	}
+
+	if config.MaxSize <= 0 {
+		config.MaxSize = defaultHistorySize
+	}
+
+	return &history{
+		HistoryConfig: config,
+		started:       make(map[string]time.Time),
+		file: &jsonl.File[HistoryItem]{
+			Path:    file,
+			MaxSize: config.MaxSize * mnd.Megabyte,
+			Backups: historyBackups,
+		},
+	}
+}
+
+// enabled returns true if watch history is saved.
+func (h *history) enabled() bool {
+	return h.Enabled && h.file.Path != ""
+}
+
+func (c *cmd) setupHistory() {
+	if !c.Plex.Enabled() || !c.history.enabled() {
+		return
+	}
+
+	c.Printf("==> Plex Watch History Started, servers: %d, interval:1m file:%s max_age:%s max_size:%dMB save_ips:%v",
+		len(c.Plex), c.history.file.Path, c.history.MaxAge, c.history.MaxSize, c.history.SaveIPs)
+
+	c.Add(&common.Action{
+		Name: TrigPlexHistory,
+		Hide: true, // do not log this one.
+		Fn:   c.collectHistory,
+		D: cnfg.Duration{Duration: time.Minute +
+			time.Duration(c.Config.Rand().Intn(randomMilliseconds2))*time.Millisecond},
+	})
+}
+
+// collectHistory makes sure sessions are collected every minute, so finished sessions are found.
+// Other timers also collect sessions, and the cache prevents extra requests to Plex.
+func (c *cmd) collectHistory(ctx context.Context, input *common.ActionInput) {
+	for idx, server := range c.Plex {
+		if !server.Enabled() {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, server.Timeout.Duration)
+		if _, err := c.getSessions(ctx, idx, time.Minute); err != nil {
+			c.Errorf("[%s requested] Getting Plex %d sessions for watch history: %v", input.Type, idx+1, err)
+		}
+
+		cancel()
+	}
+}
+
+// trackHistory saves every session in previous that is no longer in current.
+// This is called by the session tracker with the lock held.
+func (c *cmd) trackHistory(idx int, current, previous *plex.Sessions) {
+	if !c.history.enabled() {
+		return
+	}
+
+	now := time.Now()
+	ids := make(map[string]struct{})
+
+	for _, session := range current.Sessions {
+		key := fmt.Sprint(idx, "/", session.Session.ID)
+		ids[session.Session.ID] = struct{}{}
+
+		if _, ok := c.history.started[key]; !ok {
+			c.history.started[key] = now
+		}
+	}
+
+	if previous == nil {
+		return
+	}
+
+	items := []*HistoryItem{}
+
+	for _, session := range previous.Sessions {
+		if _, ok := ids[session.Session.ID]; ok {
+			continue
+		}
+
+		key := fmt.Sprint(idx, "/", session.Session.ID)
+		item := newHistoryItem(idx, previous.Name, session, c.history.started[key], now)
+		if !c.history.SaveIPs {
+			item.Address = ""
+		}
+
+		items = append(items, item)
+		delete(c.history.started, key)
+	}
+
+	if err := c.history.add(items...); err != nil {
+		c.Errorf("Saving Plex %d watch history: %v", idx+1, err)
+	}
+}
+
+func newHistoryItem(idx int, server string, session *plex.Session, started, stopped time.Time) *HistoryItem {
+	if started.IsZero() {
+		started = stopped // We did not see this session start.
+	}
+
+	item := &HistoryItem{
+		Instance:         idx + 1,
+		Server:           server,
+		Started:          started,
+		Stopped:          stopped,
+		User:             session.User.Title,
+		UserID:           session.User.ID,
+		Type:             session.Type,
+		Title:            session.Title,
+		ParentTitle:      session.ParentTitle,
+		GrandparentTitle: session.GrandparentTitle,
+		Year:             session.Year,
+		Library:          session.LibrarySectionTitle,
+		RatingKey:        session.RatingKey,
+		GUID:             session.GUID,
+		Duration:         session.Duration,
+		ViewOffset:       session.ViewOffset,
+		Player:           session.Player.Title,
+		Product:          session.Player.Product,
+		Platform:         session.Player.Platform,
+		Address:          session.Player.Address,
+		Local:            session.Player.Local,
+		VideoDecision:    session.TranscodeSession.VideoDecision,
+		AudioDecision:    session.TranscodeSession.AudioDecision,
+	}
+
+	if session.Type == "episode" {
+		item.Season, item.Episode = session.ParentIndex, session.Index
+	}
+
+	if session.Duration > 0 {
+		item.Percent = session.ViewOffset / session.Duration * 100 //nolint:mnd
+	}
+
+	if item.VideoDecision == "" {
+		item.VideoDecision = "directplay"
+	}
+
+	if item.AudioDecision == "" {
+		item.AudioDecision = "directplay"
+	}
+
+	for _, guid := range session.GuID {
+		item.GUIDs = append(item.GUIDs, guid.ID)
+	}
+
+	return item
+}
+
+// add appends items to the history file, and prunes items that are too old.
+func (h *history) add(items ...*HistoryItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	h.Lock()
+	defer h.Unlock()
+
+	if err := h.load(); err != nil {
+		return err
+	}
+
+	rotated, err := h.file.Append(items...)
+	if err != nil {
+		return fmt.Errorf("history file: %w", err)
+	}
+
+	h.items = append(h.items, items...)
+
+	pruned, err := h.prune(time.Now())
+	if rotated || pruned {
+		h.loaded = false // A file was removed, so reload the items that are left on the next query.
+	}
+
+	return err
+}
+
+// prune removes items older than max age from memory, and rotated files older than max age from disk.
+// Call this with the lock held.
+func (h *history) prune(now time.Time) (bool, error) {
+	if h.MaxAge.Duration <= 0 {
+		return false, nil
+	}
+
+	cutoff := now.Add(-h.MaxAge.Duration)
+	idx := sort.Search(len(h.items), func(i int) bool { return !h.items[i].Stopped.Before(cutoff) })
+	h.items = h.items[idx:]
+
+	pruned, err := h.file.PruneBefore(cutoff)
+	if err != nil {
+		return pruned, fmt.Errorf("pruning history file: %w", err)
+	}
+
+	return pruned, nil
+}
+
+// load reads the history file into memory, if it has not been read yet. Call this with the lock held.
+func (h *history) load() error {
+	if h.loaded {
+		return nil
+	}
+
+	items := []*HistoryItem{}
+	if err := h.file.Each(func(item *HistoryItem) { items = append(items, item) }); err != nil {
+		return fmt.Errorf("history file: %w", err)
+	}
+
+	// Items are written when a session stops, so they're already in order; this makes sure.
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Stopped.Before(items[j].Stopped) })
+	h.items, h.loaded = items, true
+
+	_, err := h.prune(time.Now())
+
+	return err
+}
+
+// list returns the items in the history. The slice is a copy, so it may be read without the lock.
+func (h *history) list() ([]*HistoryItem, error) {
+	h.Lock()
+	defer h.Unlock()
+
+	if err := h.load(); err != nil {
+		return nil, err
+	}
+
+	return append([]*HistoryItem{}, h.items...), nil
+}
+
+// query returns the history items that match the filter, newest first.
+func (h *history) query(filter *historyFilter) ([]*HistoryItem, error) {
+	items, err := h.list()
+	if err != nil {
+		return nil, err
+	}
+
+	output := []*HistoryItem{}
+
+	for idx := len(items) - 1; idx >= 0; idx-- {
+		if !filter.match(items[idx]) {
+			continue
+		}
+
+		if output = append(output, items[idx]); filter.limit > 0 && len(output) >= filter.limit {
+			break
+		}
+	}
+
+	return output, nil
+}
+
+// users returns a watch summary for each user that matches the filter, most plays first.
+func (h *history) users(filter *historyFilter) ([]*HistoryUser, error) {
+	items, err := h.list()
+	if err != nil {
+		return nil, err
+	}
+
+	users := make(map[string]*HistoryUser)
+
+	for _, item := range items {
+		if !filter.match(item) {
+			continue
+		}
+
+		user, ok := users[item.UserID]
+		if !ok {
+			user = &HistoryUser{User: item.User, UserID: item.UserID}
+			users[item.UserID] = user
+		}
+
+		user.Plays++
+		user.Watched += item.ViewOffset
+
+		if item.Stopped.After(user.LastSeen) {
+			user.LastSeen = item.Stopped
+		}
+	}
+
+	output := make([]*HistoryUser, 0, len(users))
+	for _, user := range users {
+		output = append(output, user)
+	}
+
+	sort.Slice(output, func(i, j int) bool { return output[i].Plays > output[j].Plays })
+
+	return output, nil
+}
+
+func (f *historyFilter) match(item *HistoryItem) bool {
+	switch {
+	case f.instance != 0 && item.Instance != f.instance:
+		return false
+	case f.user != "" && !strings.EqualFold(f.user, item.User) && f.user != item.UserID:
+		return false
+	case !f.start.IsZero() && item.Stopped.Before(f.start):
+		return false
+	case !f.end.IsZero() && !item.Stopped.Before(f.end):
+		return false
+	case f.item == "":
+		return true
+	}
+
+	if f.item == item.RatingKey || f.item == item.GUID {
+		return true
+	}
+
+	for _, guid := range item.GUIDs {
+		if f.item == guid {
+			return true
+		}
+	}
+
+	find := strings.ToLower(f.item)
+
+	return strings.Contains(strings.ToLower(item.Title), find) ||
+		strings.Contains(strings.ToLower(item.GrandparentTitle), find)
+}
+
+// parseHistoryFilter builds a history filter from the request's query parameters.
+func parseHistoryFilter(req *http.Request) (*historyFilter, error) {
+	var (
+		err    error
+		query  = req.URL.Query()
+		filter = &historyFilter{user: query.Get("user"), item: query.Get("item"), limit: defaultHistoryLimit}
+	)
+
+	filter.instance, _ = strconv.Atoi(mux.Vars(req)["id"])
+
+	if limit, err := strconv.Atoi(query.Get("limit")); err == nil {
+		filter.limit = limit
+	}
+
+	if filter.start, err = parseHistoryDate(query.Get("start"), false); err != nil {
+		return nil, err
+	}
+
+	if filter.end, err = parseHistoryDate(query.Get("end"), true); err != nil {
+		return nil, err
+	}
+
+	return filter, nil
+}
+
+// parseHistoryDate parses a date from the history API. An end date without a time includes the whole day.
+func parseHistoryDate(input string, end bool) (time.Time, error) {
+	if input == "" {
+		return time.Time{}, nil
+	}
+
+	if date, err := time.Parse(time.RFC3339, input); err == nil {
+		return date, nil
+	}
+
+	date, err := time.ParseInLocation(historyDate, input, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s", ErrBadDate, input)
+	}
+
+	if end {
+		date = date.AddDate(0, 0, 1)
+	}
+
+	return date, nil
+}
+
+// HistoryEnabled returns true if the local Plex watch history is saved.
+func (a *Action) HistoryEnabled() bool {
+	return a.cmd.history.enabled()
+}
+
+// HandleHistory returns the local Plex watch history.
+// @Description  Returns completed Plex sessions from the local watch history, newest first.
+// @Description  Filter by user (name or ID), item (title, rating key or GUID) and date range.
+// @Summary      Retrieve Plex watch history.
+// @Tags         Plex
+// @Produce      json
+// @Param        user   query   string  false  "User name or ID"
+// @Param        item   query   string  false  "Part of a title, or a rating key or GUID"
+// @Param        start  query   string  false  "Oldest date to include: 2006-01-02 or RFC3339"
+// @Param        end    query   string  false  "Newest date to include: 2006-01-02 or RFC3339"
+// @Param        limit  query   int     false  "Maximum number of items to return. Default 100, 0 for all."
+// @Success      200  {object} apps.Respond.apiResponse{message=[]HistoryItem} "watch history"
+// @Failure      400  {object} apps.Respond.apiResponse{message=string} "bad date"
+// @Failure      500  {object} apps.Respond.apiResponse{message=string} "history file error"
+// @Failure      404  {object} string "bad token or api key"
+// @Router       /api/plex/1/history [get]
+// @Security     ApiKeyAuth
+func (a *Action) HandleHistory(req *http.Request) (int, interface{}) {
+	filter, err := parseHistoryFilter(req)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	items, err := a.cmd.history.query(filter)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, items
+}
+
+// HandleHistoryUsers returns a summary of the local Plex watch history for each user.
+// @Description  Returns the number of plays and time watched for each user in the local Plex watch history.
+// @Description  Filter by item (title, rating key or GUID) and date range.
+// @Summary      Retrieve Plex watch history by user.
+// @Tags         Plex
+// @Produce      json
+// @Param        item   query   string  false  "Part of a title, or a rating key or GUID"
+// @Param        start  query   string  false  "Oldest date to include: 2006-01-02 or RFC3339"
+// @Param        end    query   string  false  "Newest date to include: 2006-01-02 or RFC3339"
+// @Success      200  {object} apps.Respond.apiResponse{message=[]HistoryUser} "watch history by user"
+// @Failure      400  {object} apps.Respond.apiResponse{message=string} "bad date"
+// @Failure      500  {object} apps.Respond.apiResponse{message=string} "history file error"
+// @Failure      404  {object} string "bad token or api key"
+// @Router       /api/plex/1/history/users [get]
+// @Security     ApiKeyAuth
+func (a *Action) HandleHistoryUsers(req *http.Request) (int, interface{}) {
+	filter, err := parseHistoryFilter(req)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	users, err := a.cmd.history.users(filter)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, users
+}
@@ -0,0 +1,113 @@
+package plexcron //nolint:testpackage // the history index is not exported.
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/plex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golift.io/cnfg"
+)
+
+func testHistoryItem(user, title string, stopped time.Time) *HistoryItem {
+	return &HistoryItem{Instance: 1, User: user, UserID: "id-" + user, Title: title, Stopped: stopped, ViewOffset: 1000}
+}
+
+// historyTitles returns the title of each item, so the tests can compare them.
+func historyTitles(items []*HistoryItem) []string {
+	titles := []string{}
+	for _, item := range items {
+		titles = append(titles, item.Title)
+	}
+
+	return titles
+}
+
+func TestHistoryDisabled(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), HistoryFileName)
+
+	assert.False(t, newHistory(nil, file).enabled(), "history must be opt-in")
+	assert.False(t, newHistory(&HistoryConfig{}, file).enabled(), "history must be opt-in")
+	assert.False(t, newHistory(&HistoryConfig{Enabled: true}, "").enabled(), "history needs a file")
+	assert.True(t, newHistory(&HistoryConfig{Enabled: true}, file).enabled())
+	assert.DirExists(t, filepath.Dir(file))
+	assert.NoFileExists(t, file, "nothing is written until a session ends")
+}
+
+func TestHistoryQuery(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	file := filepath.Join(t.TempDir(), HistoryFileName)
+	hist := newHistory(&HistoryConfig{Enabled: true, MaxAge: cnfg.Duration{Duration: 24 * time.Hour}}, file)
+
+	require.NoError(t, hist.add(
+		testHistoryItem("bob", "Too Old", now.Add(-48*time.Hour)),
+		testHistoryItem("bob", "Movie One", now.Add(-3*time.Hour)),
+		testHistoryItem("alice", "Show", now.Add(-2*time.Hour)),
+	))
+	require.NoError(t, hist.add(testHistoryItem("bob", "Movie Two", now.Add(-time.Hour))))
+
+	tests := []struct {
+		name   string
+		filter historyFilter
+		want   []string
+	}{
+		{name: "all", filter: historyFilter{}, want: []string{"Movie Two", "Show", "Movie One"}},
+		{name: "limit", filter: historyFilter{limit: 2}, want: []string{"Movie Two", "Show"}},
+		{name: "user", filter: historyFilter{user: "BOB"}, want: []string{"Movie Two", "Movie One"}},
+		{name: "user id", filter: historyFilter{user: "id-alice"}, want: []string{"Show"}},
+		{name: "item", filter: historyFilter{item: "movie"}, want: []string{"Movie Two", "Movie One"}},
+		{name: "start", filter: historyFilter{start: now.Add(-90 * time.Minute)}, want: []string{"Movie Two"}},
+		{name: "end", filter: historyFilter{end: now.Add(-90 * time.Minute)}, want: []string{"Show", "Movie One"}},
+		{name: "instance", filter: historyFilter{instance: 2}, want: []string{}},
+	}
+
+	for _, test := range tests {
+		items, err := hist.query(&test.filter)
+		require.NoError(t, err, test.name)
+		assert.Equal(t, test.want, historyTitles(items), test.name)
+	}
+
+	users, err := hist.users(&historyFilter{})
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, "bob", users[0].User, "the user with the most plays is first")
+	assert.Equal(t, 2, users[0].Plays, "pruned items are not counted")
+	assert.InDelta(t, 2000, users[0].Watched, 0)
+
+	// A new history reads the file once, and prunes the old items it finds.
+	items, err := newHistory(hist.HistoryConfig, file).query(&historyFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Movie Two", "Show", "Movie One"}, historyTitles(items))
+}
+
+func TestTrackHistoryAddress(t *testing.T) {
+	t.Parallel()
+
+	for _, saveIPs := range []bool{false, true} {
+		cmd := &cmd{history: newHistory(&HistoryConfig{Enabled: true, SaveIPs: saveIPs},
+			filepath.Join(t.TempDir(), HistoryFileName))}
+
+		session := &plex.Session{Title: "Movie", Player: plex.Player{Address: "10.1.2.3"}}
+		session.Session.ID = "one"
+		previous := &plex.Sessions{Sessions: []*plex.Session{session}}
+
+		cmd.trackHistory(0, previous, nil)
+		cmd.trackHistory(0, &plex.Sessions{}, previous)
+
+		items, err := cmd.history.query(&historyFilter{})
+		require.NoError(t, err)
+		require.Len(t, items, 1, "the finished session must be saved")
+
+		if saveIPs {
+			assert.Equal(t, "10.1.2.3", items[0].Address)
+		} else {
+			assert.Empty(t, items[0].Address, "player addresses are only saved when asked")
+		}
+	}
+}
@@ -19,8 +19,8 @@ const (
 // sendSessionNew is used when the end user does not have or use Plex webhooks.
 // They can enable the plex session tracker to send notifications for new sessions.
 // event is either media.play or media.resume.
-func (c *cmd) sendSessionPlaying(ctx context.Context, session *plex.Session, sessions *plex.Sessions, event string) {
-	if err := c.checkPlexAgent(ctx, session); err != nil {
+func (c *cmd) sendSessionPlaying(ctx context.Context, idx int, session *plex.Session, sessions *plex.Sessions, event string) {
+	if err := c.checkPlexAgent(ctx, idx, session); err != nil {
 		c.Errorf("Failed Plex Request: %v", err)
 		return
 	}
@@ -34,7 +34,7 @@ func (c *cmd) sendSessionPlaying(ctx context.Context, session *plex.Session, ses
 			Load: convertSessionsToWebhook(session, event),
 		},
 		LogMsg: fmt.Sprintf("Plex New Session on %s {%s/%s} %s => %s: %s (%s)",
-			c.Plex.Server.Name(), session.Session.ID, session.SessionKey, session.User.Title,
+			sessions.Name, session.Session.ID, session.SessionKey, session.User.Title,
 			session.Type, session.Title, session.Player.State),
 		LogPayload: true,
 	})
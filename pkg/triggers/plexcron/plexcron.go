@@ -2,6 +2,7 @@ package plexcron
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"time"
 
@@ -26,8 +27,14 @@ type Action struct {
 
 type cmd struct {
 	*common.Config
-	Plex *apps.PlexConfig
+	Plex apps.PlexServers
 	sent map[string]struct{} // Tracks Finished sessions already sent.
+	// Tracks the last import history ID for each starr app; used for Plex auto scan.
+	imports map[string]int64
+	// Policies are checked against every session. Violations tracks when a session first broke a policy.
+	Policies   []*Policy
+	violations map[string]time.Time
+	history    *history
 	sync.Mutex
 }
 
@@ -44,12 +51,23 @@ const (
 )
 
 // New configures the library.
-func New(config *common.Config, plex *apps.PlexConfig) *Action {
+// Watch history is only saved when it's enabled and a history file is provided.
+func New(
+	config *common.Config,
+	plex apps.PlexServers,
+	policies []*Policy,
+	history *HistoryConfig,
+	historyFile string,
+) *Action {
 	return &Action{
 		cmd: &cmd{
-			Config: config,
-			Plex:   plex,
-			sent:   make(map[string]struct{}),
+			Config:     config,
+			Plex:       plex,
+			sent:       make(map[string]struct{}),
+			imports:    make(map[string]int64),
+			Policies:   policies,
+			violations: make(map[string]time.Time),
+			history:    newHistory(history, historyFile),
 		},
 	}
 }
@@ -62,6 +80,9 @@ func (a *Action) Send(event website.EventType) {
 // Run initializes the library.
 func (a *Action) Create() {
 	a.cmd.run()
+	a.cmd.setupScan()
+	a.cmd.setupPolicies()
+	a.cmd.setupHistory()
 }
 
 func (c *cmd) run() {
@@ -76,8 +97,8 @@ func (c *cmd) run() {
 	if cfg.Interval.Duration > 0 {
 		randomTime := time.Duration(c.Config.Rand().Intn(randomMilliseconds)) * time.Millisecond
 		dur = cfg.Interval.Duration + randomTime
-		c.Printf("==> Plex Sessions Collection Started, URL: %s, interval:%s timeout:%s webhook_cooldown:%v delay:%v",
-			c.Plex.URL, cfg.Interval, c.Plex.Timeout, cfg.Cooldown, cfg.Delay)
+		c.Printf("==> Plex Sessions Collection Started, servers: %d, interval:%s webhook_cooldown:%v delay:%v",
+			len(c.Plex), cfg.Interval, cfg.Cooldown, cfg.Delay)
 	}
 
 	c.Add(&common.Action{
@@ -88,8 +109,8 @@ func (c *cmd) run() {
 	})
 
 	if cfg.MoviesPC != 0 || cfg.SeriesPC != 0 || cfg.TrackSess {
-		c.Printf("==> Plex Sessions Tracker Started, URL: %s, interval:1m timeout:%s movies:%d%% series:%d%% play:%v",
-			c.Plex.URL, c.Plex.Timeout, cfg.MoviesPC, cfg.SeriesPC, cfg.TrackSess)
+		c.Printf("==> Plex Sessions Tracker Started, servers: %d, interval:1m movies:%d%% series:%d%% play:%v",
+			len(c.Plex), cfg.MoviesPC, cfg.SeriesPC, cfg.TrackSess)
 
 		c.Add(&common.Action{
 			Name: "Checking Plex for completed sessions.",
@@ -107,17 +128,25 @@ func (a *Action) SendWebhook(hook *plex.IncomingWebhook) {
 }
 
 func (c *cmd) sendWebhook(hook *plex.IncomingWebhook) {
-	sessions := &plex.Sessions{Name: c.Plex.Server.Name()}
+	sessions := &plex.Sessions{Name: hook.Server.Title}
 	ci := clientinfo.Get()
 	ctx := context.Background()
 
+	idx := c.findServer(hook.Server.UUID)
+	if idx < 0 {
+		c.Errorf("Plex webhook from %s (%s) matches no configured server UUID; not collecting sessions.",
+			hook.Server.Title, hook.Server.UUID)
+	} else {
+		sessions.Name = c.Plex[idx].Server.Name()
+	}
+
 	// If NoActivity=false, then grab sessions, but wait 'Delay' to make sure they're updated.
-	if ci != nil && !ci.Actions.Plex.NoActivity {
+	if idx >= 0 && ci != nil && !ci.Actions.Plex.NoActivity {
 		time.Sleep(ci.Actions.Plex.Delay.Duration)
-		ctx, cancel := context.WithTimeout(ctx, c.Plex.Timeout.Duration)
+		ctx, cancel := context.WithTimeout(ctx, c.Plex[idx].Timeout.Duration)
 
 		var err error
-		if sessions, err = c.getSessions(ctx, time.Second); err != nil {
+		if sessions, err = c.getSessions(ctx, idx, time.Second); err != nil {
 			c.Errorf("Getting Plex sessions: %v", err)
 		}
 
@@ -136,9 +165,56 @@ func (c *cmd) sendWebhook(hook *plex.IncomingWebhook) {
 	})
 }
 
-// GetSessions returns the plex sessions up to 1 minute old. This uses a channel so concurrent requests are avoided.
-func (a *Action) GetSessions(ctx context.Context) (*plex.Sessions, error) {
-	return a.cmd.getSessions(ctx, time.Minute)
+// FindServer returns the index of the enabled server with the provided uuid (machine identifier).
+// If none match, it returns the only enabled server, or -1 when zero or several servers are enabled.
+func (a *Action) FindServer(uuid string) int {
+	return a.cmd.findServer(uuid)
+}
+
+func (c *cmd) findServer(uuid string) int {
+	only, enabled := -1, 0
+
+	for idx, server := range c.Plex {
+		if !server.Enabled() {
+			continue
+		} else if uuid != "" && server.Server.UUID() == uuid {
+			return idx
+		}
+
+		only = idx
+		enabled++
+	}
+
+	if enabled != 1 {
+		// Guessing between several servers would mix up their data.
+		return -1
+	}
+
+	return only
+}
+
+// GetSessions returns the plex sessions, up to 1 minute old, from every enabled server.
+// Sessions from servers that returned an error are included with the error.
+func (a *Action) GetSessions(ctx context.Context) ([]*plex.Sessions, error) {
+	var (
+		output = []*plex.Sessions{}
+		errs   []error
+	)
+
+	for idx, server := range a.cmd.Plex {
+		if !server.Enabled() {
+			continue
+		}
+
+		sessions, err := a.cmd.getSessions(ctx, idx, time.Minute)
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		output = append(output, sessions)
+	}
+
+	return output, errors.Join(errs...)
 }
 
 // GetMetaSnap grabs some basic system info: cpu, memory, username. Gets added to Plex sessions and webhook payloads.
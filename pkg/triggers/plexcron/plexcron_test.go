@@ -0,0 +1,69 @@
+package plexcron //nolint:testpackage // findServer is not exported.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/plex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testServers returns a server for each uuid. An empty uuid makes a disabled server.
+func testServers(t *testing.T, uuids ...string) apps.PlexServers {
+	t.Helper()
+
+	// Each server's machine identifier is its URL path.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"MediaContainer":{"machineIdentifier":%q}}`, r.URL.Path[1:])
+	}))
+	t.Cleanup(srv.Close)
+
+	servers := apps.PlexServers{}
+
+	for _, uuid := range uuids {
+		config := &plex.Config{}
+		if uuid != "" {
+			config = &plex.Config{URL: srv.URL + "/" + uuid, Token: "token"}
+		}
+
+		server := &apps.PlexConfig{Config: config, Server: plex.New(config)}
+		if uuid != "" {
+			_, err := server.GetInfo(context.Background())
+			require.NoError(t, err)
+		}
+
+		servers = append(servers, server)
+	}
+
+	return servers
+}
+
+func TestFindServer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		servers []string
+		uuid    string
+		expect  int
+	}{
+		{name: "match", servers: []string{"one", "two"}, uuid: "two", expect: 1},
+		{name: "match after disabled", servers: []string{"", "two"}, uuid: "two", expect: 1},
+		{name: "only server", servers: []string{"", "two"}, uuid: "three", expect: 1},
+		{name: "only server without uuid", servers: []string{"one"}, uuid: "", expect: 0},
+		{name: "several servers", servers: []string{"one", "two"}, uuid: "three", expect: -1},
+		{name: "several servers without uuid", servers: []string{"one", "two"}, uuid: "", expect: -1},
+		{name: "no enabled servers", servers: []string{""}, uuid: "one", expect: -1},
+		{name: "no servers", servers: nil, uuid: "one", expect: -1},
+	}
+
+	for _, test := range tests {
+		cmd := &cmd{Plex: testServers(t, test.servers...)}
+		assert.Equal(t, test.expect, cmd.findServer(test.uuid), test.name)
+	}
+}
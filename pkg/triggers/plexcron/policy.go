@@ -0,0 +1,369 @@
+package plexcron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/plex"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
+	"github.com/Notifiarr/notifiarr/pkg/website"
+	"golift.io/cnfg"
+)
+
+/* This file contains the procedures to check Plex sessions against user-defined policies. */
+
+const TrigPlexPolicy common.TriggerName = "Checking Plex sessions against policies."
+
+// ErrInvalidPolicy is returned when a policy has a bad rule or action.
+var ErrInvalidPolicy = errors.New("invalid plex policy")
+
+// Policy rules. Each policy has one rule.
+const (
+	RuleNo4KTranscode = "no_4k_transcode"    // 4K video may not be transcoded.
+	RuleNoTranscode   = "no_transcode"       // Video in the policy's libraries may not be transcoded.
+	RuleMaxRemote     = "max_remote_streams" // Each user may only have Max remote streams.
+	RuleMaxBandwidth  = "max_bandwidth"      // All sessions combined may only use Max kbps.
+)
+
+// Policy actions. This is what happens to a session that breaks a policy rule.
+// Plex cannot message a viewer except when killing a session, so warn notifies first,
+// and kills the session (with the reason) if it still breaks the rule after the grace period.
+const (
+	ActionNotify = "notify"
+	ActionWarn   = "warn"
+	ActionKill   = "kill"
+)
+
+const (
+	// How long a warned session may break a rule before it's killed.
+	defaultPolicyGrace = 2 * time.Minute
+	// Plex reports this resolution for 4K video.
+	resolution4K = "4k"
+	// Video this wide or wider is 4K.
+	width4K = 3840
+	// Plex uses this decision when a stream is transcoded.
+	transcode = "transcode"
+)
+
+// Policy is a rule that every Plex session is checked against.
+type Policy struct {
+	Name      string        `json:"name"      toml:"name"      xml:"name"      yaml:"name"`
+	Rule      string        `json:"rule"      toml:"rule"      xml:"rule"      yaml:"rule"`
+	Action    string        `json:"action"    toml:"action"    xml:"action"    yaml:"action"`
+	Max       int64         `json:"max"       toml:"max"       xml:"max"       yaml:"max"`
+	Libraries []string      `json:"libraries" toml:"libraries" xml:"library"   yaml:"libraries"`
+	Exempt    []string      `json:"exempt"    toml:"exempt"    xml:"exempt"    yaml:"exempt"`
+	Reason    string        `json:"reason"    toml:"reason"    xml:"reason"    yaml:"reason"`
+	Grace     cnfg.Duration `json:"grace"     toml:"grace"     xml:"grace"     yaml:"grace"`
+}
+
+// PolicyViolation is sent to the website when a session breaks a policy.
+type PolicyViolation struct {
+	Server  string        `json:"server"`
+	Policy  string        `json:"policy"`
+	Rule    string        `json:"rule"`
+	Action  string        `json:"action"`
+	Killed  bool          `json:"killed"`
+	Error   string        `json:"error,omitempty"`
+	Session *plex.Session `json:"session"`
+}
+
+// Validate checks a policy for errors, and sets defaults.
+func (p *Policy) Validate() error {
+	switch p.Rule {
+	case RuleNo4KTranscode, RuleNoTranscode:
+	case RuleMaxRemote, RuleMaxBandwidth:
+		if p.Max < 1 {
+			return fmt.Errorf("%w: policy '%s' rule %s requires max above 0", ErrInvalidPolicy, p.Name, p.Rule)
+		}
+	default:
+		return fmt.Errorf("%w: policy '%s' has unknown rule: %s", ErrInvalidPolicy, p.Name, p.Rule)
+	}
+
+	switch p.Action {
+	case ActionNotify, ActionWarn, ActionKill:
+	case "":
+		p.Action = ActionNotify
+	default:
+		return fmt.Errorf("%w: policy '%s' has unknown action: %s", ErrInvalidPolicy, p.Name, p.Action)
+	}
+
+	if p.Name == "" {
+		p.Name = p.Rule
+	}
+
+	if p.Grace.Duration <= 0 {
+		p.Grace.Duration = defaultPolicyGrace
+	}
+
+	if p.Reason == "" {
+		p.Reason = "This stream breaks the server policy: " + p.Name
+	}
+
+	return nil
+}
+
+func (c *cmd) setupPolicies() {
+	if !c.Plex.Enabled() || len(c.Policies) == 0 {
+		return
+	}
+
+	for _, policy := range c.Policies {
+		if err := policy.Validate(); err != nil {
+			c.Errorf("Plex policies disabled: %v", err)
+			return
+		}
+	}
+
+	c.Printf("==> Plex Policy Enforcement Started, servers: %d, policies: %d, interval:1m", len(c.Plex), len(c.Policies))
+
+	c.Add(&common.Action{
+		Name: TrigPlexPolicy,
+		Hide: true, // do not log this one.
+		Fn:   c.checkPolicies,
+		D: cnfg.Duration{Duration: time.Minute +
+			time.Duration(c.Config.Rand().Intn(randomMilliseconds2))*time.Millisecond},
+	})
+}
+
+// checkPolicies runs on an interval and checks every session on every server against every policy.
+func (c *cmd) checkPolicies(ctx context.Context, input *common.ActionInput) {
+	violations := []*PolicyViolation{}
+	current := make(map[string]time.Time)
+
+	for idx, server := range c.Plex {
+		if !server.Enabled() {
+			continue
+		}
+
+		sessions, err := c.getSessions(ctx, idx, time.Minute)
+		if err != nil {
+			c.Errorf("[%s requested] Getting Plex %d sessions for policies: %v", input.Type, idx+1, err)
+			continue
+		}
+
+		is4K := c.is4KFunc(ctx, idx)
+
+		for pIdx, policy := range c.Policies {
+			for _, session := range policy.check(sessions.Sessions, is4K) {
+				key := fmt.Sprint(idx, "/", pIdx, "/", session.Session.ID)
+
+				first, seen := c.violations[key]
+				if !seen {
+					first = time.Now()
+				}
+
+				current[key] = first
+
+				if violation := c.enforcePolicy(ctx, idx, policy, session, seen, first); violation != nil {
+					violations = append(violations, violation)
+				}
+			}
+		}
+	}
+
+	c.violations = current // Forget sessions that are gone or fixed.
+
+	if len(violations) == 0 {
+		return
+	}
+
+	c.SendData(&website.Request{
+		Route:      website.PolicyRoute,
+		Event:      input.Type,
+		Payload:    map[string]any{"violations": violations},
+		LogMsg:     fmt.Sprintf("Plex Policy Violations (%d)", len(violations)),
+		LogPayload: true,
+	})
+}
+
+// enforcePolicy takes the policy's action on a session. Returns a violation to send to the website, or nil.
+func (c *cmd) enforcePolicy(
+	ctx context.Context,
+	idx int,
+	policy *Policy,
+	session *plex.Session,
+	seen bool,
+	first time.Time,
+) *PolicyViolation {
+	violation := &PolicyViolation{
+		Server:  c.Plex[idx].Server.Name(),
+		Policy:  policy.Name,
+		Rule:    policy.Rule,
+		Action:  policy.Action,
+		Session: session,
+	}
+
+	switch {
+	case policy.Action == ActionKill,
+		policy.Action == ActionWarn && seen && time.Since(first) >= policy.Grace.Duration:
+		violation.Killed = true
+
+		if _, err := c.Plex[idx].KillSessionWithContext(ctx, session.Session.ID, policy.Reason); err != nil {
+			violation.Killed = false
+			violation.Error = err.Error()
+			c.Errorf("Killing Plex %d session %s (%s) for policy '%s': %v",
+				idx+1, session.Session.ID, session.User.Title, policy.Name, err)
+		} else {
+			c.Printf("Killed Plex %d session %s (%s) for policy '%s': %s",
+				idx+1, session.Session.ID, session.User.Title, policy.Name, session.Title)
+		}
+
+		return violation
+	case seen:
+		return nil // Already notified.
+	default:
+		c.Printf("Plex %d session %s (%s) breaks policy '%s' (%s): %s",
+			idx+1, session.Session.ID, session.User.Title, policy.Name, policy.Action, session.Title)
+		return violation
+	}
+}
+
+// check returns the sessions that break the policy rule.
+func (p *Policy) check(sessions []*plex.Session, is4K func(*plex.Session) bool) []*plex.Session {
+	list := []*plex.Session{}
+
+	for _, session := range sessions {
+		if !p.exempt(session) {
+			list = append(list, session)
+		}
+	}
+
+	switch p.Rule {
+	case RuleNo4KTranscode:
+		return filterSessions(list, func(s *plex.Session) bool {
+			return s.TranscodeSession.VideoDecision == transcode && is4K(s)
+		})
+	case RuleNoTranscode:
+		return filterSessions(list, func(s *plex.Session) bool {
+			return s.TranscodeSession.VideoDecision == transcode && p.library(s)
+		})
+	case RuleMaxRemote:
+		return p.checkRemote(list)
+	case RuleMaxBandwidth:
+		return p.checkBandwidth(list)
+	default:
+		return nil
+	}
+}
+
+// checkRemote returns each user's newest remote sessions above the maximum.
+func (p *Policy) checkRemote(sessions []*plex.Session) []*plex.Session {
+	output := []*plex.Session{}
+	counts := make(map[string]int64)
+
+	for _, session := range sortSessions(sessions) {
+		if session.Player.Local || session.Session.Location == "lan" {
+			continue
+		}
+
+		if counts[session.User.ID]++; counts[session.User.ID] > p.Max {
+			output = append(output, session)
+		}
+	}
+
+	return output
+}
+
+// checkBandwidth returns the newest sessions that put the total bandwidth above the maximum.
+func (p *Policy) checkBandwidth(sessions []*plex.Session) []*plex.Session {
+	output := []*plex.Session{}
+	total := int64(0)
+
+	for _, session := range sortSessions(sessions) {
+		if total += session.Session.Bandwidth; total > p.Max {
+			output = append(output, session)
+		}
+	}
+
+	return output
+}
+
+// exempt returns true if the session's user is exempt from the policy.
+func (p *Policy) exempt(session *plex.Session) bool {
+	for _, user := range p.Exempt {
+		if strings.EqualFold(user, session.User.Title) || user == session.User.ID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// library returns true if the session's library is part of the policy. No libraries means all of them.
+func (p *Policy) library(session *plex.Session) bool {
+	for _, library := range p.Libraries {
+		if strings.EqualFold(library, session.LibrarySectionTitle) || library == session.LibrarySectionID {
+			return true
+		}
+	}
+
+	return len(p.Libraries) == 0
+}
+
+// is4KFunc returns a function that checks if a session's source video is 4K.
+// When transcoding, Plex reports the transcoded resolution, so the source metadata is checked too.
+func (c *cmd) is4KFunc(ctx context.Context, idx int) func(*plex.Session) bool {
+	cache := make(map[string]bool)
+
+	return func(session *plex.Session) bool {
+		for _, media := range session.Media {
+			if media.VideoResolution == resolution4K || media.Width >= width4K {
+				return true
+			}
+		}
+
+		if found, ok := cache[session.Key]; ok {
+			return found
+		}
+
+		cache[session.Key] = false
+
+		section, err := c.Plex[idx].GetPlexSectionKeyWithContext(ctx, session.Key)
+		if err != nil {
+			c.Errorf("Getting Plex %d metadata for %s: %v", idx+1, session.Key, err)
+			return false
+		}
+
+		for _, metadata := range section.Metadata {
+			for _, media := range metadata.Media {
+				cache[session.Key] = cache[session.Key] || media.VideoResolution == resolution4K || media.Width >= width4K
+			}
+		}
+
+		return cache[session.Key]
+	}
+}
+
+// filterSessions returns the sessions that match.
+func filterSessions(sessions []*plex.Session, match func(*plex.Session) bool) []*plex.Session {
+	output := []*plex.Session{}
+
+	for _, session := range sessions {
+		if match(session) {
+			output = append(output, session)
+		}
+	}
+
+	return output
+}
+
+// sortSessions returns a copy of the sessions sorted oldest first. Plex increments the session key for new sessions.
+func sortSessions(sessions []*plex.Session) []*plex.Session {
+	sorted := make([]*plex.Session, len(sessions))
+	copy(sorted, sessions)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		left, _ := strconv.Atoi(sorted[i].SessionKey)
+		right, _ := strconv.Atoi(sorted[j].SessionKey)
+
+		return left < right
+	})
+
+	return sorted
+}
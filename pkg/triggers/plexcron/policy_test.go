@@ -0,0 +1,113 @@
+package plexcron //nolint:testpackage // policy checks are not exported.
+
+import (
+	"testing"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/plex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSession returns a plex session for policy tests.
+// The key is the session key; Plex makes newer sessions with higher keys.
+func testSession(key, user, library, decision, location string, bandwidth int64) *plex.Session {
+	session := &plex.Session{
+		SessionKey:          key,
+		LibrarySectionTitle: library,
+		User:                plex.User{ID: "id-" + user, Title: user},
+		TranscodeSession:    plex.Transcode{VideoDecision: decision},
+	}
+	session.Session.ID = "session-" + key
+	session.Session.Location = location
+	session.Session.Bandwidth = bandwidth
+
+	return session
+}
+
+// sessionKeys returns the session keys, so the tests can compare them.
+func sessionKeys(sessions []*plex.Session) []string {
+	keys := []string{}
+	for _, session := range sessions {
+		keys = append(keys, session.SessionKey)
+	}
+
+	return keys
+}
+
+func TestPolicyCheck(t *testing.T) {
+	t.Parallel()
+
+	sessions := []*plex.Session{
+		testSession("3", "bob", "Movies", transcode, "wan", 8000),
+		testSession("1", "bob", "Movies", "copy", "wan", 4000),
+		testSession("2", "alice", "TV", transcode, "wan", 3000),
+		testSession("10", "bob", "TV", "directplay", "wan", 2000),
+		testSession("4", "carol", "Movies", transcode, "lan", 1000),
+	}
+	is4K := func(session *plex.Session) bool { return session.LibrarySectionTitle == "Movies" }
+
+	tests := []struct {
+		name   string
+		policy Policy
+		want   []string
+	}{
+		{name: "4k transcode", policy: Policy{Rule: RuleNo4KTranscode}, want: []string{"3", "4"}},
+		{name: "4k transcode exempt name", policy: Policy{Rule: RuleNo4KTranscode, Exempt: []string{"BOB"}},
+			want: []string{"4"}},
+		{name: "4k transcode exempt id", policy: Policy{Rule: RuleNo4KTranscode, Exempt: []string{"id-carol"}},
+			want: []string{"3"}},
+		{name: "transcode", policy: Policy{Rule: RuleNoTranscode}, want: []string{"3", "2", "4"}},
+		{name: "transcode library", policy: Policy{Rule: RuleNoTranscode, Libraries: []string{"tv"}},
+			want: []string{"2"}},
+		{name: "remote", policy: Policy{Rule: RuleMaxRemote, Max: 1}, want: []string{"3", "10"}},
+		{name: "remote two", policy: Policy{Rule: RuleMaxRemote, Max: 2}, want: []string{"10"}},
+		{name: "bandwidth", policy: Policy{Rule: RuleMaxBandwidth, Max: 10000}, want: []string{"3", "4", "10"}},
+		{name: "bandwidth high", policy: Policy{Rule: RuleMaxBandwidth, Max: 20000}, want: []string{}},
+		{name: "unknown", policy: Policy{Rule: "nope"}, want: []string{}},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, sessionKeys(test.policy.check(sessions, is4K)), test.name)
+	}
+}
+
+func TestPolicyCheckLocal(t *testing.T) {
+	t.Parallel()
+
+	local := testSession("5", "bob", "Movies", "copy", "wan", 0)
+	local.Player.Local = true
+	sessions := []*plex.Session{testSession("1", "bob", "Movies", "copy", "lan", 0), local}
+
+	assert.Empty(t, (&Policy{Rule: RuleMaxRemote, Max: 1}).check(sessions, nil), "local streams are not remote")
+}
+
+func TestPolicyValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		policy Policy
+		valid  bool
+	}{
+		{name: "4k", policy: Policy{Rule: RuleNo4KTranscode}, valid: true},
+		{name: "remote", policy: Policy{Rule: RuleMaxRemote, Max: 2, Action: ActionKill}, valid: true},
+		{name: "remote no max", policy: Policy{Rule: RuleMaxRemote}, valid: false},
+		{name: "bandwidth no max", policy: Policy{Rule: RuleMaxBandwidth}, valid: false},
+		{name: "bad rule", policy: Policy{Rule: "nope"}, valid: false},
+		{name: "bad action", policy: Policy{Rule: RuleNoTranscode, Action: "nope"}, valid: false},
+	}
+
+	for _, test := range tests {
+		err := test.policy.Validate()
+		if !test.valid {
+			require.ErrorIs(t, err, ErrInvalidPolicy, test.name)
+			continue
+		}
+
+		require.NoError(t, err, test.name)
+		assert.NotEmpty(t, test.policy.Name, test.name)
+		assert.NotEmpty(t, test.policy.Action, test.name)
+		assert.NotEmpty(t, test.policy.Reason, test.name)
+		assert.Equal(t, defaultPolicyGrace, test.policy.Grace.Duration, test.name)
+	}
+}
@@ -0,0 +1,223 @@
+package plexcron
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/plex"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
+	"github.com/Notifiarr/notifiarr/pkg/website"
+	"golift.io/cnfg"
+	"golift.io/starr"
+	"golift.io/starr/radarr"
+	"golift.io/starr/sonarr"
+)
+
+/* This file contains the procedures to run partial Plex library scans after Radarr and Sonarr imports. */
+
+const (
+	TrigPlexScan     common.TriggerName = "Scanning paths in Plex libraries."
+	TrigPlexAutoScan common.TriggerName = "Checking Radarr and Sonarr for imports to scan in Plex."
+)
+
+const (
+	// How often to check Radarr and Sonarr history for new imports.
+	autoScanInterval = time.Minute
+	// How many import history records to inspect from each starr app.
+	autoScanHistory = 50
+)
+
+// ScanPaths asks every enabled Plex server to scan the library section that contains each path.
+// The paths are translated with each server's path maps first, so Radarr and Sonarr paths are fine.
+func (a *Action) ScanPaths(event website.EventType, paths ...string) {
+	a.cmd.Exec(&common.ActionInput{Type: event, Args: paths}, TrigPlexScan)
+}
+
+func (c *cmd) setupScan() {
+	if !c.Plex.Enabled() {
+		return
+	}
+
+	c.Add(&common.Action{
+		Name: TrigPlexScan,
+		Fn:   c.scanPaths,
+		C:    make(chan *common.ActionInput, 1),
+	})
+
+	if !c.autoScanEnabled() {
+		return
+	}
+
+	c.Printf("==> Plex Auto Scan Started, interval:%v radarr:%d sonarr:%d",
+		autoScanInterval, len(c.Apps.Radarr), len(c.Apps.Sonarr))
+
+	c.Add(&common.Action{
+		Name: TrigPlexAutoScan,
+		Hide: true, // do not log this one.
+		Fn:   c.checkImports,
+		D:    cnfg.Duration{Duration: autoScanInterval},
+	})
+}
+
+// autoScanEnabled returns true if a Plex server has auto scan enabled, and there's a Radarr or Sonarr to watch.
+func (c *cmd) autoScanEnabled() bool {
+	var starrs bool
+
+	for _, app := range c.Apps.Radarr {
+		starrs = starrs || app.Enabled()
+	}
+
+	for _, app := range c.Apps.Sonarr {
+		starrs = starrs || app.Enabled()
+	}
+
+	if !starrs {
+		return false
+	}
+
+	for _, server := range c.Plex {
+		if server.Enabled() && server.AutoScan {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *cmd) scanPaths(ctx context.Context, input *common.ActionInput) {
+	c.scan(ctx, input.Type, input.Args, false)
+}
+
+// scan requests a partial library scan for each path on every enabled Plex server.
+// When auto is true, only servers with auto scan enabled are used.
+func (c *cmd) scan(ctx context.Context, event website.EventType, paths []string, auto bool) {
+	for idx, server := range c.Plex {
+		if !server.Enabled() || (auto && !server.AutoScan) {
+			continue
+		}
+
+		for _, filePath := range paths {
+			result, err := server.ScanPathWithContext(ctx, filePath)
+			switch {
+			case errors.Is(err, plex.ErrNoSection):
+				c.Debugf("[%s requested] Plex %d (%s) has no library for path: %s",
+					event, idx+1, server.Server.Name(), filePath)
+			case err != nil:
+				c.Errorf("[%s requested] Scanning Plex %d (%s) path %s: %v",
+					event, idx+1, server.Server.Name(), filePath, err)
+			default:
+				c.Printf("[%s requested] Scanning Plex %d (%s) library '%s' path: %s",
+					event, idx+1, server.Server.Name(), result.Section, result.PlexPath)
+			}
+		}
+	}
+}
+
+// checkImports runs on an interval and scans the folders of newly imported Radarr and Sonarr files.
+func (c *cmd) checkImports(ctx context.Context, input *common.ActionInput) {
+	paths := append(c.getRadarrImports(ctx), c.getSonarrImports(ctx)...)
+	if len(paths) > 0 {
+		c.scan(ctx, input.Type, paths, true)
+	}
+}
+
+func (c *cmd) getRadarrImports(ctx context.Context) []string {
+	paths := []string{}
+
+	for idx, app := range c.Apps.Radarr {
+		if !app.Enabled() {
+			continue
+		}
+
+		history, err := app.GetHistoryPageContext(ctx, &starr.PageReq{
+			Page:     1,
+			PageSize: autoScanHistory,
+			SortDir:  starr.SortDescend,
+			SortKey:  "date",
+			Filter:   radarr.FilterDownloadFolderImported,
+		})
+		if err != nil {
+			c.Errorf("Getting Radarr %d history for Plex auto scan: %v", idx+1, err)
+			continue
+		}
+
+		imports := make(map[int64]string)
+		for _, rec := range history.Records {
+			imports[rec.ID] = rec.Data.ImportedPath
+		}
+
+		paths = append(paths, c.newImports("radarr"+app.URL, imports)...)
+	}
+
+	return paths
+}
+
+func (c *cmd) getSonarrImports(ctx context.Context) []string {
+	paths := []string{}
+
+	for idx, app := range c.Apps.Sonarr {
+		if !app.Enabled() {
+			continue
+		}
+
+		history, err := app.GetHistoryPageContext(ctx, &starr.PageReq{
+			Page:     1,
+			PageSize: autoScanHistory,
+			SortDir:  starr.SortDescend,
+			SortKey:  "date",
+			Filter:   sonarr.FilterDownloadFolderImported,
+		})
+		if err != nil {
+			c.Errorf("Getting Sonarr %d history for Plex auto scan: %v", idx+1, err)
+			continue
+		}
+
+		imports := make(map[int64]string)
+		for _, rec := range history.Records {
+			imports[rec.ID] = rec.Data.ImportedPath
+		}
+
+		paths = append(paths, c.newImports("sonarr"+app.URL, imports)...)
+	}
+
+	return paths
+}
+
+// newImports returns the unique parent folders for imports newer than the last import we saw.
+// Nothing is returned the first time an app is checked; existing history is not scanned.
+func (c *cmd) newImports(key string, imports map[int64]string) []string {
+	last, seen := c.imports[key]
+	folders := make(map[string]struct{})
+
+	for id, importedPath := range imports {
+		if id > c.imports[key] {
+			c.imports[key] = id
+		}
+
+		if seen && id > last && importedPath != "" {
+			folders[parentFolder(importedPath)] = struct{}{}
+		}
+	}
+
+	if !seen && len(imports) == 0 {
+		c.imports[key] = 0
+	}
+
+	paths := make([]string, 0, len(folders))
+	for folder := range folders {
+		paths = append(paths, folder)
+	}
+
+	return paths
+}
+
+// parentFolder returns the folder that contains a file. Works with Windows and Unix paths.
+func parentFolder(filePath string) string {
+	if idx := strings.LastIndexAny(filePath, `/\`); idx > 0 {
+		return filePath[:idx]
+	}
+
+	return filePath
+}
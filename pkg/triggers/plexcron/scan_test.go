@@ -0,0 +1,44 @@
+package plexcron //nolint:testpackage // the import tracker is not exported.
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParentFolder(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "/movies/Movie (2024)", parentFolder("/movies/Movie (2024)/movie.mkv"))
+	assert.Equal(t, `D:\TV\Show`, parentFolder(`D:\TV\Show\episode.mkv`))
+	assert.Equal(t, "file.mkv", parentFolder("file.mkv"))
+	assert.Equal(t, "/file.mkv", parentFolder("/file.mkv"), "the root folder is not returned")
+}
+
+func TestNewImports(t *testing.T) {
+	t.Parallel()
+
+	cmd := &cmd{imports: make(map[string]int64)}
+
+	paths := cmd.newImports("radarr", map[int64]string{5: "/movies/Old/old.mkv"})
+	assert.Empty(t, paths, "existing history must not be scanned the first time")
+	assert.EqualValues(t, 5, cmd.imports["radarr"])
+
+	paths = cmd.newImports("radarr", map[int64]string{
+		5: "/movies/Old/old.mkv",
+		6: "/movies/New/new.mkv",
+		7: "/movies/New/new.srt",
+		8: "/movies/Other/other.mkv",
+		9: "",
+	})
+	sort.Strings(paths)
+	assert.Equal(t, []string{"/movies/New", "/movies/Other"}, paths, "each new folder must be scanned once")
+	assert.EqualValues(t, 9, cmd.imports["radarr"])
+
+	assert.Empty(t, cmd.newImports("radarr", map[int64]string{9: "/movies/Again/again.mkv"}))
+	assert.Empty(t, cmd.newImports("sonarr", nil), "an app with no history is not scanned")
+	assert.Contains(t, cmd.imports, "sonarr", "an app with no history is seen")
+	assert.Equal(t, []string{"/tv/Show"}, cmd.newImports("sonarr", map[int64]string{1: "/tv/Show/ep.mkv"}),
+		"the first import for an app with no history must be scanned")
+}
@@ -14,29 +14,38 @@ import (
 )
 
 // sendPlexSessions is fired by a timer if Plex Sessions feature has an interval defined.
+// Each enabled server's sessions are sent separately.
 func (c *cmd) sendPlexSessions(ctx context.Context, input *common.ActionInput) {
-	sessions, err := c.getSessions(ctx, time.Minute)
-	if err != nil {
-		c.Errorf("Getting Plex sessions: %v", err)
-	}
+	for idx, server := range c.Plex {
+		if !server.Enabled() {
+			continue
+		}
+
+		sessions, err := c.getSessions(ctx, idx, time.Minute)
+		if err != nil {
+			c.Errorf("Getting Plex sessions: %v", err)
+		}
 
-	c.SendData(&website.Request{
-		Route:      website.PlexRoute,
-		Event:      input.Type,
-		Payload:    &website.Payload{Snap: c.getMetaSnap(ctx), Plex: sessions},
-		LogMsg:     "Plex Sessions",
-		LogPayload: true,
-	})
+		c.SendData(&website.Request{
+			Route:      website.PlexRoute,
+			Event:      input.Type,
+			Payload:    &website.Payload{Snap: c.getMetaSnap(ctx), Plex: sessions},
+			LogMsg:     fmt.Sprintf("Plex Sessions (%d)", idx+1),
+			LogPayload: true,
+		})
+	}
 }
 
 // getSessions interacts with the for loop/channels in runSessionHolder().
 // The Lock ensures only one request to Plex happens at once.
 // Because of the cache two requests may get the same answer.
-func (c *cmd) getSessions(ctx context.Context, allowedAge time.Duration) (*plex.Sessions, error) {
+func (c *cmd) getSessions(ctx context.Context, idx int, allowedAge time.Duration) (*plex.Sessions, error) {
 	c.Lock()
 	defer c.Unlock()
 
-	item := data.Get("plexCurrentSessions")
+	server := c.Plex[idx]
+
+	item := data.GetWithID("plexCurrentSessions", idx)
 	if item != nil && time.Now().Add(-allowedAge).Before(item.Time) && item.Data != nil {
 		return item.Data.(*plex.Sessions), nil //nolint:forcetypeassert
 	}
@@ -44,35 +53,38 @@ func (c *cmd) getSessions(ctx context.Context, allowedAge time.Duration) (*plex.
 	deadline, _ := ctx.Deadline()
 	start := time.Now()
 	timeout := deadline.Sub(start)
-	sessions, err := c.Plex.GetSessionsWithContext(ctx)
+	sessions, err := server.GetSessionsWithContext(ctx)
 
 	switch {
 	case errors.Is(err, context.DeadlineExceeded):
-		return &plex.Sessions{Name: c.Plex.Server.Name()}, fmt.Errorf("plex sessions timed out after %s: %w", timeout, err)
+		return &plex.Sessions{Name: server.Server.Name()}, fmt.Errorf("plex sessions timed out after %s: %w", timeout, err)
 	case errors.Is(err, context.Canceled):
-		return &plex.Sessions{Name: c.Plex.Server.Name()},
+		return &plex.Sessions{Name: server.Server.Name()},
 			fmt.Errorf("plex sessions cancelled after %s: %w", time.Since(start), err)
 	case err != nil:
-		return &plex.Sessions{Name: c.Plex.Server.Name()}, fmt.Errorf("plex sessions: %w", err)
-	case item != nil && item.Data != nil:
-		c.plexSessionTracker(ctx, sessions, item.Data.(*plex.Sessions)) //nolint:forcetypeassert
-	default:
-		c.plexSessionTracker(ctx, sessions, nil)
+		return &plex.Sessions{Name: server.Server.Name()}, fmt.Errorf("plex sessions: %w", err)
 	}
 
-	sessions.Name = c.Plex.Server.Name()
+	sessions.Name = server.Server.Name()
+
+	if item != nil && item.Data != nil {
+		c.plexSessionTracker(ctx, idx, sessions, item.Data.(*plex.Sessions)) //nolint:forcetypeassert
+	} else {
+		c.plexSessionTracker(ctx, idx, sessions, nil)
+	}
 
 	return sessions, nil
 }
 
 // plexSessionTracker checks for state changes between the previous session pull
 // and the current session pull. if changes are present, a timestmp is added.
-func (c *cmd) plexSessionTracker(ctx context.Context, current, previous *plex.Sessions) {
+func (c *cmd) plexSessionTracker(ctx context.Context, idx int, current, previous *plex.Sessions) {
 	now := time.Now()
 	info := clientinfo.Get()
 
 	// data.Save("plexPreviousSessions", previous)
-	data.Save("plexCurrentSessions", current)
+	data.SaveWithID("plexCurrentSessions", idx, current)
+	c.trackHistory(idx, current, previous)
 
 	for _, currSess := range current.Sessions {
 		// make sure every session has a start time.
@@ -81,16 +93,21 @@ func (c *cmd) plexSessionTracker(ctx context.Context, current, previous *plex.Se
 		switch {
 		case previous == nil:
 			continue // this only happens once.
-		case c.checkExistingSession(ctx, currSess, current, previous):
+		case c.checkExistingSession(ctx, idx, currSess, current, previous):
 			continue // existing session.
-		case currSess.Player.State == playing && info.Actions.Plex.TrackSess:
+		case currSess.Player.State == playing && info != nil && info.Actions.Plex.TrackSess:
 			// We are tracking sessions (no webhooks); send this brand new session to website.
-			c.sendSessionPlaying(ctx, currSess, current, mediaPlay)
+			c.sendSessionPlaying(ctx, idx, currSess, current, mediaPlay)
 		}
 	}
 }
 
-func (c *cmd) checkExistingSession(ctx context.Context, currSess *plex.Session, current, previous *plex.Sessions) bool {
+func (c *cmd) checkExistingSession(
+	ctx context.Context,
+	idx int,
+	currSess *plex.Session,
+	current, previous *plex.Sessions,
+) bool {
 	// now check if a current session matches a previous session
 	for _, prevSess := range previous.Sessions {
 		if currSess.Session.ID != prevSess.Session.ID {
@@ -104,9 +121,9 @@ func (c *cmd) checkExistingSession(ctx context.Context, currSess *plex.Session,
 		} else
 		// Check for a session that was paused and is now playing (resumed).
 		if ci := clientinfo.Get(); currSess.Player.State == playing &&
-			prevSess.Player.State == paused && ci.Actions.Plex.TrackSess {
+			prevSess.Player.State == paused && ci != nil && ci.Actions.Plex.TrackSess {
 			// Check if we're tracking sessions. If yes, send this resumed session.
-			c.sendSessionPlaying(ctx, currSess, current, mediaResume)
+			c.sendSessionPlaying(ctx, idx, currSess, current, mediaResume)
 		}
 
 		// we found this current session in previous session list, so go to the next one.
@@ -21,30 +21,34 @@ const maxQueuePayloadSize = 15
 
 type lidarrRecord struct {
 	*lidarr.QueueRecord
-	Name            string `json:"name"`
-	ArtistTitle     string `json:"artistTitle"`
-	ForeignAlbumID  string `json:"foreignAlbumId"`
-	ForeignArtistID string `json:"foreignArtistId"`
+	Name            string   `json:"name"`
+	ArtistTitle     string   `json:"artistTitle"`
+	ForeignAlbumID  string   `json:"foreignAlbumId"`
+	ForeignArtistID string   `json:"foreignArtistId"`
+	Stalled         *Stalled `json:"stalled,omitempty"`
 }
 
 type radarrRecord struct {
 	*radarr.QueueRecord
-	Name           string `json:"name"`
-	ForeignMovieID int64  `json:"foreignMovieId"`
+	Name           string   `json:"name"`
+	ForeignMovieID int64    `json:"foreignMovieId"`
+	Stalled        *Stalled `json:"stalled,omitempty"`
 }
 
 type sonarrRecord struct {
 	*sonarr.QueueRecord
-	Name            string `json:"name"`
-	ForeignSeriesID int64  `json:"foreignSeriesId"`
+	Name            string   `json:"name"`
+	ForeignSeriesID int64    `json:"foreignSeriesId"`
+	Stalled         *Stalled `json:"stalled,omitempty"`
 }
 
 type readarrRecord struct {
 	*readarr.QueueRecord
-	Name            string `json:"name"`
-	AuthorTitle     string `json:"authorTitle"`
-	ForeignBookID   string `json:"foreignBookId"`
-	ForeignAuthorID string `json:"foreignAuthorId"`
+	Name            string   `json:"name"`
+	AuthorTitle     string   `json:"authorTitle"`
+	ForeignBookID   string   `json:"foreignBookId"`
+	ForeignAuthorID string   `json:"foreignAuthorId"`
+	Stalled         *Stalled `json:"stalled,omitempty"`
 }
 
 // sendDownloadingQueues gathers the downloading queue items from cache and sends them.
@@ -53,10 +57,11 @@ func (c *cmd) sendDownloadingQueues(ctx context.Context, input *common.ActionInp
 	radarr := c.getDownloadingItemsRadarr(ctx)
 	readarr := c.getDownloadingItemsReadarr(ctx)
 	sonarr := c.getDownloadingItemsSonarr(ctx)
+	whisparr := c.getDownloadingItemsWhisparr(ctx)
 
-	if lidarr.Empty() && radarr.Empty() && readarr.Empty() && sonarr.Empty() {
-		c.Debugf("[%s requested] No Downloading Items found; Lidarr: %d, Radarr: %d, Readarr: %d, Sonarr: %d",
-			input.Type, lidarr.Len(), radarr.Len(), readarr.Len(), sonarr.Len())
+	if lidarr.Empty() && radarr.Empty() && readarr.Empty() && sonarr.Empty() && whisparr.Empty() {
+		c.Debugf("[%s requested] No Downloading Items found; Lidarr: %d, Radarr: %d, Readarr: %d, Sonarr: %d, Whisparr: %d",
+			input.Type, lidarr.Len(), radarr.Len(), readarr.Len(), sonarr.Len(), whisparr.Len())
 
 		if c.empty {
 			return
@@ -72,13 +77,14 @@ func (c *cmd) sendDownloadingQueues(ctx context.Context, input *common.ActionInp
 		Event:      input.Type,
 		LogPayload: true,
 		ErrorsOnly: !c.DebugEnabled(),
-		LogMsg: fmt.Sprintf("Downloading Items; Lidarr: %d, Radarr: %d, Readarr: %d, Sonarr: %d",
-			lidarr.Len(), radarr.Len(), readarr.Len(), sonarr.Len()),
+		LogMsg: fmt.Sprintf("Downloading Items; Lidarr: %d, Radarr: %d, Readarr: %d, Sonarr: %d, Whisparr: %d",
+			lidarr.Len(), radarr.Len(), readarr.Len(), sonarr.Len(), whisparr.Len()),
 		Payload: &QueuesPaylod{
-			Lidarr:  lidarr,
-			Radarr:  radarr,
-			Readarr: readarr,
-			Sonarr:  sonarr,
+			Lidarr:   lidarr,
+			Radarr:   radarr,
+			Readarr:  readarr,
+			Sonarr:   sonarr,
+			Whisparr: whisparr,
 		},
 	})
 }
@@ -183,16 +189,18 @@ func (c *cmd) getDownloadingItemsRadarr(ctx context.Context) itemList {
 		}
 
 		queue, _ := cacheItem.Data.(*radarr.Queue)
-		radarrQueue := c.rangeDownloadingItemsRadarr(ctx, idx, app, queue.Records)
+		radarrQueue := c.rangeDownloadingItemsRadarr(ctx, idx, "radarrMovie", app, queue.Records)
 		items[instance] = listItem{Name: app.Name, Queue: radarrQueue, Total: queue.TotalRecords}
 	}
 
 	return items
 }
 
+// rangeDownloadingItemsRadarr is also used for Whisparr, so the movie cache key prefix is passed in.
 func (c *cmd) rangeDownloadingItemsRadarr(
 	ctx context.Context,
 	idx int,
+	cacheKey string,
 	app *apps.RadarrConfig,
 	records []*radarr.QueueRecord,
 ) []*radarrRecord {
@@ -217,14 +225,14 @@ func (c *cmd) rangeDownloadingItemsRadarr(
 
 		// We have to connect back to the starr app and pull meta data for the active downloading item.
 		// The data gets cached for a while so this extra api hit should only happen once for each item.
-		cacheItem := data.GetWithID(fmt.Sprint("radarrMovie", item.MovieID), idx)
+		cacheItem := data.GetWithID(fmt.Sprint(cacheKey, item.MovieID), idx)
 		if cacheItem == nil || cacheItem.Data == nil {
 			movie, err := app.GetMovieByIDContext(ctx, item.MovieID)
 			if err != nil {
 				c.Errorf("Getting data for downloading item: %v", err)
 				cacheItem = &cache.Item{Data: &radarr.Movie{}} //nolint:wsl
 			} else {
-				data.SaveWithID(fmt.Sprint("radarrMovie", item.MovieID), idx, movie)
+				data.SaveWithID(fmt.Sprint(cacheKey, item.MovieID), idx, movie)
 				cacheItem = &cache.Item{Data: movie}
 			}
 		}
@@ -398,3 +406,30 @@ func (c *cmd) rangeDownloadingItemsSonarr(
 
 	return sonarrQueue
 }
+
+func (c *cmd) getDownloadingItemsWhisparr(ctx context.Context) itemList {
+	items := make(itemList)
+
+	info := clientinfo.Get()
+	if info == nil {
+		return items
+	}
+
+	for idx, app := range c.Apps.Whisparr {
+		instance := idx + 1
+		if !app.Enabled() || !info.Actions.Apps.Whisparr.Finished(instance) {
+			continue
+		}
+
+		cacheItem := data.GetWithID("whisparr", idx)
+		if cacheItem == nil || cacheItem.Data == nil {
+			continue
+		}
+
+		queue, _ := cacheItem.Data.(*radarr.Queue)
+		whisparrQueue := c.rangeDownloadingItemsRadarr(ctx, idx, "whisparrMovie", (*apps.RadarrConfig)(app), queue.Records)
+		items[instance] = listItem{Name: app.Name, Queue: whisparrQueue, Total: queue.TotalRecords}
+	}
+
+	return items
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/Notifiarr/notifiarr/pkg/website"
 	"github.com/Notifiarr/notifiarr/pkg/website/clientinfo"
 	"golift.io/cnfg"
+	"golift.io/starr"
 )
 
 const TrigLidarrQueue common.TriggerName = "Storing Lidarr instance %d queue."
@@ -43,6 +44,13 @@ func (app *lidarrApp) storeQueue(ctx context.Context, input *common.ActionInput)
 	app.cmd.Debugf("[%s requested] Stored Lidarr Queue (%d items), instance %d %s",
 		input.Type, len(queue.Records), app.idx+1, app.app.Name)
 	data.SaveWithID("lidarr", app.idx, queue)
+
+	items := make([]*progress, len(queue.Records))
+	for idx, item := range queue.Records {
+		items[idx] = &progress{id: item.DownloadID, sizeleft: item.Sizeleft, timeleft: item.Timeleft, status: item.Status}
+	}
+
+	trackProgress("lidarr", app.idx, items)
 }
 
 func (c *cmd) setupLidarr() bool {
@@ -61,6 +69,9 @@ func (c *cmd) setupLidarr() bool {
 			dur = finishedDuration
 		} else if info.Actions.Apps.Lidarr.Stuck(instance) {
 			dur = stuckDuration
+		} else if c.hasRules(starr.Lidarr, instance) || c.stall.enabled() {
+			// Remediation rules and stall checks need the queue even when the website does not want stuck items.
+			dur = stuckDuration
 		}
 
 		if dur != 0 {
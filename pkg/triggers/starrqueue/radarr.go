@@ -10,6 +10,7 @@ import (
 	"github.com/Notifiarr/notifiarr/pkg/website"
 	"github.com/Notifiarr/notifiarr/pkg/website/clientinfo"
 	"golift.io/cnfg"
+	"golift.io/starr"
 )
 
 const TrigRadarrQueue common.TriggerName = "Storing Radarr instance %d queue."
@@ -45,6 +46,13 @@ func (app *radarrApp) storeQueue(ctx context.Context, input *common.ActionInput)
 	app.cmd.Debugf("[%s requested] Stored Radarr Queue (%d items), instance %d %s",
 		input.Type, len(queue.Records), app.idx+1, app.app.Name)
 	data.SaveWithID("radarr", app.idx, queue)
+
+	items := make([]*progress, len(queue.Records))
+	for idx, item := range queue.Records {
+		items[idx] = &progress{id: item.DownloadID, sizeleft: item.Sizeleft, timeleft: item.Timeleft, status: item.Status}
+	}
+
+	trackProgress("radarr", app.idx, items)
 }
 
 func (c *cmd) setupRadarr() bool {
@@ -63,6 +71,9 @@ func (c *cmd) setupRadarr() bool {
 			dur = finishedDuration
 		} else if info.Actions.Apps.Radarr.Stuck(instance) {
 			dur = stuckDuration
+		} else if c.hasRules(starr.Radarr, instance) || c.stall.enabled() {
+			// Remediation rules and stall checks need the queue even when the website does not want stuck items.
+			dur = stuckDuration
 		}
 
 		if dur != 0 {
@@ -10,6 +10,7 @@ import (
 	"github.com/Notifiarr/notifiarr/pkg/website"
 	"github.com/Notifiarr/notifiarr/pkg/website/clientinfo"
 	"golift.io/cnfg"
+	"golift.io/starr"
 )
 
 const TrigReadarrQueue common.TriggerName = "Storing Readarr instance %d queue."
@@ -43,6 +44,13 @@ func (app *readarrApp) storeQueue(ctx context.Context, input *common.ActionInput
 	app.cmd.Debugf("[%s requested] Stored Readarr Queue (%d items), instance %d %s",
 		input.Type, len(queue.Records), app.idx+1, app.app.Name)
 	data.SaveWithID("readarr", app.idx, queue)
+
+	items := make([]*progress, len(queue.Records))
+	for idx, item := range queue.Records {
+		items[idx] = &progress{id: item.DownloadID, sizeleft: item.Sizeleft, timeleft: item.Timeleft, status: item.Status}
+	}
+
+	trackProgress("readarr", app.idx, items)
 }
 
 func (c *cmd) setupReadarr() bool {
@@ -65,6 +73,9 @@ func (c *cmd) setupReadarr() bool {
 		case info.Actions.Apps.Readarr.Stuck(instance):
 			enable = true
 			dur = stuckDuration
+		case c.hasRules(starr.Readarr, instance) || c.stall.enabled():
+			// Remediation rules and stall checks need the queue even when the website does not want stuck items.
+			dur = stuckDuration
 		default:
 			continue
 		}
@@ -0,0 +1,492 @@
+package starrqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common/statefile"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/data"
+	"golift.io/cnfg"
+	"golift.io/starr"
+	"golift.io/starr/lidarr"
+	"golift.io/starr/radarr"
+	"golift.io/starr/readarr"
+	"golift.io/starr/sonarr"
+)
+
+/* This file contains the procedures to remove stuck queue items with user-defined rules. */
+
+// AuditFileName is the name of the file every remediation action is written to.
+// It lives in the same folder as the config file. Each line is one json encoded AuditEntry.
+const AuditFileName = "queueaudit.jsonl"
+
+// StuckFileName is the name of the file that saves when each item was first seen stuck,
+// so items do not start over after a restart. It lives in the same folder as the config file.
+const StuckFileName = "queuestuck.json"
+
+const (
+	// The audit log is rotated when it's bigger than this.
+	auditMaxSize = 10 * mnd.Megabyte
+	// How many rotated audit logs are kept.
+	auditBackups = 2
+)
+
+const TrigStuckRemediation common.TriggerName = "Checking stuck queue items against remediation rules."
+
+// ErrInvalidRule is returned when a queue rule has a bad app or is missing a filter.
+var ErrInvalidRule = errors.New("invalid queue rule")
+
+// Remediation results written to the audit log.
+const (
+	resultRemoved     = "removed"
+	resultDryRun      = "dry run"
+	resultRateLimited = "rate limited"
+	resultError       = "error"
+)
+
+// Rule removes stuck items from a starr app's queue. An item matches when it has one of the statuses,
+// and a message that contains Message, and has been stuck for Age. Instance 0 matches every instance.
+// Statuses are checked against the item's status, tracked download status and tracked download state.
+type Rule struct {
+	Name      string        `json:"name"      toml:"name"      xml:"name"      yaml:"name"`
+	App       string        `json:"app"       toml:"app"       xml:"app"       yaml:"app"`
+	Instance  int           `json:"instance"  toml:"instance"  xml:"instance"  yaml:"instance"`
+	Status    []string      `json:"status"    toml:"status"    xml:"status"    yaml:"status"`
+	Message   string        `json:"message"   toml:"message"   xml:"message"   yaml:"message"`
+	Age       cnfg.Duration `json:"age"       toml:"age"       xml:"age"       yaml:"age"`
+	Remove    bool          `json:"remove"    toml:"remove"    xml:"remove"    yaml:"remove"`
+	Blocklist bool          `json:"blocklist" toml:"blocklist" xml:"blocklist" yaml:"blocklist"`
+	Search    bool          `json:"search"    toml:"search"    xml:"search"    yaml:"search"`
+	DryRun    bool          `json:"dryRun"    toml:"dry_run"   xml:"dry_run"   yaml:"dryRun"`
+}
+
+// AuditEntry is one remediation action written to the audit log.
+type AuditEntry struct {
+	Time       time.Time `json:"time"`
+	Rule       string    `json:"rule"`
+	App        string    `json:"app"`
+	Instance   int       `json:"instance"`
+	Name       string    `json:"name"`
+	QueueID    int64     `json:"queueId"`
+	DownloadID string    `json:"downloadId"`
+	Title      string    `json:"title"`
+	Message    string    `json:"message,omitempty"`
+	Stuck      string    `json:"stuck"`
+	Remove     bool      `json:"remove"`
+	Blocklist  bool      `json:"blocklist"`
+	Search     bool      `json:"search"`
+	Result     string    `json:"result"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// stuckRecord is the part of a queue record, from any starr app, that rules are checked against.
+type stuckRecord struct {
+	id         int64
+	downloadID string
+	title      string
+	states     []string
+	messages   []string
+}
+
+// stuckState tracks when an item was first seen stuck, and the last thing a rule did to it.
+// These are saved to the stuck file.
+type stuckState struct {
+	Since  time.Time `json:"since"`
+	Result string    `json:"result,omitempty"`
+}
+
+// queueDeleter removes an item from any starr app's queue.
+type queueDeleter func(ctx context.Context, queueID int64, opts *starr.QueueDeleteOpts) error
+
+// Validate checks a rule for errors, and sets defaults.
+func (r *Rule) Validate() error {
+	switch r.App = strings.ToLower(r.App); r.App {
+	case starr.Lidarr.Lower(), starr.Radarr.Lower(), starr.Readarr.Lower(), starr.Sonarr.Lower(), starr.Whisparr.Lower():
+	default:
+		return fmt.Errorf("%w: rule '%s' has unknown app: %s", ErrInvalidRule, r.Name, r.App)
+	}
+
+	if len(r.Status) == 0 && r.Message == "" {
+		return fmt.Errorf("%w: rule '%s' requires a status or message", ErrInvalidRule, r.Name)
+	}
+
+	if r.Search && !r.Blocklist {
+		return fmt.Errorf("%w: rule '%s' can only search when blocklist is enabled", ErrInvalidRule, r.Name)
+	}
+
+	if r.Name == "" {
+		r.Name = strings.TrimSpace(r.App + " " + strings.Join(r.Status, ",") + " " + r.Message)
+	}
+
+	return nil
+}
+
+// hasRules returns true if any rule applies to the app instance.
+func (c *cmd) hasRules(app starr.App, instance int) bool {
+	for _, rule := range c.rules {
+		if rule.App == app.Lower() && (rule.Instance == 0 || rule.Instance == instance) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *cmd) setupRemediation() {
+	if len(c.rules) == 0 {
+		return
+	}
+
+	for _, rule := range c.rules {
+		if err := rule.Validate(); err != nil {
+			c.Errorf("Queue remediation disabled: %v", err)
+			c.rules = nil
+
+			return
+		}
+	}
+
+	if err := statefile.Load(c.stuckFile, &c.stuck); err != nil {
+		c.Errorf("Loading stuck queue items, remediation starts over: %v", err)
+	}
+
+	if c.stuck == nil {
+		c.stuck = make(map[string]*stuckState)
+	}
+
+	c.Printf("==> Stuck Queue Remediation Started, rules: %d, interval: %s, audit log: %s",
+		len(c.rules), stuckDuration, c.audit.Path)
+
+	c.Add(&common.Action{
+		Name: TrigStuckRemediation,
+		Fn:   c.remediateStuckItems,
+		C:    make(chan *common.ActionInput, 1),
+		D:    cnfg.Duration{Duration: stuckDuration},
+	})
+}
+
+// remediateStuckItems runs on an interval and checks every cached stuck queue item against the rules.
+func (c *cmd) remediateStuckItems(ctx context.Context, input *common.ActionInput) {
+	seen := make(map[string]*stuckState)
+	entries := []*AuditEntry{}
+
+	for idx, app := range c.Apps.Lidarr {
+		if app.Enabled() && c.hasRules(starr.Lidarr, idx+1) {
+			entries = append(entries, c.remediate(ctx, starr.Lidarr, idx, &app.ExtraConfig,
+				app.DeleteQueueContext, stuckLidarr(idx), seen)...)
+		}
+	}
+
+	for idx, app := range c.Apps.Radarr {
+		if app.Enabled() && c.hasRules(starr.Radarr, idx+1) {
+			entries = append(entries, c.remediate(ctx, starr.Radarr, idx, &app.ExtraConfig,
+				app.DeleteQueueContext, stuckRadarr(starr.Radarr, idx), seen)...)
+		}
+	}
+
+	for idx, app := range c.Apps.Readarr {
+		if app.Enabled() && c.hasRules(starr.Readarr, idx+1) {
+			entries = append(entries, c.remediate(ctx, starr.Readarr, idx, &app.ExtraConfig,
+				app.DeleteQueueContext, stuckReadarr(idx), seen)...)
+		}
+	}
+
+	for idx, app := range c.Apps.Sonarr {
+		if app.Enabled() && c.hasRules(starr.Sonarr, idx+1) {
+			entries = append(entries, c.remediate(ctx, starr.Sonarr, idx, &app.ExtraConfig,
+				app.DeleteQueueContext, stuckSonarr(idx), seen)...)
+		}
+	}
+
+	for idx, app := range c.Apps.Whisparr {
+		if app.Enabled() && c.hasRules(starr.Whisparr, idx+1) {
+			entries = append(entries, c.remediate(ctx, starr.Whisparr, idx, &app.ExtraConfig,
+				app.DeleteQueueContext, stuckRadarr(starr.Whisparr, idx), seen)...)
+		}
+	}
+
+	c.stuck = seen
+
+	if err := statefile.Save(c.stuckFile, seen); err != nil {
+		c.Errorf("[%s requested] Saving stuck queue items: %v", input.Type, err)
+	}
+
+	if len(entries) == 0 {
+		return
+	}
+
+	if err := c.writeAudit(entries); err != nil {
+		c.Errorf("[%s requested] Writing queue remediation audit log: %v", input.Type, err)
+	}
+}
+
+// remediate checks one app instance's stuck items against the rules, and removes the ones that match.
+func (c *cmd) remediate( //nolint:funlen
+	ctx context.Context,
+	app starr.App,
+	idx int,
+	config *apps.ExtraConfig,
+	deleter queueDeleter,
+	records []*stuckRecord,
+	seen map[string]*stuckState,
+) []*AuditEntry {
+	entries := []*AuditEntry{}
+	// repeatStomper is used to act on each download ID only once.
+	repeatStomper := make(map[string]struct{})
+
+	for _, record := range records {
+		key := fmt.Sprint(app, idx, record.id, record.downloadID)
+
+		state := c.stuck[key]
+		if state == nil {
+			state = &stuckState{Since: time.Now()}
+		}
+
+		seen[key] = state
+
+		rule, message := c.findRule(app, idx+1, record, time.Since(state.Since))
+		if _, exists := repeatStomper[record.downloadID]; rule == nil || exists {
+			continue
+		}
+
+		repeatStomper[record.downloadID] = struct{}{}
+
+		entry := &AuditEntry{
+			Time:       time.Now(),
+			Rule:       rule.Name,
+			App:        app.String(),
+			Instance:   idx + 1,
+			Name:       config.Name,
+			QueueID:    record.id,
+			DownloadID: record.downloadID,
+			Title:      record.title,
+			Message:    message,
+			Stuck:      time.Since(state.Since).Round(time.Second).String(),
+			Remove:     rule.Remove,
+			Blocklist:  rule.Blocklist,
+			Search:     rule.Search,
+		}
+
+		switch remove := rule.Remove; {
+		case rule.DryRun:
+			entry.Result = resultDryRun
+		case !config.DelOK():
+			entry.Result = resultRateLimited
+		default:
+			err := deleter(ctx, record.id, &starr.QueueDeleteOpts{
+				RemoveFromClient: &remove,
+				BlockList:        rule.Blocklist,
+				SkipRedownload:   !rule.Search,
+			})
+			if err != nil {
+				entry.Result = resultError
+				entry.Error = err.Error()
+			} else {
+				entry.Result = resultRemoved
+
+				delete(seen, key)
+			}
+		}
+
+		// Repeated dry runs, rate limits and errors are only logged once per item.
+		if entry.Result == state.Result {
+			continue
+		}
+
+		state.Result = entry.Result
+		entries = append(entries, entry)
+		c.Printf("Queue Remediation: %s (%d) '%s' matched rule '%s' (stuck %s): %s %s",
+			app, idx+1, record.title, rule.Name, entry.Stuck, entry.Result, entry.Error)
+	}
+
+	return entries
+}
+
+// findRule returns the first rule that matches a stuck item, and the message that matched it.
+func (c *cmd) findRule(app starr.App, instance int, record *stuckRecord, stuck time.Duration) (*Rule, string) {
+	for _, rule := range c.rules {
+		if rule.App != app.Lower() || (rule.Instance != 0 && rule.Instance != instance) ||
+			stuck < rule.Age.Duration || !rule.hasStatus(record.states) {
+			continue
+		}
+
+		if rule.Message == "" {
+			return rule, strings.Join(record.messages, "; ")
+		}
+
+		for _, message := range record.messages {
+			if strings.Contains(strings.ToLower(message), strings.ToLower(rule.Message)) {
+				return rule, message
+			}
+		}
+	}
+
+	return nil, ""
+}
+
+// hasStatus returns true if the rule has no statuses, or one of them matches.
+func (r *Rule) hasStatus(states []string) bool {
+	if len(r.Status) == 0 {
+		return true
+	}
+
+	for _, status := range r.Status {
+		for _, state := range states {
+			if strings.EqualFold(status, state) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// writeAudit appends remediation actions to the audit log.
+func (c *cmd) writeAudit(entries []*AuditEntry) error {
+	if _, err := c.audit.Append(entries...); err != nil {
+		return fmt.Errorf("audit log: %w", err)
+	}
+
+	return nil
+}
+
+// statusMessages flattens an item's error and status messages.
+func statusMessages(errorMessage string, messages []*starr.StatusMessage) []string {
+	output := []string{}
+	if errorMessage != "" {
+		output = append(output, errorMessage)
+	}
+
+	for _, msg := range messages {
+		if msg == nil {
+			continue
+		}
+
+		if msg.Title != "" {
+			output = append(output, msg.Title)
+		}
+
+		output = append(output, msg.Messages...)
+	}
+
+	return output
+}
+
+func stuckLidarr(idx int) []*stuckRecord {
+	records := []*stuckRecord{}
+
+	item := data.GetWithID("lidarr", idx)
+	if item == nil || item.Data == nil {
+		return records
+	}
+
+	queue, ok := item.Data.(*lidarr.Queue)
+	if !ok {
+		return records
+	}
+
+	for _, item := range queue.Records {
+		if isStuck(item.Status, item.ErrorMessage, item.StatusMessages) {
+			records = append(records, &stuckRecord{
+				id:         item.ID,
+				downloadID: item.DownloadID,
+				title:      item.Title,
+				states:     []string{item.Status, item.TrackedDownloadStatus},
+				messages:   statusMessages(item.ErrorMessage, item.StatusMessages),
+			})
+		}
+	}
+
+	return records
+}
+
+// stuckRadarr is also used for Whisparr.
+func stuckRadarr(app starr.App, idx int) []*stuckRecord {
+	records := []*stuckRecord{}
+
+	item := data.GetWithID(app.Lower(), idx)
+	if item == nil || item.Data == nil {
+		return records
+	}
+
+	queue, ok := item.Data.(*radarr.Queue)
+	if !ok {
+		return records
+	}
+
+	for _, item := range queue.Records {
+		if isStuck(item.Status, item.ErrorMessage, item.StatusMessages) {
+			records = append(records, &stuckRecord{
+				id:         item.ID,
+				downloadID: item.DownloadID,
+				title:      item.Title,
+				states:     []string{item.Status, item.TrackedDownloadStatus, item.TrackedDownloadState},
+				messages:   statusMessages(item.ErrorMessage, item.StatusMessages),
+			})
+		}
+	}
+
+	return records
+}
+
+func stuckReadarr(idx int) []*stuckRecord {
+	records := []*stuckRecord{}
+
+	item := data.GetWithID("readarr", idx)
+	if item == nil || item.Data == nil {
+		return records
+	}
+
+	queue, ok := item.Data.(*readarr.Queue)
+	if !ok {
+		return records
+	}
+
+	for _, item := range queue.Records {
+		if isStuck(item.Status, item.ErrorMessage, item.StatusMessages) {
+			records = append(records, &stuckRecord{
+				id:         item.ID,
+				downloadID: item.DownloadID,
+				title:      item.Title,
+				states:     []string{item.Status, item.TrackedDownloadStatus, item.TrackedDownloadState},
+				messages:   statusMessages(item.ErrorMessage, item.StatusMessages),
+			})
+		}
+	}
+
+	return records
+}
+
+func stuckSonarr(idx int) []*stuckRecord {
+	records := []*stuckRecord{}
+
+	item := data.GetWithID("sonarr", idx)
+	if item == nil || item.Data == nil {
+		return records
+	}
+
+	queue, ok := item.Data.(*sonarr.Queue)
+	if !ok {
+		return records
+	}
+
+	for _, item := range queue.Records {
+		if isStuck(item.Status, item.ErrorMessage, item.StatusMessages) {
+			records = append(records, &stuckRecord{
+				id:         item.ID,
+				downloadID: item.DownloadID,
+				title:      item.Title,
+				states:     []string{item.Status, item.TrackedDownloadStatus, item.TrackedDownloadState},
+				messages:   statusMessages(item.ErrorMessage, item.StatusMessages),
+			})
+		}
+	}
+
+	return records
+}
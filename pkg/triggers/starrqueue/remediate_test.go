@@ -0,0 +1,150 @@
+package starrqueue //nolint:testpackage // the remediation rules are checked by unexported methods.
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"github.com/Notifiarr/notifiarr/pkg/logs"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common/jsonl"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common/statefile"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golift.io/cnfg"
+	"golift.io/starr"
+)
+
+func TestRuleValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		rule  Rule
+		valid bool
+	}{
+		{name: "status", rule: Rule{App: "Sonarr", Status: []string{"warning"}}, valid: true},
+		{name: "message", rule: Rule{App: "radarr", Message: "sample"}, valid: true},
+		{name: "search", rule: Rule{App: "radarr", Message: "sample", Blocklist: true, Search: true}, valid: true},
+		{name: "no filter", rule: Rule{App: "radarr"}, valid: false},
+		{name: "bad app", rule: Rule{App: "plex", Message: "sample"}, valid: false},
+		{name: "search no blocklist", rule: Rule{App: "radarr", Message: "sample", Search: true}, valid: false},
+	}
+
+	for _, test := range tests {
+		err := test.rule.Validate()
+		if !test.valid {
+			require.ErrorIs(t, err, ErrInvalidRule, test.name)
+			continue
+		}
+
+		require.NoError(t, err, test.name)
+		assert.NotEmpty(t, test.rule.Name, test.name)
+	}
+}
+
+func TestFindRule(t *testing.T) {
+	t.Parallel()
+
+	cmd := &cmd{rules: []*Rule{
+		{Name: "sample", App: "sonarr", Instance: 2, Message: "Sample"},
+		{Name: "blocked", App: "sonarr", Status: []string{"importBlocked"}, Age: cnfg.Duration{Duration: time.Hour}},
+		{Name: "warning", App: "radarr", Status: []string{"warning"}},
+	}}
+
+	tests := []struct {
+		name     string
+		app      starr.App
+		instance int
+		states   []string
+		messages []string
+		stuck    time.Duration
+		want     string
+		message  string
+	}{
+		{name: "message", app: starr.Sonarr, instance: 2, messages: []string{"No files", "sample file found"},
+			want: "sample", message: "sample file found"},
+		{name: "other instance", app: starr.Sonarr, instance: 1, messages: []string{"sample"}, want: ""},
+		{name: "too young", app: starr.Sonarr, instance: 1, states: []string{"importblocked"},
+			stuck: time.Minute, want: ""},
+		{name: "old enough", app: starr.Sonarr, instance: 1, states: []string{"completed", "importblocked"},
+			messages: []string{"one", "two"}, stuck: 2 * time.Hour, want: "blocked", message: "one; two"},
+		{name: "other app", app: starr.Lidarr, instance: 1, states: []string{"warning"}, want: ""},
+		{name: "status", app: starr.Radarr, instance: 3, states: []string{"Warning"}, want: "warning"},
+	}
+
+	for _, test := range tests {
+		record := &stuckRecord{states: test.states, messages: test.messages}
+		rule, message := cmd.findRule(test.app, test.instance, record, test.stuck)
+
+		if test.want == "" {
+			assert.Nil(t, rule, test.name)
+			continue
+		}
+
+		require.NotNil(t, rule, test.name)
+		assert.Equal(t, test.want, rule.Name, test.name)
+		assert.Equal(t, test.message, message, test.name)
+	}
+}
+
+func TestRemediateRestart(t *testing.T) {
+	t.Parallel()
+
+	stuckFile := filepath.Join(t.TempDir(), StuckFileName)
+	records := []*stuckRecord{{id: 1, downloadID: "abc", title: "Movie", states: []string{"warning"}}}
+	newCmd := func() *cmd {
+		cmd := &cmd{
+			Config:    &common.Config{Logger: logs.New()},
+			rules:     []*Rule{{App: "radarr", Status: []string{"warning"}, Age: cnfg.Duration{Duration: time.Hour}}},
+			audit:     &jsonl.File[AuditEntry]{},
+			stuck:     make(map[string]*stuckState),
+			stuckFile: stuckFile,
+		}
+		cmd.setupRemediation()
+
+		return cmd
+	}
+
+	cmd := newCmd()
+	seen := make(map[string]*stuckState)
+	assert.Empty(t, cmd.remediate(context.Background(), starr.Radarr, 0, &apps.ExtraConfig{}, nil, records, seen),
+		"the item was not stuck long enough")
+	require.Len(t, seen, 1)
+
+	// Pretend the item was first seen stuck two hours ago, and the app restarted.
+	for _, state := range seen {
+		state.Since = time.Now().Add(-2 * time.Hour)
+	}
+
+	require.NoError(t, statefile.Save(stuckFile, seen))
+
+	cmd = newCmd()
+	seen = make(map[string]*stuckState)
+	entries := cmd.remediate(context.Background(), starr.Radarr, 0, &apps.ExtraConfig{}, nil, records, seen)
+	require.Len(t, entries, 1, "the stuck time must survive a restart")
+	assert.Equal(t, resultRateLimited, entries[0].Result, "the app does not allow deletes")
+
+	cmd.stuck = seen
+	assert.Empty(t, cmd.remediate(context.Background(), starr.Radarr, 0, &apps.ExtraConfig{}, nil, records, seen),
+		"repeated results are only logged once")
+}
+
+func TestStuckWrongType(t *testing.T) {
+	t.Parallel()
+
+	// The instance index is high to stay away from other tests using the data cache.
+	data.SaveWithID("sonarr", 990, "not a queue")
+	data.SaveWithID("lidarr", 990, "not a queue")
+	data.SaveWithID("readarr", 990, "not a queue")
+	data.SaveWithID("radarr", 990, "not a queue")
+
+	assert.Empty(t, stuckSonarr(990))
+	assert.Empty(t, stuckLidarr(990))
+	assert.Empty(t, stuckReadarr(990))
+	assert.Empty(t, stuckRadarr(starr.Radarr, 990))
+	assert.Empty(t, stuckRadarr(starr.Whisparr, 991), "a missing queue has no records")
+}
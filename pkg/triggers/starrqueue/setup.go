@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common/jsonl"
 	"github.com/Notifiarr/notifiarr/pkg/website/clientinfo"
 	"golift.io/cnfg"
 )
@@ -19,6 +20,14 @@ type cmd struct {
 	*common.Config
 	// We set empty to true after we send 1 "empty downloads" payload.
 	empty bool
+	// rules remove stuck queue items, and every removal is written to the audit log.
+	// stuck is saved to stuckFile, so items are not forgotten after a restart.
+	rules     []*Rule
+	audit     *jsonl.File[AuditEntry]
+	stuck     map[string]*stuckState
+	stuckFile string
+	// stall marks queue items as stalled when they stop downloading.
+	stall *StallCheck
 }
 
 const (
@@ -46,25 +55,37 @@ const (
 
 // QueuesPaylod is what we send to the website.
 type QueuesPaylod struct {
-	Lidarr  itemList `json:"lidarr"`
-	Radarr  itemList `json:"radarr"`
-	Readarr itemList `json:"readarr"`
-	Sonarr  itemList `json:"sonarr"`
+	Lidarr   itemList `json:"lidarr"`
+	Radarr   itemList `json:"radarr"`
+	Readarr  itemList `json:"readarr"`
+	Sonarr   itemList `json:"sonarr"`
+	Whisparr itemList `json:"whisparr"`
 }
 
 // New configures the library.
-func New(config *common.Config) *Action {
-	return &Action{cmd: &cmd{Config: config}}
+func New(config *common.Config, rules []*Rule, auditFile, stuckFile string, stall *StallCheck) *Action {
+	return &Action{cmd: &cmd{
+		Config:    config,
+		rules:     rules,
+		audit:     &jsonl.File[AuditEntry]{Path: auditFile, MaxSize: auditMaxSize, Backups: auditBackups},
+		stuck:     make(map[string]*stuckState),
+		stuckFile: stuckFile,
+		stall:     stall,
+	}}
 }
 
 // Run initializes the library.
 func (a *Action) Create() {
+	// Rules are validated first, so the app queues are stored for them.
+	a.cmd.setupRemediation()
+
 	lidarr := a.cmd.setupLidarr()
 	radarr := a.cmd.setupRadarr()
 	readarr := a.cmd.setupReadarr()
 	sonarr := a.cmd.setupSonarr()
+	whisparr := a.cmd.setupWhisparr()
 
-	if lidarr || radarr || readarr || sonarr {
+	if lidarr || radarr || readarr || sonarr || whisparr {
 		a.cmd.Add(&common.Action{
 			Name: TrigStuckItems,
 			Fn:   a.cmd.sendStuckQueues,
@@ -10,6 +10,7 @@ import (
 	"github.com/Notifiarr/notifiarr/pkg/website"
 	"github.com/Notifiarr/notifiarr/pkg/website/clientinfo"
 	"golift.io/cnfg"
+	"golift.io/starr"
 )
 
 const TrigSonarrQueue common.TriggerName = "Storing Sonarr instance %d queue."
@@ -45,6 +46,13 @@ func (app *sonarrApp) storeQueue(ctx context.Context, input *common.ActionInput)
 	app.cmd.Debugf("[%s requested] Stored Sonarr Queue (%d items), instance %d %s",
 		input.Type, len(queue.Records), app.idx+1, app.app.Name)
 	data.SaveWithID("sonarr", app.idx, queue)
+
+	items := make([]*progress, len(queue.Records))
+	for idx, item := range queue.Records {
+		items[idx] = &progress{id: item.DownloadID, sizeleft: item.Sizeleft, timeleft: item.Timeleft, status: item.Status}
+	}
+
+	trackProgress("sonarr", app.idx, items)
 }
 
 func (c *cmd) setupSonarr() bool {
@@ -65,6 +73,9 @@ func (c *cmd) setupSonarr() bool {
 		} else if info.Actions.Apps.Sonarr.Stuck(instance) {
 			enable = true
 			dur = stuckDuration
+		} else if c.hasRules(starr.Sonarr, instance) || c.stall.enabled() {
+			// Remediation rules and stall checks need the queue even when the website does not want stuck items.
+			dur = stuckDuration
 		}
 
 		if dur != 0 {
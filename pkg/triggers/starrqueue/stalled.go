@@ -0,0 +1,121 @@
+package starrqueue
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/triggers/data"
+	"golift.io/cnfg"
+)
+
+/* This file contains the procedures to find downloads that stopped making progress. */
+
+// Reasons a download is stalled.
+const (
+	StalledNoProgress = "no progress"
+	StalledAge        = "age"
+)
+
+// StallCheck marks queue items as stalled when they stop downloading. A zero duration disables each check.
+// Window is how long a downloading item may go without progress. MaxAge is how long any item may stay in the queue.
+// Both are measured from when this client first saw the item, and survive reloads, but not restarts.
+type StallCheck struct {
+	Window cnfg.Duration `json:"window" toml:"window"  xml:"window"  yaml:"window"`
+	MaxAge cnfg.Duration `json:"maxAge" toml:"max_age" xml:"max_age" yaml:"maxAge"`
+}
+
+// enabled returns true if either stall check is enabled.
+// Progress is tracked on every queue poll, and survives reloads, so the queue is polled while this is true.
+func (s *StallCheck) enabled() bool {
+	return s != nil && (s.Window.Duration > 0 || s.MaxAge.Duration > 0)
+}
+
+// Stalled is included with a stuck queue item that stopped making progress.
+type Stalled struct {
+	Reason   string    `json:"reason"`
+	Since    time.Time `json:"since"`
+	Seconds  int64     `json:"seconds"`
+	Sizeleft float64   `json:"sizeleft"`
+	Timeleft string    `json:"timeleft"`
+}
+
+// progress tracks one download (by download ID) across queue polls.
+type progress struct {
+	id         string
+	firstSeen  time.Time
+	lastChange time.Time
+	sizeleft   float64
+	timeleft   string
+	status     string
+}
+
+// progressList is saved in the data cache for each app instance, so it survives reloads.
+type progressList map[string]*progress
+
+// trackProgress compares a fresh queue to the last one and saves when each download last made progress.
+// Only the first record for each download ID is used. Delay items have no download ID and are skipped.
+func trackProgress(key string, idx int, items []*progress) {
+	last := getProgress(key, idx)
+	current := make(progressList)
+	now := time.Now()
+
+	for _, item := range items {
+		if item.id == "" {
+			continue
+		} else if _, exists := current[item.id]; exists {
+			continue
+		}
+
+		item.firstSeen, item.lastChange = now, now
+
+		if prev := last[item.id]; prev != nil {
+			item.firstSeen = prev.firstSeen
+			// Any change in size left is progress, even if it went up (ie. a repair).
+			if prev.sizeleft == item.sizeleft {
+				item.lastChange = prev.lastChange
+			}
+		}
+
+		current[item.id] = item
+	}
+
+	data.SaveWithID(key+"Progress", idx, current)
+}
+
+// getProgress returns the saved download progress for an app instance.
+func getProgress(key string, idx int) progressList {
+	item := data.GetWithID(key+"Progress", idx)
+	if item == nil || item.Data == nil {
+		return progressList{}
+	}
+
+	list, _ := item.Data.(progressList)
+
+	return list
+}
+
+// stalled returns stall data if a download stopped making progress, or has been in the queue too long.
+// Returns nil if the download is not stalled, or the checks are disabled.
+func (c *cmd) stalled(list progressList, downloadID string) *Stalled {
+	item := list[downloadID]
+	if c.stall == nil || item == nil {
+		return nil
+	}
+
+	stalled := &Stalled{Sizeleft: item.sizeleft, Timeleft: item.timeleft}
+
+	switch status := strings.ToLower(item.status); {
+	case status == delay:
+		return nil
+	case c.stall.Window.Duration > 0 && status == downloading && time.Since(item.lastChange) > c.stall.Window.Duration:
+		stalled.Reason, stalled.Since = StalledNoProgress, item.lastChange
+	case c.stall.MaxAge.Duration > 0 && time.Since(item.firstSeen) > c.stall.MaxAge.Duration:
+		stalled.Reason, stalled.Since = StalledAge, item.firstSeen
+	default:
+		return nil
+	}
+
+	stalled.Seconds = int64(time.Since(stalled.Since).Seconds())
+
+	return stalled
+}
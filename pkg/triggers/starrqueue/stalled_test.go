@@ -0,0 +1,105 @@
+package starrqueue //nolint:testpackage // progress tracking is not exported.
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golift.io/cnfg"
+)
+
+func TestTrackProgress(t *testing.T) {
+	t.Parallel()
+
+	// This key is only used by this test, so the data cache is not shared with other tests.
+	const key = "testTrack"
+
+	trackProgress(key, 0, []*progress{
+		{id: "one", sizeleft: 100},
+		{id: "two", sizeleft: 100},
+		{id: "", sizeleft: 100, status: delay},
+	})
+
+	first := getProgress(key, 0)
+	require.Len(t, first, 2, "items without a download id are skipped")
+
+	// Pretend the last poll was an hour ago.
+	for _, item := range first {
+		item.firstSeen = item.firstSeen.Add(-time.Hour)
+		item.lastChange = item.lastChange.Add(-time.Hour)
+	}
+
+	trackProgress(key, 0, []*progress{
+		{id: "one", sizeleft: 100},
+		{id: "one", sizeleft: 50},
+		{id: "two", sizeleft: 150},
+		{id: "three", sizeleft: 10},
+	})
+
+	second := getProgress(key, 0)
+	require.Len(t, second, 3, "downloads that left the queue are forgotten, and duplicates are collapsed")
+
+	tests := []struct {
+		id       string
+		progress bool
+		old      bool
+	}{
+		{id: "one", progress: false, old: true},
+		{id: "two", progress: true, old: true},
+		{id: "three", progress: true, old: false},
+	}
+
+	for _, test := range tests {
+		item := second[test.id]
+		require.NotNil(t, item, test.id)
+		assert.Equal(t, test.progress, time.Since(item.lastChange) < time.Minute, "progress: %s", test.id)
+		assert.Equal(t, test.old, time.Since(item.firstSeen) > time.Minute, "first seen: %s", test.id)
+	}
+}
+
+func TestStalled(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	list := progressList{
+		"stuck":   {status: downloading, firstSeen: now.Add(-3 * time.Hour), lastChange: now.Add(-2 * time.Hour)},
+		"moving":  {status: downloading, firstSeen: now.Add(-3 * time.Hour), lastChange: now},
+		"old":     {status: "queued", firstSeen: now.Add(-50 * time.Hour), lastChange: now.Add(-50 * time.Hour)},
+		"delayed": {status: delay, firstSeen: now.Add(-50 * time.Hour), lastChange: now.Add(-50 * time.Hour)},
+		"paused":  {status: "paused", firstSeen: now.Add(-3 * time.Hour), lastChange: now.Add(-3 * time.Hour)},
+	}
+	check := &StallCheck{Window: cnfg.Duration{Duration: time.Hour}, MaxAge: cnfg.Duration{Duration: 48 * time.Hour}}
+
+	tests := []struct {
+		id     string
+		check  *StallCheck
+		reason string
+	}{
+		{id: "stuck", check: check, reason: StalledNoProgress},
+		{id: "moving", check: check, reason: ""},
+		{id: "old", check: check, reason: StalledAge},
+		{id: "delayed", check: check, reason: ""},
+		{id: "paused", check: check, reason: ""},
+		{id: "missing", check: check, reason: ""},
+		{id: "stuck", check: nil, reason: ""},
+		{id: "stuck", check: &StallCheck{MaxAge: check.MaxAge}, reason: ""},
+		{id: "old", check: &StallCheck{Window: check.Window}, reason: ""},
+	}
+
+	for _, test := range tests {
+		stalled := (&cmd{stall: test.check}).stalled(list, test.id)
+		if test.reason == "" {
+			assert.Nil(t, stalled, test.id)
+			continue
+		}
+
+		require.NotNil(t, stalled, test.id)
+		assert.Equal(t, test.reason, stalled.Reason, test.id)
+		assert.Positive(t, stalled.Seconds, test.id)
+	}
+
+	assert.False(t, (*StallCheck)(nil).enabled())
+	assert.False(t, (&StallCheck{}).enabled())
+	assert.True(t, (&StallCheck{MaxAge: check.MaxAge}).enabled())
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/Notifiarr/notifiarr/pkg/triggers/data"
 	"github.com/Notifiarr/notifiarr/pkg/website"
 	"github.com/Notifiarr/notifiarr/pkg/website/clientinfo"
+	"golift.io/starr"
 	"golift.io/starr/lidarr"
 	"golift.io/starr/radarr"
 	"golift.io/starr/readarr"
@@ -27,8 +28,9 @@ func (c *cmd) sendStuckQueues(ctx context.Context, input *common.ActionInput) {
 	radarr := c.getFinishedItemsRadarr(ctx)
 	readarr := c.getFinishedItemsReadarr(ctx)
 	sonarr := c.getFinishedItemsSonarr(ctx)
+	whisparr := c.getFinishedItemsWhisparr(ctx)
 
-	if lidarr.Empty() && radarr.Empty() && readarr.Empty() && sonarr.Empty() {
+	if lidarr.Empty() && radarr.Empty() && readarr.Empty() && sonarr.Empty() && whisparr.Empty() {
 		c.Debugf("[%s requested] No stuck items found.", input.Type)
 		return
 	}
@@ -37,13 +39,14 @@ func (c *cmd) sendStuckQueues(ctx context.Context, input *common.ActionInput) {
 		Route:      website.StuckRoute,
 		Event:      input.Type,
 		LogPayload: true,
-		LogMsg: fmt.Sprintf("Stuck Items; Lidarr: %d, Radarr: %d, Readarr: %d, Sonarr: %d",
-			lidarr.Len(), radarr.Len(), readarr.Len(), sonarr.Len()),
+		LogMsg: fmt.Sprintf("Stuck Items; Lidarr: %d, Radarr: %d, Readarr: %d, Sonarr: %d, Whisparr: %d",
+			lidarr.Len(), radarr.Len(), readarr.Len(), sonarr.Len(), whisparr.Len()),
 		Payload: &QueuesPaylod{
-			Lidarr:  lidarr,
-			Radarr:  radarr,
-			Readarr: readarr,
-			Sonarr:  sonarr,
+			Lidarr:   lidarr,
+			Radarr:   radarr,
+			Readarr:  readarr,
+			Sonarr:   sonarr,
+			Whisparr: whisparr,
 		},
 	})
 }
@@ -64,20 +67,21 @@ func (c *cmd) getFinishedItemsLidarr(_ context.Context) itemList { //nolint:cycl
 
 		queue, _ := item.Data.(*lidarr.Queue)
 		instance := idx + 1
+		tracked := getProgress("lidarr", idx)
 		appqueue := []*lidarrRecord{}
 		// repeatStomper is used to collapse duplicate download IDs.
 		repeatStomper := make(map[string]struct{})
 
 		for _, item := range queue.Records {
-			if s := strings.ToLower(item.Status); s != completed && s != warning &&
-				s != failed && s != errorstr && item.ErrorMessage == "" && len(item.StatusMessages) == 0 {
+			stalled := c.stalled(tracked, item.DownloadID)
+			if !isStuck(item.Status, item.ErrorMessage, item.StatusMessages) && stalled == nil {
 				continue
 			} else if _, exists := repeatStomper[item.DownloadID]; exists {
 				continue
 			}
 
 			repeatStomper[item.DownloadID] = struct{}{}
-			appqueue = append(appqueue, &lidarrRecord{QueueRecord: item}) //nolint:wsl
+			appqueue = append(appqueue, &lidarrRecord{QueueRecord: item, Stalled: stalled}) //nolint:wsl
 		}
 
 		stuck[instance] = listItem{Name: app.Name, Queue: appqueue, Total: queue.TotalRecords}
@@ -104,20 +108,21 @@ func (c *cmd) getFinishedItemsRadarr(_ context.Context) itemList { //nolint:cycl
 
 		queue, _ := item.Data.(*radarr.Queue)
 		instance := idx + 1
+		tracked := getProgress("radarr", idx)
 		appqueue := []*radarrRecord{}
 		// repeatStomper is used to collapse duplicate download IDs.
 		repeatStomper := make(map[string]struct{})
 
 		for _, item := range queue.Records {
-			if s := strings.ToLower(item.Status); s != completed && s != warning &&
-				s != failed && s != errorstr && item.ErrorMessage == "" && len(item.StatusMessages) == 0 {
+			stalled := c.stalled(tracked, item.DownloadID)
+			if !isStuck(item.Status, item.ErrorMessage, item.StatusMessages) && stalled == nil {
 				continue
 			} else if _, exists := repeatStomper[item.DownloadID]; exists {
 				continue
 			}
 
 			repeatStomper[item.DownloadID] = struct{}{}
-			appqueue = append(appqueue, &radarrRecord{QueueRecord: item}) //nolint:wsl
+			appqueue = append(appqueue, &radarrRecord{QueueRecord: item, Stalled: stalled}) //nolint:wsl
 		}
 
 		stuck[instance] = listItem{Name: app.Name, Queue: appqueue, Total: queue.TotalRecords}
@@ -144,20 +149,21 @@ func (c *cmd) getFinishedItemsReadarr(_ context.Context) itemList { //nolint:cyc
 
 		queue, _ := item.Data.(*readarr.Queue)
 		instance := idx + 1
+		tracked := getProgress("readarr", idx)
 		appqueue := []*readarrRecord{}
 		// repeatStomper is used to collapse duplicate download IDs.
 		repeatStomper := make(map[string]struct{})
 
 		for _, item := range queue.Records {
-			if s := strings.ToLower(item.Status); s != completed && s != warning &&
-				s != failed && s != errorstr && item.ErrorMessage == "" && len(item.StatusMessages) == 0 {
+			stalled := c.stalled(tracked, item.DownloadID)
+			if !isStuck(item.Status, item.ErrorMessage, item.StatusMessages) && stalled == nil {
 				continue
 			} else if _, exists := repeatStomper[item.DownloadID]; exists {
 				continue
 			}
 
 			repeatStomper[item.DownloadID] = struct{}{}
-			appqueue = append(appqueue, &readarrRecord{QueueRecord: item}) //nolint:wsl
+			appqueue = append(appqueue, &readarrRecord{QueueRecord: item, Stalled: stalled}) //nolint:wsl
 		}
 
 		stuck[instance] = listItem{Name: app.Name, Queue: appqueue, Total: queue.TotalRecords}
@@ -184,20 +190,21 @@ func (c *cmd) getFinishedItemsSonarr(_ context.Context) itemList { //nolint:cycl
 
 		queue, _ := cacheItem.Data.(*sonarr.Queue)
 		instance := idx + 1
+		tracked := getProgress("sonarr", idx)
 		appqueue := []*sonarrRecord{}
 		// repeatStomper is used to collapse duplicate download IDs.
 		repeatStomper := make(map[string]struct{})
 
 		for _, item := range queue.Records {
-			if s := strings.ToLower(item.Status); s != completed && s != warning &&
-				s != failed && s != errorstr && item.ErrorMessage == "" && len(item.StatusMessages) == 0 {
+			stalled := c.stalled(tracked, item.DownloadID)
+			if !isStuck(item.Status, item.ErrorMessage, item.StatusMessages) && stalled == nil {
 				continue
 			} else if _, exists := repeatStomper[item.DownloadID]; exists {
 				continue
 			}
 
 			repeatStomper[item.DownloadID] = struct{}{}
-			appqueue = append(appqueue, &sonarrRecord{QueueRecord: item}) //nolint:wsl
+			appqueue = append(appqueue, &sonarrRecord{QueueRecord: item, Stalled: stalled}) //nolint:wsl
 		}
 
 		stuck[instance] = listItem{Name: app.Name, Queue: appqueue, Total: queue.TotalRecords}
@@ -207,3 +214,52 @@ func (c *cmd) getFinishedItemsSonarr(_ context.Context) itemList { //nolint:cycl
 
 	return stuck
 }
+
+func (c *cmd) getFinishedItemsWhisparr(_ context.Context) itemList { //nolint:cyclop
+	stuck := make(itemList)
+
+	for idx, app := range c.Apps.Whisparr {
+		ci := clientinfo.Get()
+		if !app.Enabled() || ci == nil || !ci.Actions.Apps.Whisparr.Stuck(idx+1) {
+			continue
+		}
+
+		item := data.GetWithID("whisparr", idx)
+		if item == nil || item.Data == nil {
+			continue
+		}
+
+		queue, _ := item.Data.(*radarr.Queue)
+		instance := idx + 1
+		tracked := getProgress("whisparr", idx)
+		appqueue := []*radarrRecord{}
+		// repeatStomper is used to collapse duplicate download IDs.
+		repeatStomper := make(map[string]struct{})
+
+		for _, item := range queue.Records {
+			stalled := c.stalled(tracked, item.DownloadID)
+			if !isStuck(item.Status, item.ErrorMessage, item.StatusMessages) && stalled == nil {
+				continue
+			} else if _, exists := repeatStomper[item.DownloadID]; exists {
+				continue
+			}
+
+			repeatStomper[item.DownloadID] = struct{}{}
+			appqueue = append(appqueue, &radarrRecord{QueueRecord: item, Stalled: stalled}) //nolint:wsl
+		}
+
+		stuck[instance] = listItem{Name: app.Name, Queue: appqueue, Total: queue.TotalRecords}
+		c.Debugf("Checking Whisparr (%d) Queue for Stuck Items, queue size: %d, stuck: %d",
+			instance, len(queue.Records), len(appqueue))
+	}
+
+	return stuck
+}
+
+// isStuck returns true if a queue item has a stuck status, or any error or status messages.
+func isStuck(status, errorMessage string, messages []*starr.StatusMessage) bool {
+	s := strings.ToLower(status)
+
+	return s == completed || s == warning || s == failed || s == errorstr ||
+		errorMessage != "" || len(messages) > 0
+}
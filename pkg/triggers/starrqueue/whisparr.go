@@ -0,0 +1,93 @@
+package starrqueue
+
+import (
+	"context"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/data"
+	"github.com/Notifiarr/notifiarr/pkg/website"
+	"github.com/Notifiarr/notifiarr/pkg/website/clientinfo"
+	"golift.io/cnfg"
+	"golift.io/starr"
+)
+
+const TrigWhisparrQueue common.TriggerName = "Storing Whisparr instance %d queue."
+
+// StoreWhisparr fetches and stores the Whisparr queue immediately for the specified instance.
+// Does not send data to the website.
+func (a *Action) StoreWhisparr(event website.EventType, instance int) {
+	if name := TrigWhisparrQueue.WithInstance(instance); !a.cmd.Exec(&common.ActionInput{Type: event}, name) {
+		a.cmd.Errorf("[%s requested] Failed! %s Disabled?", event, name)
+	}
+}
+
+type whisparrApp struct {
+	app *apps.WhisparrConfig
+	cmd *cmd
+	idx int
+}
+
+// storeQueue runs at an interval and saves the queue for an app internally.
+func (app *whisparrApp) storeQueue(ctx context.Context, input *common.ActionInput) {
+	queue, err := app.app.GetQueueContext(ctx, queueItemsMax, 1)
+	if err != nil {
+		app.cmd.Errorf("[%s requested] Getting Whisparr Queue (instance %d): %v", input.Type, app.idx+1, err)
+		return
+	}
+
+	for _, item := range queue.Records {
+		item.Quality = nil
+		item.CustomFormats = nil
+		item.Languages = nil
+	}
+
+	app.cmd.Debugf("[%s requested] Stored Whisparr Queue (%d items), instance %d %s",
+		input.Type, len(queue.Records), app.idx+1, app.app.Name)
+	data.SaveWithID("whisparr", app.idx, queue)
+
+	items := make([]*progress, len(queue.Records))
+	for idx, item := range queue.Records {
+		items[idx] = &progress{id: item.DownloadID, sizeleft: item.Sizeleft, timeleft: item.Timeleft, status: item.Status}
+	}
+
+	trackProgress("whisparr", app.idx, items)
+}
+
+func (c *cmd) setupWhisparr() bool {
+	var enabled bool
+
+	for idx, app := range c.Apps.Whisparr {
+		info := clientinfo.Get()
+		if !app.Enabled() || info == nil {
+			continue
+		}
+
+		var dur time.Duration
+
+		instance := idx + 1
+		if info.Actions.Apps.Whisparr.Finished(instance) {
+			dur = finishedDuration
+		} else if info.Actions.Apps.Whisparr.Stuck(instance) {
+			dur = stuckDuration
+		} else if c.hasRules(starr.Whisparr, instance) || c.stall.enabled() {
+			// Remediation rules and stall checks need the queue even when the website does not want stuck items.
+			dur = stuckDuration
+		}
+
+		if dur != 0 {
+			enabled = true
+
+			c.Add(&common.Action{
+				Hide: true,
+				Name: TrigWhisparrQueue.WithInstance(instance),
+				Fn:   (&whisparrApp{app: app, cmd: c, idx: idx}).storeQueue,
+				C:    make(chan *common.ActionInput, 1),
+				D:    cnfg.Duration{Duration: dur},
+			})
+		}
+	}
+
+	return enabled
+}
@@ -5,6 +5,7 @@ package triggers
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"reflect"
 
 	"github.com/Notifiarr/notifiarr/pkg/apps"
@@ -17,10 +18,12 @@ import (
 	"github.com/Notifiarr/notifiarr/pkg/triggers/common"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/crontimer"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/dashboard"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/downloads"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/emptytrash"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/fileupload"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/filewatch"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/gaps"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/jellyfincron"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/mdblist"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/plexcron"
 	"github.com/Notifiarr/notifiarr/pkg/triggers/snapcron"
@@ -37,6 +40,15 @@ type Config struct {
 	WatchFiles []*filewatch.WatchFile
 	LogFiles   []string
 	Commands   []*commands.Command
+	PlexPolicy []*plexcron.Policy
+	PlexHist   *plexcron.HistoryConfig
+	QueueRules []*starrqueue.Rule
+	Stalled    *starrqueue.StallCheck
+	SeedRules  []*downloads.SeedRule
+	Failed     *downloads.FailedConfig
+	DiskGuard  *downloads.DiskGuard
+	Orphans    *downloads.OrphanScan
+	Archive    *backups.Archive
 	ClientInfo *clientinfo.Config
 	ConfigFile string
 	AutoUpdate string
@@ -51,6 +63,7 @@ type Actions struct {
 	*common.Config
 	// Order is important here.
 	PlexCron   *plexcron.Action
+	JellyCron  *jellyfincron.Action
 	Backups    *backups.Action
 	CFSync     *cfsync.Action
 	CronTimer  *crontimer.Action
@@ -64,6 +77,7 @@ type Actions struct {
 	MDbList    *mdblist.Action
 	FileUpload *fileupload.Action
 	AutoUpdate *autoupdate.Action
+	Downloads  *downloads.Action
 }
 
 // New turns a populated Config into a pile of Actions.
@@ -76,24 +90,38 @@ func New(config *Config) *Actions {
 		CI:       config.ClientInfo,
 		Services: config.Services,
 	}
-	plex := plexcron.New(common, config.Apps.Plex)
+	// Local history, audit logs and trigger states are saved next to the config file.
+	historyFile, auditFile, stuckFile, seedAuditFile, failedSentFile, diskFile := "", "", "", "", "", ""
+	if config.ConfigFile != "" {
+		historyFile = filepath.Join(filepath.Dir(config.ConfigFile), plexcron.HistoryFileName)
+		auditFile = filepath.Join(filepath.Dir(config.ConfigFile), starrqueue.AuditFileName)
+		stuckFile = filepath.Join(filepath.Dir(config.ConfigFile), starrqueue.StuckFileName)
+		seedAuditFile = filepath.Join(filepath.Dir(config.ConfigFile), downloads.SeedAuditFileName)
+		failedSentFile = filepath.Join(filepath.Dir(config.ConfigFile), downloads.FailedSentFileName)
+		diskFile = filepath.Join(filepath.Dir(config.ConfigFile), downloads.DiskStateFileName)
+	}
+
+	plex := plexcron.New(common, config.Apps.Plex, config.PlexPolicy, config.PlexHist, historyFile)
 
 	return &Actions{
 		PlexCron:   plex,
-		Backups:    backups.New(common),
+		JellyCron:  jellyfincron.New(common, config.Apps.Jellyfin, plex),
+		Backups:    backups.New(common, config.Archive),
 		CFSync:     cfsync.New(common),
 		CronTimer:  crontimer.New(common),
 		Dashboard:  dashboard.New(common, plex),
 		FileWatch:  filewatch.New(common, config.WatchFiles, config.LogFiles),
 		Gaps:       gaps.New(common),
 		SnapCron:   snapcron.New(common),
-		StarrQueue: starrqueue.New(common),
+		StarrQueue: starrqueue.New(common, config.QueueRules, auditFile, stuckFile, config.Stalled),
 		Commands:   commands.New(common, config.Commands),
 		EmptyTrash: emptytrash.New(common),
 		MDbList:    mdblist.New(common),
 		FileUpload: fileupload.New(common),
 		Config:     common,
 		AutoUpdate: autoupdate.New(common, config.AutoUpdate, config.ConfigFile, config.UnstableCh),
+		Downloads: downloads.New(common, config.SeedRules, seedAuditFile,
+			config.Failed, failedSentFile, config.DiskGuard, diskFile, config.Orphans),
 	}
 }
 
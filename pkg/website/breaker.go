@@ -0,0 +1,159 @@
+package website
+
+import (
+	"expvar"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+)
+
+const (
+	// BreakerThreshold is how many website requests must fail in a row before the circuit breaker opens.
+	// A request fails once it runs out of retries, so its retries are not counted separately.
+	BreakerThreshold = 5
+	// BreakerMinBackoff is how long the breaker stays open the first time it trips.
+	BreakerMinBackoff = 10 * time.Second
+	// BreakerMaxBackoff is the longest the breaker stays open before probing the website again.
+	BreakerMaxBackoff = 10 * time.Minute
+	// maxRetryDelay caps the exponential delay between retries of a single request.
+	maxRetryDelay = 5 * time.Second
+)
+
+// BreakerState is the state of the website circuit breaker.
+type BreakerState string
+
+// These are the possible circuit breaker states.
+const (
+	// BreakerClosed means requests flow normally.
+	BreakerClosed BreakerState = "closed"
+	// BreakerOpen means requests fail immediately without contacting the website.
+	BreakerOpen BreakerState = "open"
+	// BreakerHalfOpen means a single probe request is allowed through to test the website.
+	BreakerHalfOpen BreakerState = "half-open"
+)
+
+// breakerState is exported in expvar, so the metrics and landing pages can show it.
+var breakerState = new(expvar.String) //nolint:gochecknoglobals
+
+func init() { //nolint:gochecknoinits
+	breakerState.Set(string(BreakerClosed))
+	mnd.Website.Set("Circuit Breaker", breakerState)
+}
+
+// breaker keeps the website http client from hammering the website while it's down.
+// After BreakerThreshold consecutive failures it opens, and requests fail fast with ErrCircuitOpen.
+// Once the backoff elapses, one request is allowed through (half-open). If that probe works the
+// breaker closes, otherwise it opens again with double the backoff, plus some jitter.
+type breaker struct {
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	backoff  time.Duration
+	until    time.Time
+	probing  bool
+}
+
+func newBreaker() *breaker {
+	return &breaker{state: BreakerClosed}
+}
+
+// allow returns an error if a request should not be sent to the website right now.
+// Returns true if the allowed request is a half-open probe.
+func (b *breaker) allow() (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch {
+	case b.state == BreakerClosed:
+		return false, nil
+	case b.state == BreakerOpen && time.Now().Before(b.until):
+	case b.state == BreakerOpen:
+		b.setState(BreakerHalfOpen)
+		fallthrough
+	case !b.probing:
+		b.probing = true
+		return true, nil
+	}
+
+	mnd.Website.Add("Circuit Breaker Fast Fails", 1)
+
+	if b.state == BreakerHalfOpen {
+		return false, fmt.Errorf("%w: waiting for a probe request in flight to the website", ErrCircuitOpen)
+	}
+
+	return false, fmt.Errorf("%w: website requests paused until %s",
+		ErrCircuitOpen, b.until.Round(time.Second).Format(time.Kitchen))
+}
+
+// success closes the breaker and resets the backoff.
+func (b *breaker) success() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wasOpen := b.state != BreakerClosed
+	b.failures = 0
+	b.backoff = 0
+	b.probing = false
+	b.setState(BreakerClosed)
+
+	return wasOpen
+}
+
+// done releases a half-open probe that ended without a result, like a canceled request.
+// The breaker stays half-open, so the next request becomes the probe. Does nothing after success or failure.
+func (b *breaker) done() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerHalfOpen {
+		b.probing = false
+	}
+}
+
+// failure records a failed request and returns how long the breaker opened for, if it opened.
+func (b *breaker) failure() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+
+	if b.state == BreakerOpen || (b.state == BreakerClosed && b.failures < BreakerThreshold) {
+		return 0 // already open (from a request sent before it opened), or not enough failures yet.
+	}
+
+	switch {
+	case b.backoff == 0:
+		b.backoff = BreakerMinBackoff
+	case b.state == BreakerHalfOpen:
+		b.backoff = min(2*b.backoff, BreakerMaxBackoff) //nolint:mnd
+	}
+
+	wait := jitter(b.backoff)
+	b.until = time.Now().Add(wait)
+	b.probing = false
+
+	mnd.Website.Add("Circuit Breaker Trips", 1)
+	b.setState(BreakerOpen)
+
+	return wait
+}
+
+func (b *breaker) setState(state BreakerState) {
+	b.state = state
+	breakerState.Set(string(state))
+}
+
+// retryDelay returns the time to wait before the next retry of a single request.
+// It doubles for every retry, and includes jitter so many waiting requests do not retry at once.
+func retryDelay(retry int) time.Duration {
+	delay := RetryDelay << min(retry, 8) //nolint:mnd // 222ms << 8 is ~57s, so the cap wins first.
+	return jitter(min(delay, maxRetryDelay))
+}
+
+// jitter adds up to 25% random time to a duration.
+func jitter(dur time.Duration) time.Duration {
+	return dur + time.Duration(rand.Int63n(int64(dur/4)+1)) //nolint:gosec,mnd
+}
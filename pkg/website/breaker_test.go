@@ -0,0 +1,140 @@
+package website //nolint:testpackage // the breaker is not exported.
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testLogger discards the log lines Do() writes. The logs package can't be imported here.
+type testLogger struct{ mnd.Logger }
+
+func (testLogger) Printf(string, ...any)        {}
+func (testLogger) ErrorfNoShare(string, ...any) {}
+
+// expire makes an open breaker ready for a half-open probe.
+func (b *breaker) expire() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.until = time.Now().Add(-time.Second)
+}
+
+func TestBreakerCycle(t *testing.T) {
+	t.Parallel()
+
+	brk := newBreaker()
+
+	for i := 1; i < BreakerThreshold; i++ {
+		probe, err := brk.allow()
+		require.NoError(t, err, "the breaker must stay closed until the threshold")
+		assert.False(t, probe, "a closed breaker does not send probes")
+		assert.Zero(t, brk.failure(), "the breaker must not open before the threshold")
+	}
+
+	wait := brk.failure()
+	assert.GreaterOrEqual(t, wait, BreakerMinBackoff, "the breaker opens at the threshold")
+	assert.Equal(t, BreakerOpen, brk.state)
+
+	_, err := brk.allow()
+	require.ErrorIs(t, err, ErrCircuitOpen, "an open breaker fails fast")
+
+	brk.expire()
+
+	probe, err := brk.allow()
+	require.NoError(t, err, "an expired breaker allows a probe")
+	assert.True(t, probe)
+	assert.Equal(t, BreakerHalfOpen, brk.state)
+
+	_, err = brk.allow()
+	require.ErrorIs(t, err, ErrCircuitOpen, "only one probe is allowed at a time")
+	assert.Contains(t, err.Error(), "probe request in flight", "a half-open breaker is not paused until a past time")
+
+	// The probe fails, so the backoff doubles.
+	assert.GreaterOrEqual(t, brk.failure(), 2*BreakerMinBackoff)
+	assert.Equal(t, BreakerOpen, brk.state)
+
+	brk.expire()
+
+	probe, err = brk.allow()
+	require.NoError(t, err)
+	assert.True(t, probe)
+	assert.True(t, brk.success(), "success must report the breaker was open")
+	brk.done() // deferred in Do().
+	assert.Equal(t, BreakerClosed, brk.state)
+	assert.Zero(t, brk.backoff, "the backoff resets when the breaker closes")
+
+	probe, err = brk.allow()
+	require.NoError(t, err)
+	assert.False(t, probe)
+	assert.False(t, brk.success(), "success must report the breaker was already closed")
+}
+
+func TestBreakerCanceledProbe(t *testing.T) {
+	t.Parallel()
+
+	brk := newBreaker()
+	for range BreakerThreshold {
+		brk.failure()
+	}
+
+	brk.expire()
+
+	probe, err := brk.allow()
+	require.NoError(t, err)
+	require.True(t, probe)
+
+	brk.done() // The probe was canceled: no success, no failure.
+	assert.Equal(t, BreakerHalfOpen, brk.state, "a canceled probe says nothing about the website")
+
+	probe, err = brk.allow()
+	require.NoError(t, err, "the next request must be allowed to probe")
+	assert.True(t, probe)
+
+	brk.failure()
+	brk.done() // deferred in Do(), must not release the next probe.
+	assert.Equal(t, BreakerOpen, brk.state)
+	assert.False(t, brk.probing)
+}
+
+func TestDoCountsOneFailurePerRequest(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int64
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := &httpClient{Retries: 1, Logger: testLogger{}, Client: server.Client(), breaker: newBreaker()}
+
+	for range BreakerThreshold - 1 {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+
+		_, err = client.Do(req) //nolint:bodyclose // Do closes the body of a failed response.
+		require.ErrorIs(t, err, ErrNon200)
+	}
+
+	assert.EqualValues(t, 2*(BreakerThreshold-1), attempts.Load(), "every request is retried once")
+	assert.Equal(t, BreakerThreshold-1, client.breaker.failures, "retries must not count as more failures")
+	assert.Equal(t, BreakerClosed, client.breaker.state)
+}
+
+func TestRetryDelay(t *testing.T) {
+	t.Parallel()
+
+	assert.GreaterOrEqual(t, retryDelay(0), RetryDelay)
+	assert.LessOrEqual(t, retryDelay(0), RetryDelay+RetryDelay/4)
+	assert.GreaterOrEqual(t, retryDelay(1), 2*RetryDelay)
+	assert.LessOrEqual(t, retryDelay(100), maxRetryDelay+maxRetryDelay/4, "the delay must be capped")
+}
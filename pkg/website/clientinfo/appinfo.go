@@ -26,6 +26,8 @@ type Config struct {
 	*apps.Apps
 	*website.Server
 	CmdList []*cmdconfig.Config
+	// CacheFile is where the last good website response is saved. Empty disables the cache.
+	CacheFile string
 }
 
 // AppInfo contains exported info about this app and its host.
@@ -90,6 +92,7 @@ type AppConfigs struct {
 	Radarr   []*AppInfoAppConfig `json:"radarr"`
 	Readarr  []*AppInfoAppConfig `json:"readarr"`
 	Sonarr   []*AppInfoAppConfig `json:"sonarr"`
+	Whisparr []*AppInfoAppConfig `json:"whisparr"`
 	Tautulli *AppInfoTautulli    `json:"tautulli"`
 }
 
@@ -109,9 +112,14 @@ type AppInfoTautulli struct {
 
 // Info is used for JSON input for our outgoing app info.
 func (c *Config) Info(ctx context.Context, startup bool) *AppInfo { //nolint:funlen
-	numPlex := 0 // maybe one day we'll support more than 1 plex.
-	if c.Apps.Plex.Enabled() {
-		numPlex = 1
+	numJellyfin := 0 // jellyfin or emby.
+	if c.Apps.Jellyfin.Enabled() {
+		numJellyfin = 1
+	}
+
+	numOverseerr := 0 // overseerr or jellyseerr.
+	if c.Apps.Overseerr.Enabled() {
+		numOverseerr = 1
 	}
 
 	numTautulli := 0 // maybe one day we'll support more than 1 tautulli.
@@ -148,10 +156,13 @@ func (c *Config) Info(ctx context.Context, startup bool) *AppInfo { //nolint:fun
 			Tunnel:    true, // no toggle for this.
 		},
 		Num: map[string]int{
+			"bazarr":       len(c.Apps.Bazarr),
 			"nzbget":       len(c.Apps.NZBGet),
 			"deluge":       len(c.Apps.Deluge),
+			"jellyfin":     numJellyfin,
 			"lidarr":       len(c.Apps.Lidarr),
-			"plex":         numPlex,
+			"overseerr":    numOverseerr,
+			"plex":         len(c.Apps.Plex),
 			"prowlarr":     len(c.Apps.Prowlarr),
 			"qbit":         len(c.Apps.Qbit),
 			"rtorrent":     len(c.Apps.Rtorrent),
@@ -161,6 +172,7 @@ func (c *Config) Info(ctx context.Context, startup bool) *AppInfo { //nolint:fun
 			"tautulli":     numTautulli,
 			"sabnzbd":      len(c.Apps.SabNZB),
 			"sonarr":       len(c.Apps.Sonarr),
+			"whisparr":     len(c.Apps.Whisparr),
 		},
 		Config: AppInfoConfig{
 			WebsiteTimeout: c.Server.Config.Timeout.String(),
@@ -202,6 +214,10 @@ func (c *Config) getAppConfigs(ctx context.Context, startup bool) *AppConfigs {
 		apps.Sonarr = append(apps.Sonarr, add(i, app.Name))
 	}
 
+	for i, app := range c.Apps.Whisparr {
+		apps.Whisparr = append(apps.Whisparr, add(i, app.Name))
+	}
+
 	if !startup {
 		if u, err := c.tautulliUsers(ctx); err != nil {
 			c.Error("Getting Tautulli Users:", err)
@@ -0,0 +1,91 @@
+package clientinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/Notifiarr/notifiarr/pkg/triggers/data"
+)
+
+// CacheFileName is the name of the file the last good website response is saved to.
+// It lives in the same folder as the config file.
+const CacheFileName = "clientinfo.cache.json"
+
+// StaleRefresh is how often we ask the website for fresh client info while running on a cached copy.
+const StaleRefresh = 2 * time.Minute
+
+// diskCache is the format of the client info cache file.
+type diskCache struct {
+	Saved    time.Time       `json:"saved"`
+	Response json.RawMessage `json:"response"`
+}
+
+// Stale returns true if this client info was loaded from the disk cache instead of the website.
+func (c *ClientInfo) Stale() bool {
+	return c != nil && !c.StaleSince.IsZero()
+}
+
+// saveCache writes the raw website response to disk so it can be used if the website is down on startup.
+// The file may contain private data, so it is only readable by the user running this app.
+func (c *Config) saveCache(response []byte) error {
+	if c.CacheFile == "" {
+		return nil
+	}
+
+	payload, err := json.Marshal(&diskCache{Saved: time.Now(), Response: response})
+	if err != nil {
+		return fmt.Errorf("encoding client info cache: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.CacheFile), mnd.Mode0750); err != nil {
+		return fmt.Errorf("making client info cache dir: %w", err)
+	}
+
+	// Write a temp file and rename it, so a crash mid-write does not destroy a good cache.
+	tmpFile := c.CacheFile + ".tmp"
+	if err := os.WriteFile(tmpFile, payload, mnd.Mode0600); err != nil {
+		return fmt.Errorf("writing client info cache: %w", err)
+	}
+
+	if err := os.Chmod(tmpFile, mnd.Mode0600); err != nil { // WriteFile does not change existing file modes.
+		return fmt.Errorf("securing client info cache: %w", err)
+	}
+
+	if err := os.Rename(tmpFile, c.CacheFile); err != nil {
+		return fmt.Errorf("moving client info cache into place: %w", err)
+	}
+
+	return nil
+}
+
+// LoadCachedClientInfo reads the last good client info from disk and makes it available with Get().
+// The returned client info is marked stale with the time it was originally received from the website.
+func (c *Config) LoadCachedClientInfo() (*ClientInfo, error) {
+	if c.CacheFile == "" {
+		return nil, ErrNoCacheFile
+	}
+
+	payload, err := os.ReadFile(c.CacheFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading client info cache: %w", err)
+	}
+
+	var cache diskCache
+	if err := json.Unmarshal(payload, &cache); err != nil {
+		return nil, fmt.Errorf("decoding client info cache: %w", err)
+	}
+
+	clientInfo := ClientInfo{}
+	if err := json.Unmarshal(cache.Response, &clientInfo); err != nil {
+		return nil, fmt.Errorf("parsing cached client info: %w", err)
+	}
+
+	clientInfo.StaleSince = cache.Saved
+	data.Save("clientInfo", &clientInfo)
+
+	return &clientInfo, nil
+}
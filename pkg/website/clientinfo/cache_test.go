@@ -0,0 +1,48 @@
+package clientinfo //nolint:testpackage // saveCache is not exported.
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{CacheFile: filepath.Join(t.TempDir(), "sub", CacheFileName)}
+	require.NoError(t, config.saveCache([]byte(`{"user":{"welcome":"hello there"}}`)))
+
+	info, err := os.Stat(config.CacheFile)
+	require.NoError(t, err, "the cache file must be written")
+	assert.Equal(t, os.FileMode(mnd.Mode0600), info.Mode().Perm(), "the cache file may contain secrets")
+
+	clientInfo, err := config.LoadCachedClientInfo()
+	require.NoError(t, err)
+	assert.Equal(t, "hello there", clientInfo.User.WelcomeMSG)
+	assert.True(t, clientInfo.Stale(), "cached client info must be marked stale")
+	assert.WithinDuration(t, time.Now(), clientInfo.StaleSince, time.Minute)
+}
+
+func TestCacheErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := (&Config{}).LoadCachedClientInfo()
+	require.ErrorIs(t, err, ErrNoCacheFile)
+	require.NoError(t, (&Config{}).saveCache([]byte("{}")), "an empty cache file path must not error")
+
+	config := &Config{CacheFile: filepath.Join(t.TempDir(), CacheFileName)}
+	_, err = config.LoadCachedClientInfo()
+	require.Error(t, err, "a missing cache file must return an error")
+
+	require.NoError(t, os.WriteFile(config.CacheFile, []byte("not json"), mnd.Mode0600))
+	_, err = config.LoadCachedClientInfo()
+	require.Error(t, err, "a broken cache file must return an error")
+
+	assert.False(t, (*ClientInfo)(nil).Stale())
+	assert.False(t, (&ClientInfo{}).Stale())
+}
@@ -3,7 +3,9 @@ package clientinfo
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/Notifiarr/notifiarr/pkg/mnd"
 	"github.com/Notifiarr/notifiarr/pkg/snapshot"
@@ -49,8 +51,14 @@ type ClientInfo struct {
 		Snapshot  snapshot.Config `json:"snapshot"`  // Site Config for System Snapshot.
 	} `json:"actions"`
 	IntegrityCheck bool `json:"integrityCheck"`
+	// StaleSince is set when this data came from the disk cache because the website was unreachable.
+	// The time is when the cached copy was originally received from the website.
+	StaleSince time.Time `json:"-"`
 }
 
+// ErrNoCacheFile is returned when the client info disk cache is not configured.
+var ErrNoCacheFile = errors.New("client info cache file path is not configured")
+
 // MuleryServer is data from the website. It's a tunnel's https and wss urls.
 type MuleryServer struct {
 	Tunnel   string `json:"tunnel"`   // ex: "https://africa.notifiarr.com/"
@@ -87,7 +95,8 @@ type MdbListConfig struct {
 
 // DashConfig is the configuration returned from the notifiarr website for the dashboard configuration.
 type DashConfig struct {
-	Interval cnfg.Duration `json:"interval"` // how often to fire.
+	Interval     cnfg.Duration `json:"interval"`     // how often to fire.
+	TorrentStats bool          `json:"torrentStats"` // send tracker and category breakdowns.
 }
 
 // AppConfig is the data that comes from the website for each Starr app.
@@ -111,6 +120,7 @@ type AllAppConfigs struct {
 	Radarr   InstanceConfig `json:"radarr"`
 	Readarr  InstanceConfig `json:"readarr"`
 	Sonarr   InstanceConfig `json:"sonarr"`
+	Whisparr InstanceConfig `json:"whisparr"`
 }
 
 // PlexConfig is the website-derived configuration for Plex.
@@ -151,6 +161,10 @@ func (c *ClientInfo) String() string {
 		return "<nil>"
 	}
 
+	if c.Stale() {
+		return c.User.WelcomeMSG + " (stale since " + c.StaleSince.Round(time.Second).String() + ")"
+	}
+
 	return c.User.WelcomeMSG
 }
 
@@ -184,6 +198,10 @@ func (c *Config) SaveClientInfo(ctx context.Context, startup bool) (*ClientInfo,
 	// Only set this if there was no error.
 	data.Save("clientInfo", &clientInfo)
 
+	if err := c.saveCache(body.Details.Response); err != nil {
+		c.Errorf("Saving client info cache: %v", err)
+	}
+
 	return &clientInfo, nil
 }
 
@@ -57,6 +57,12 @@ type SonarrConTest struct {
 	Status *sonarr.SystemStatus `json:"systemStatus,omitempty"`
 }
 
+// WhisparrConTest contains information about connected Whisparrs.
+type WhisparrConTest struct {
+	conTest
+	Status *radarr.SystemStatus `json:"systemStatus,omitempty"`
+}
+
 // ProwlarrConTest contains information about connected Prowlarrs.
 type ProwlarrConTest struct {
 	conTest
@@ -94,6 +100,7 @@ type AppStatuses struct {
 	Radarr   []*RadarrConTest   `json:"radarr,omitempty"`
 	Readarr  []*ReadarrConTest  `json:"readarr,omitempty"`
 	Sonarr   []*SonarrConTest   `json:"sonarr,omitempty"`
+	Whisparr []*WhisparrConTest `json:"whisparr,omitempty"`
 	Prowlarr []*ProwlarrConTest `json:"prowlarr,omitempty"`
 	Plex     []*PlexConTest     `json:"plex,omitempty"`
 	Tautulli []*TautulliConTest `json:"tautulli,omitempty"`
@@ -136,7 +143,7 @@ func (c *Config) VersionHandler(r *http.Request) (int, interface{}) {
 // @Summary      Retrieve client info + 1 app's info.
 // @Tags         Client
 // @Produce      json
-// @Param        app      path string  true  "Application" Enums(lidarr, prowlarr, radarr, readarr, sonarr, plex, tautulli)
+// @Param        app      path string  true  "Application" Enums(lidarr, prowlarr, radarr, readarr, sonarr, whisparr, plex, tautulli)
 // @Param        instance path int64   true  "Application instance (1-index)."
 // @Success      200  {object} apps.Respond.apiResponse{message=AppInfo} "contains app info included appStatus"
 // @Failure      404  {object} string "bad token or api key"
@@ -158,16 +165,18 @@ func (c *Config) appStatsForVersion(ctx context.Context) *AppStatuses {
 		rad  = make([]*RadarrConTest, len(c.Apps.Radarr))
 		read = make([]*ReadarrConTest, len(c.Apps.Readarr))
 		son  = make([]*SonarrConTest, len(c.Apps.Sonarr))
-		plx  = []*PlexConTest{}
+		whis = make([]*WhisparrConTest, len(c.Apps.Whisparr))
+		plx  = make([]*PlexConTest, len(c.Apps.Plex))
 		wait sync.WaitGroup
 	)
 
-	c.getPlexVersion(ctx, &wait, c.Apps.Plex, &plx)
+	c.getPlexVersion(ctx, &wait, c.Apps.Plex, plx)
 	c.getLidarrVersion(ctx, &wait, c.Apps.Lidarr, lid)
 	c.getProwlarrVersion(ctx, &wait, c.Apps.Prowlarr, prl)
 	c.getRadarrVersion(ctx, &wait, c.Apps.Radarr, rad)
 	c.getReadarrVersion(ctx, &wait, c.Apps.Readarr, read)
 	c.getSonarrVersion(ctx, &wait, c.Apps.Sonarr, son)
+	c.getWhisparrVersion(ctx, &wait, c.Apps.Whisparr, whis)
 	wait.Wait()
 
 	return &AppStatuses{
@@ -175,6 +184,7 @@ func (c *Config) appStatsForVersion(ctx context.Context) *AppStatuses {
 		Radarr:   rad,
 		Readarr:  read,
 		Sonarr:   son,
+		Whisparr: whis,
 		Prowlarr: prl,
 		Plex:     plx,
 	}
@@ -236,6 +246,23 @@ func (c *Config) appStatsForVersionInstance(ctx context.Context, app string, ins
 		return &AppStatuses{Sonarr: []*SonarrConTest{{
 			conTest: conTest{Instance: instance, Up: false, Name: c.Apps.Sonarr[idx].Name, Error: mnd.ErrDisabledInstance.Error()},
 		}}}
+	case "whisparr":
+		if idx < 0 || idx >= len(c.Apps.Whisparr) {
+			return &AppStatuses{Whisparr: []*WhisparrConTest{{
+				conTest: conTest{Instance: instance, Up: false, Error: mnd.ErrDisabledInstance.Error()},
+			}}}
+		}
+
+		if c.Apps.Whisparr[idx].Enabled() {
+			stat, err := c.Apps.Whisparr[idx].GetSystemStatusContext(ctx)
+			data.SaveWithID(app+mnd.Status, idx, stat)
+
+			return &AppStatuses{Whisparr: []*WhisparrConTest{{c.getConTest(app, c.Apps.Whisparr[idx].Name, instance, err), stat}}}
+		}
+
+		return &AppStatuses{Whisparr: []*WhisparrConTest{{
+			conTest: conTest{Instance: instance, Up: false, Name: c.Apps.Whisparr[idx].Name, Error: mnd.ErrDisabledInstance.Error()},
+		}}}
 	case "prowlarr":
 		if instance <= len(c.Apps.Prowlarr) && c.Apps.Prowlarr[idx].Enabled() {
 			stat, err := c.Apps.Prowlarr[idx].GetSystemStatusContext(ctx)
@@ -248,10 +275,14 @@ func (c *Config) appStatsForVersionInstance(ctx context.Context, app string, ins
 			conTest: conTest{Instance: instance, Up: false, Name: c.Apps.Prowlarr[idx].Name, Error: mnd.ErrDisabledInstance.Error()},
 		}}}
 	case "plex":
-		stat := &AppStatuses{Plex: c.plexVersionReply(c.Apps.Plex.GetInfo(ctx))}
-		stat.Plex[0].Name = c.Apps.Plex.Server.Name()
+		if instance > 0 && instance <= len(c.Apps.Plex) && c.Apps.Plex[idx].Enabled() {
+			stat, err := c.Apps.Plex[idx].GetInfo(ctx)
+			return &AppStatuses{Plex: []*PlexConTest{c.plexVersionReply(idx, stat, err)}}
+		}
 
-		return stat
+		return &AppStatuses{Plex: []*PlexConTest{{
+			conTest: conTest{Instance: instance, Up: false, Error: mnd.ErrDisabledInstance.Error()},
+		}}}
 	case "tautulli":
 		if !c.Apps.Tautulli.Enabled() {
 			return &AppStatuses{Tautulli: []*TautulliConTest{{
@@ -378,27 +409,56 @@ func (c *Config) getSonarrVersion(ctx context.Context, wait *sync.WaitGroup, son
 	}
 }
 
-func (c *Config) getPlexVersion(ctx context.Context, wait *sync.WaitGroup, plexServer *apps.PlexConfig, plx *[]*PlexConTest) {
-	if !plexServer.Enabled() {
-		return
+func (c *Config) getWhisparrVersion(ctx context.Context, wait *sync.WaitGroup, whisparrs []*apps.WhisparrConfig, whis []*WhisparrConTest) {
+	for idx, app := range whisparrs {
+		whis[idx] = &WhisparrConTest{conTest: conTest{Instance: idx + 1, Up: false, Name: app.Name}}
+
+		if !app.Enabled() {
+			whis[idx].Error = mnd.ErrDisabledInstance.Error()
+			continue
+		}
+
+		wait.Add(1)
+
+		go func(idx int, app *apps.WhisparrConfig) {
+			defer wait.Done()
+
+			stat, err := app.GetSystemStatusContext(ctx)
+			data.SaveWithID("whisparrStatus", idx, stat)
+
+			whis[idx] = &WhisparrConTest{conTest: c.getConTest("Whisparr", app.Name, idx+1, err), Status: stat}
+		}(idx, app)
 	}
+}
 
-	wait.Add(1)
+func (c *Config) getPlexVersion(ctx context.Context, wait *sync.WaitGroup, plexServers apps.PlexServers, plx []*PlexConTest) {
+	for idx, app := range plexServers {
+		plx[idx] = &PlexConTest{conTest: conTest{Instance: idx + 1, Up: false, Name: app.Server.Name()}}
 
-	go func() {
-		defer wait.Done()
-		*plx = c.plexVersionReply(plexServer.GetInfo(ctx)) //nolint:wsl
-	}()
+		if !app.Enabled() {
+			plx[idx].Error = mnd.ErrDisabledInstance.Error()
+			continue
+		}
+
+		wait.Add(1)
+
+		go func(idx int, app *apps.PlexConfig) {
+			defer wait.Done()
+
+			stat, err := app.GetInfo(ctx)
+			plx[idx] = c.plexVersionReply(idx, stat, err)
+		}(idx, app)
+	}
 }
 
-func (c *Config) plexVersionReply(stat *plex.PMSInfo, err error) []*PlexConTest {
+func (c *Config) plexVersionReply(idx int, stat *plex.PMSInfo, err error) *PlexConTest {
 	if stat == nil {
 		stat = &plex.PMSInfo{}
 	} else {
-		data.Save("plexStatus", stat)
+		data.SaveWithID("plexStatus", idx, stat)
 	}
 
-	return []*PlexConTest{{
+	return &PlexConTest{
 		&PlexInfo{
 			FriendlyName:       stat.FriendlyName,
 			Version:            stat.Version,
@@ -410,6 +470,6 @@ func (c *Config) plexVersionReply(stat *plex.PMSInfo, err error) []*PlexConTest
 			MyPlexSubscription: stat.MyPlexSubscription,
 			PushNotifications:  stat.PushNotifications,
 		},
-		c.getConTest("Plex", stat.FriendlyName, 1, err),
-	}}
+		c.getConTest("Plex", stat.FriendlyName, idx+1, err),
+	}
 }
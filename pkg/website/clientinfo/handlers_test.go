@@ -0,0 +1,36 @@
+package clientinfo //nolint:testpackage // appStatsForVersionInstance is not exported.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Notifiarr/notifiarr/pkg/apps"
+	"github.com/Notifiarr/notifiarr/pkg/mnd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWhisparrVersionInstance(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{Apps: &apps.Apps{Whisparr: []*apps.WhisparrConfig{{ExtraConfig: apps.ExtraConfig{Name: "whis"}}}}}
+
+	tests := []struct {
+		instance int
+		name     string
+	}{
+		{instance: 1, name: "whis"},
+		{instance: 2, name: ""},
+		{instance: 0, name: ""},
+		{instance: -1, name: ""},
+	}
+
+	for _, test := range tests {
+		stats := config.appStatsForVersionInstance(context.Background(), "whisparr", test.instance)
+		require.NotNil(t, stats, test.instance)
+		require.Len(t, stats.Whisparr, 1, test.instance)
+		assert.Equal(t, test.instance, stats.Whisparr[0].Instance)
+		assert.Equal(t, test.name, stats.Whisparr[0].Name)
+		assert.Equal(t, mnd.ErrDisabledInstance.Error(), stats.Whisparr[0].Error)
+	}
+}
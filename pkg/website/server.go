@@ -20,7 +20,7 @@ const (
 	// DefaultRetries is the number of times to attempt a request to notifiarr.com.
 	// 4 means 5 total tries: 1 try + 4 retries.
 	DefaultRetries = 4
-	// RetryDelay is how long to Sleep between retries.
+	// RetryDelay is how long to Sleep before the first retry. It doubles for each retry after that.
 	RetryDelay = 222 * time.Millisecond
 	// APIKeyLength is the string length of a valid notifiarr API key.
 	APIKeyLength = 36
@@ -32,6 +32,7 @@ var (
 	ErrInvalidResponse = errors.New("invalid response")
 	ErrNoChannel       = errors.New("the website send-data channel is closed")
 	ErrInvalidAPIKey   = errors.New("configured notifiarr API key is invalid")
+	ErrCircuitOpen     = errors.New("website circuit breaker is open")
 )
 
 // Config is the input data needed to send payloads to notifiarr.
@@ -72,6 +73,7 @@ func New(config *Config) *Server {
 			Retries: config.Retries,
 			Logger:  config.Logger,
 			Client:  &http.Client{},
+			breaker: newBreaker(),
 		},
 		hostInfo:     nil, // must start nil
 		sendData:     make(chan *Request, mnd.Kilobyte),
@@ -22,6 +22,7 @@ type httpClient struct {
 	Retries int
 	mnd.Logger
 	*http.Client
+	breaker *breaker
 }
 
 func (s *Server) ValidAPIKey() error {
@@ -191,9 +192,18 @@ func (s *Server) createFileUpload(file *UploadFile) (*bytes.Buffer, string, erro
 }
 
 // Do performs an http Request with retries and logging!
-func (h *httpClient) Do(req *http.Request) (*http.Response, error) { //nolint:cyclop
+// Requests fail fast with ErrCircuitOpen while the website appears to be down.
+func (h *httpClient) Do(req *http.Request) (*http.Response, error) { //nolint:cyclop,funlen
 	req.Header.Set("User-Agent", fmt.Sprintf("%s v%s-%s %s", mnd.Title, version.Version, version.Revision, version.Branch))
 
+	probe, err := h.breaker.allow()
+	if err != nil {
+		return nil, err
+	} else if probe {
+		// A probe that ends without a success or failure (canceled) must not block the next probe.
+		defer h.breaker.done()
+	}
+
 	deadline, ok := req.Context().Deadline()
 	if !ok {
 		deadline = time.Now().Add(h.Timeout)
@@ -213,6 +223,11 @@ func (h *httpClient) Do(req *http.Request) (*http.Response, error) { //nolint:cy
 			if resp.StatusCode < http.StatusInternalServerError &&
 				(resp.StatusCode != http.StatusBadRequest || resp.Header.Get("Content-Type") != "text/html") {
 				mnd.Website.Add(req.Method+mnd.BytesSent, resp.Request.ContentLength)
+
+				if h.breaker.success() {
+					h.Printf("==> Website is responding again, circuit breaker closed.")
+				}
+
 				return resp, nil
 			}
 
@@ -227,19 +242,40 @@ func (h *httpClient) Do(req *http.Request) (*http.Response, error) { //nolint:cy
 			err = fmt.Errorf("%w: %s: %d bytes, %s", ErrNon200, req.URL, size, resp.Status)
 		}
 
-		switch {
-		case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		if errors.Is(err, context.Canceled) {
+			// The caller gave up; that says nothing about the website, so the breaker is not updated.
 			if retry == 0 {
-				return resp, fmt.Errorf("website req timed out after %s: %s: %w", timeout, req.URL, err)
+				return resp, fmt.Errorf("website req canceled: %s: %w", req.URL, err)
 			}
 
+			return resp, fmt.Errorf("[%d/%d] website req canceled, giving up: %w", retry+1, h.Retries+1, err)
+		}
+
+		timedOut := errors.Is(err, context.DeadlineExceeded)
+		if !timedOut && retry < h.Retries && !probe {
+			delay := retryDelay(retry)
+			h.ErrorfNoShare("[%d/%d] website req failed, retrying in %s, error: %v",
+				retry+1, h.Retries+1, delay.Round(time.Millisecond), err)
+			time.Sleep(delay)
+
+			continue
+		}
+
+		// The request is out of retries, so it counts as one failure, no matter how many attempts it made.
+		if wait := h.breaker.failure(); wait > 0 {
+			h.ErrorfNoShare("==> Website requests failing, circuit breaker open for %s. Last error: %v",
+				wait.Round(time.Second), err)
+			return resp, fmt.Errorf("[%d/%d] website req failed, circuit breaker opened: %w", retry+1, h.Retries+1, err)
+		}
+
+		switch {
+		case timedOut && retry == 0:
+			return resp, fmt.Errorf("website req timed out after %s: %s: %w", timeout, req.URL, err)
+		case timedOut:
 			return resp, fmt.Errorf("[%d/%d] website req timed out after %s, giving up: %w",
 				retry+1, h.Retries+1, timeout, err)
-		case retry == h.Retries:
-			return resp, fmt.Errorf("[%d/%d] website req failed: %w", retry+1, h.Retries+1, err)
 		default:
-			h.ErrorfNoShare("[%d/%d] website req failed, retrying in %s, error: %v", retry+1, h.Retries+1, RetryDelay, err)
-			time.Sleep(RetryDelay)
+			return resp, fmt.Errorf("[%d/%d] website req failed: %w", retry+1, h.Retries+1, err)
 		}
 	}
 }
@@ -308,6 +344,10 @@ func (s *Server) watchSendDataChan(ctx context.Context) {
 		switch resp, elapsed, err := s.sendRequest(ctx, data); {
 		case data.LogMsg == "", errors.Is(err, ErrInvalidAPIKey):
 			continue
+		case errors.Is(err, ErrCircuitOpen):
+			// The breaker logged when it opened; don't flood the log with every skipped request.
+			s.Config.Debugf("[%s requested] Not sending (buf=%d/%d): %s: %v",
+				data.Event, len(s.sendData), cap(s.sendData), data.LogMsg, err)
 		case errors.Is(err, ErrNon200):
 			s.Config.ErrorfNoShare("[%s requested] Sending (%v, buf=%d/%d): %s: %v%s",
 				data.Event, elapsed, len(s.sendData), cap(s.sendData), data.LogMsg, err, resp)
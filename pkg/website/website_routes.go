@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/jellyfin"
 	"github.com/Notifiarr/notifiarr/pkg/apps/apppkg/plex"
 	"github.com/Notifiarr/notifiarr/pkg/mnd"
 	"github.com/Notifiarr/notifiarr/pkg/snapshot"
@@ -41,6 +42,13 @@ type Payload struct {
 	Load *plex.IncomingWebhook `json:"payload,omitempty"`
 }
 
+// JellyfinPayload is the outbound payload structure that is sent to Notifiarr for Jellyfin (and Emby) data.
+type JellyfinPayload struct {
+	Jellyfin *jellyfin.Sessions        `json:"jellyfin,omitempty"`
+	Snap     *snapshot.Snapshot        `json:"snapshot,omitempty"`
+	Load     *jellyfin.IncomingWebhook `json:"payload,omitempty"`
+}
+
 // Request is used when sending data through a channel.
 type Request struct {
 	Route      Route
@@ -129,7 +137,13 @@ const (
 	DashRoute     Route = notifiRoute + "/dashboard"
 	StuckRoute    Route = notifiRoute + "/stuck"
 	DownloadRoute Route = notifiRoute + "/downloads"
+	FailedRoute   Route = notifiRoute + "/failedDownloads"
+	DiskRoute     Route = notifiRoute + "/diskGuard"
+	OrphanRoute   Route = notifiRoute + "/orphanedDownloads"
 	PlexRoute     Route = notifiRoute + "/plex"
+	PolicyRoute   Route = notifiRoute + "/plexPolicy"
+	JellyRoute    Route = notifiRoute + "/jellyfin"
+	SeerrRoute    Route = notifiRoute + "/overseerr"
 	SnapRoute     Route = notifiRoute + "/snapshot"
 	SvcRoute      Route = notifiRoute + "/services"
 	CorruptRoute  Route = notifiRoute + "/corruption"